@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/BYTE-6D65/pipeline/pkg/engine"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
 )
 
 func main() {
@@ -25,22 +26,14 @@ func main() {
 
 	// Create engine with AIMD + RED enabled
 	log.Println("Creating engine with AIMD governor and RED dropper...")
-	eng, err := engine.NewWithConfig(cfg)
+	// Print control loop events to stdout
+	eng, err := engine.NewWithConfig(cfg,
+		engine.WithErrorSink(event.NewConsoleSink(os.Stdout, true),
+			event.WithSinkFilter(event.ErrorFilter{Components: []string{"control-lab*"}})))
 	if err != nil {
 		log.Fatalf("Failed to create engine: %v", err)
 	}
 
-	// Subscribe to error bus
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	sub, err := eng.ErrorBus().Subscribe(ctx)
-	if err != nil {
-		log.Fatalf("Failed to subscribe to error bus: %v", err)
-	}
-
-	log.Printf("Subscribed to error bus (ID: %s)", sub.ID())
-
 	// Print initial state
 	log.Println("\nInitial state:")
 	if limit, src, ok := engine.DetectMemoryLimit(); ok {
@@ -56,26 +49,6 @@ func main() {
 	// Start governor state printer
 	go printGovernorState(eng)
 
-	// Start error event printer
-	go func() {
-		for evt := range sub.Events() {
-			// Print control loop events
-			if evt.Component == "control-lab" || evt.Component == "control-lab:governor" {
-				fmt.Printf("\n[CONTROL EVENT] %s\n", time.Now().Format("15:04:05.000"))
-				fmt.Printf("  Severity:    %s\n", evt.Severity)
-				fmt.Printf("  Code:        %s\n", evt.Code)
-				fmt.Printf("  Message:     %s\n", evt.Message)
-				if len(evt.Context) > 0 {
-					fmt.Printf("  Context:\n")
-					for k, v := range evt.Context {
-						fmt.Printf("    %s: %v\n", k, v)
-					}
-				}
-				fmt.Println()
-			}
-		}
-	}()
-
 	// Allocate memory in chunks to trigger governor
 	log.Println("\nStarting memory stress test...")
 	log.Println("Allocating memory in 10MB chunks every 2 seconds...")
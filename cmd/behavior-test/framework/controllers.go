@@ -0,0 +1,51 @@
+package framework
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/engine"
+)
+
+// ControllerFactory builds the controller under test from the test's config.
+type ControllerFactory func(cfg engine.Config) engine.Controller
+
+// controllers are the control laws the suite can run against, by name.
+var controllers = map[string]ControllerFactory{
+	"aimd": func(cfg engine.Config) engine.Controller {
+		return engine.NewAIMDGovernorFromConfig(clock.NewSystemClock(), cfg).AsController()
+	},
+	"pid": func(cfg engine.Config) engine.Controller {
+		return engine.NewPIDControllerFromConfig(clock.NewSystemClock(), cfg)
+	},
+}
+
+// activeController is used by test cases created after UseController.
+var activeController = "aimd"
+
+// RegisterController makes a controller available to UseController.
+func RegisterController(name string, factory ControllerFactory) {
+	controllers[name] = factory
+}
+
+// ControllerNames returns the registered controller names, sorted.
+func ControllerNames() []string {
+	names := make([]string, 0, len(controllers))
+	for name := range controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UseController selects the controller for test cases created afterwards.
+// Build a fresh test registry after each call; test cases keep the
+// controller they were created with.
+func UseController(name string) error {
+	if _, ok := controllers[name]; !ok {
+		return fmt.Errorf("unknown controller %q (available: %v)", name, ControllerNames())
+	}
+	activeController = name
+	return nil
+}
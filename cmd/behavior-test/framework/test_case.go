@@ -129,18 +129,21 @@ func (r *TestResult) String() string {
 // BaseTestCase provides common functionality for tests.
 // Embed this in your test implementations.
 type BaseTestCase struct {
-	engine      *engine.Engine
-	config      engine.Config
-	memoryLimit uint64
-	result      *TestResult
-	ctx         context.Context
-	cancel      context.CancelFunc
+	engine         *engine.Engine
+	config         engine.Config
+	controllerName string
+	memoryLimit    uint64
+	result         *TestResult
+	ctx            context.Context
+	cancel         context.CancelFunc
 }
 
-// NewBaseTestCase creates a new base test case.
+// NewBaseTestCase creates a new base test case that runs against the
+// controller selected by UseController.
 func NewBaseTestCase() *BaseTestCase {
 	return &BaseTestCase{
-		result: NewTestResult("", ""),
+		controllerName: activeController,
+		result:         NewTestResult("", ""),
 	}
 }
 
@@ -149,6 +152,11 @@ func (b *BaseTestCase) Engine() *engine.Engine {
 	return b.engine
 }
 
+// ControllerName returns the name of the controller under test.
+func (b *BaseTestCase) ControllerName() string {
+	return b.controllerName
+}
+
 // Result returns the test result.
 func (b *BaseTestCase) Result() *TestResult {
 	return b.result
@@ -164,7 +172,8 @@ func (b *BaseTestCase) Context() context.Context {
 	return b.ctx
 }
 
-// SetupEngine creates an engine with the given config.
+// SetupEngine creates an engine with the given config, running the
+// controller under test.
 func (b *BaseTestCase) SetupEngine(ctx context.Context, cfg engine.Config) error {
 	b.ctx, b.cancel = context.WithCancel(ctx)
 
@@ -177,11 +186,16 @@ func (b *BaseTestCase) SetupEngine(ctx context.Context, cfg engine.Config) error
 	b.config = cfg
 
 	// Create engine
-	eng, err := engine.NewWithConfig(cfg)
+	factory, ok := controllers[b.controllerName]
+	if !ok {
+		return fmt.Errorf("unknown controller %q", b.controllerName)
+	}
+	eng, err := engine.NewWithConfig(cfg, engine.WithController(factory(cfg)))
 	if err != nil {
 		return fmt.Errorf("failed to create engine: %w", err)
 	}
 	b.engine = eng
+	b.result.AddMetric("controller", b.controllerName)
 
 	return nil
 }
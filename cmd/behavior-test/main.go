@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -24,6 +25,7 @@ func main() {
 		verbose    = flag.Bool("verbose", false, "Verbose output (detailed results)")
 		reportType = flag.String("report", "summary", "Report type: summary, detailed, json, markdown")
 		timeout    = flag.Duration("timeout", 10*time.Minute, "Timeout per test")
+		controller = flag.String("controller", "aimd", "Controller to test, or \"all\" ("+strings.Join(framework.ControllerNames(), ", ")+")")
 	)
 	flag.Parse()
 
@@ -34,11 +36,25 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Build test registry
-	testRegistry := buildTestRegistry()
+	// Controllers to run the scenarios against
+	controllerNames := []string{*controller}
+	if *controller == "all" {
+		controllerNames = framework.ControllerNames()
+	}
 
-	// Filter tests based on flags
-	testsToRun := filterTests(testRegistry, *runAll, *category, *testName)
+	// Build a test registry per controller (test cases hold their state)
+	var testsToRun []framework.TestCase
+	var testControllers []string
+	for _, name := range controllerNames {
+		if err := framework.UseController(name); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		for _, test := range filterTests(buildTestRegistry(), *runAll, *category, *testName) {
+			testsToRun = append(testsToRun, test)
+			testControllers = append(testControllers, name)
+		}
+	}
 
 	if len(testsToRun) == 0 {
 		fmt.Println("No tests match the specified criteria")
@@ -62,7 +78,7 @@ func main() {
 
 	// Run tests
 	fmt.Printf("=== %s ===\n\n", suiteName)
-	fmt.Printf("Running %d test(s)...\n\n", len(testsToRun))
+	fmt.Printf("Running %d test(s) against %s...\n\n", len(testsToRun), strings.Join(controllerNames, ", "))
 
 	for i, test := range testsToRun {
 		if ctx.Err() != nil {
@@ -70,9 +86,10 @@ func main() {
 			break
 		}
 
-		fmt.Printf("[%d/%d] Running: %s...\n", i+1, len(testsToRun), test.Name())
+		fmt.Printf("[%d/%d] Running: %s [%s]...\n", i+1, len(testsToRun), test.Name(), testControllers[i])
 
 		result := runTest(ctx, test, *timeout)
+		result.TestName += " [" + testControllers[i] + "]"
 		report.AddResult(result)
 
 		// Print result immediately
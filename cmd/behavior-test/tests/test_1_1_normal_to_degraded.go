@@ -90,7 +90,7 @@ func (t *Test11NormalToDegraded) Run(ctx context.Context) error {
 		}
 
 		// Check if DEGRADED reached
-		if t.Engine().Governor().State() == engine.StateDegraded {
+		if t.Engine().Controller().State() == engine.StateDegraded {
 			t.degradedTime = time.Now()
 			t.Metric("degraded_time", t.degradedTime)
 			break
@@ -141,7 +141,7 @@ func (t *Test11NormalToDegraded) Validate() *framework.TestResult {
 	result.TestName = t.Name()
 	result.Category = t.Category()
 
-	gov := t.Engine().Governor()
+	gov := t.Engine().Controller()
 
 	// Assertion 1: DEGRADED state reached
 	framework.AssertStateEquals(t.BaseTestCase, "Governor entered DEGRADED state", engine.StateDegraded, gov.State())
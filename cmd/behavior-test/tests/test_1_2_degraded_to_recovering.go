@@ -86,8 +86,8 @@ func (t *Test12DegradedToRecovering) Run(ctx context.Context) error {
 		default:
 		}
 
-		if t.Engine().Governor().State() == engine.StateDegraded {
-			t.degradedScale = t.Engine().Governor().Scale()
+		if t.Engine().Controller().State() == engine.StateDegraded {
+			t.degradedScale = t.Engine().Controller().Scale()
 			t.Metric("degraded_scale", t.degradedScale)
 			break
 		}
@@ -130,7 +130,7 @@ func (t *Test12DegradedToRecovering) Run(ctx context.Context) error {
 		default:
 		}
 
-		if t.Engine().Governor().State() == engine.StateRecovering {
+		if t.Engine().Controller().State() == engine.StateRecovering {
 			t.recoveringTime = time.Now()
 			t.Metric("recovering_time", t.recoveringTime)
 			break
@@ -159,7 +159,7 @@ func (t *Test12DegradedToRecovering) Validate() *framework.TestResult {
 	result.TestName = t.Name()
 	result.Category = t.Category()
 
-	gov := t.Engine().Governor()
+	gov := t.Engine().Controller()
 
 	// Assertion 1: RECOVERING state reached
 	framework.AssertStateEquals(t.BaseTestCase, "Governor entered RECOVERING state", engine.StateRecovering, gov.State())
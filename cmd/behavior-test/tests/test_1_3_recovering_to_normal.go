@@ -94,7 +94,7 @@ func (t *Test13RecoveringToNormal) Run(ctx context.Context) error {
 		default:
 		}
 
-		if t.Engine().Governor().State() == engine.StateDegraded {
+		if t.Engine().Controller().State() == engine.StateDegraded {
 			break
 		}
 
@@ -127,7 +127,7 @@ func (t *Test13RecoveringToNormal) Run(ctx context.Context) error {
 		default:
 		}
 
-		if t.Engine().Governor().State() == engine.StateRecovering {
+		if t.Engine().Controller().State() == engine.StateRecovering {
 			break
 		}
 
@@ -138,7 +138,7 @@ func (t *Test13RecoveringToNormal) Run(ctx context.Context) error {
 	// This can take ~5 minutes (10 steps × 30s from 50% → 100%)
 	// But for testing, we'll monitor for 2.5 minutes and verify at least 4-5 increases
 	recoveryDeadline := time.Now().Add(150 * time.Second)
-	lastScale := t.Engine().Governor().Scale()
+	lastScale := t.Engine().Controller().Scale()
 	lastCheckTime := time.Now()
 
 	for time.Now().Before(recoveryDeadline) {
@@ -148,8 +148,8 @@ func (t *Test13RecoveringToNormal) Run(ctx context.Context) error {
 		default:
 		}
 
-		currentScale := t.Engine().Governor().Scale()
-		currentState := t.Engine().Governor().State()
+		currentScale := t.Engine().Controller().Scale()
+		currentState := t.Engine().Controller().State()
 
 		// Check if scale increased
 		if currentScale > lastScale+0.01 { // Account for float precision
@@ -197,7 +197,7 @@ func (t *Test13RecoveringToNormal) Validate() *framework.TestResult {
 	result.TestName = t.Name()
 	result.Category = t.Category()
 
-	gov := t.Engine().Governor()
+	gov := t.Engine().Controller()
 
 	// Assertion 1: At least 4 scale increases observed
 	framework.AssertCountGreaterThan(t.BaseTestCase, "At least 4 scale increases", 3, len(t.scaleIncreases))
@@ -99,7 +99,7 @@ func (t *Test21PanicSawPrevention) Run(ctx context.Context) error {
 		default:
 		}
 
-		currentScale := t.Engine().Governor().Scale()
+		currentScale := t.Engine().Controller().Scale()
 		stats := engine.ReadMemoryStatsFast(limit)
 
 		// Detect scale decrease
@@ -200,7 +200,7 @@ func (t *Test21PanicSawPrevention) Validate() *framework.TestResult {
 
 	// Metrics
 	result.AddMetric("decrease_count", len(t.scaleDecreases))
-	result.AddMetric("final_scale", t.Engine().Governor().Scale())
+	result.AddMetric("final_scale", t.Engine().Controller().Scale())
 
 	for i, dec := range t.scaleDecreases {
 		result.AddMetric("decrease_"+string(rune('0'+i+1))+"_time", dec.Time)
@@ -102,7 +102,7 @@ func (t *Test41MinScaleFloor) Run(ctx context.Context) error {
 		default:
 		}
 
-		currentScale := t.Engine().Governor().Scale()
+		currentScale := t.Engine().Controller().Scale()
 		stats := engine.ReadMemoryStatsFast(limit)
 
 		// Track minimum scale seen
@@ -198,7 +198,7 @@ func (t *Test41MinScaleFloor) Validate() *framework.TestResult {
 	framework.AssertScaleGreaterThan(t.BaseTestCase, "Minimum scale ≥ 0.20", 0.19, t.minScaleSeen)
 
 	// Assertion 7: Scale never went below 0.19 (with small tolerance for float precision)
-	finalScale := t.Engine().Governor().Scale()
+	finalScale := t.Engine().Controller().Scale()
 	framework.AssertScaleGreaterThan(t.BaseTestCase, "Final scale ≥ 0.19", 0.19, finalScale)
 
 	// Metrics
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -35,21 +34,13 @@ func main() {
 
 	// Create engine with error signaling
 	log.Println("Creating engine with error signaling enabled...")
-	eng, err := engine.NewWithConfig(cfg)
+	// Print every error event to stdout
+	eng, err := engine.NewWithConfig(cfg,
+		engine.WithErrorSink(event.NewConsoleSink(os.Stdout, true)))
 	if err != nil {
 		log.Fatalf("Failed to create engine: %v", err)
 	}
 
-	// Subscribe to error bus
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	sub, err := eng.ErrorBus().Subscribe(ctx)
-	if err != nil {
-		log.Fatalf("Failed to subscribe to error bus: %v", err)
-	}
-
-	log.Printf("Subscribed to error bus (ID: %s)", sub.ID())
 	log.Println("Memory limit detected:")
 	if limit, src, ok := engine.DetectMemoryLimit(); ok {
 		log.Printf("  Limit: %s (source: %s)", engine.FormatBytes(limit), src)
@@ -57,27 +48,6 @@ func main() {
 		log.Printf("  No memory limit detected")
 	}
 
-	// Start error event printer
-	go func() {
-		for evt := range sub.Events() {
-			fmt.Printf("\n[ERROR EVENT] %s\n", time.Now().Format("15:04:05.000"))
-			fmt.Printf("  Severity:    %s\n", evt.Severity)
-			fmt.Printf("  Code:        %s\n", evt.Code)
-			fmt.Printf("  Component:   %s\n", evt.Component)
-			fmt.Printf("  Message:     %s\n", evt.Message)
-			if evt.Signal != event.SignalNone {
-				fmt.Printf("  Signal:      %s\n", evt.Signal)
-			}
-			if len(evt.Context) > 0 {
-				fmt.Printf("  Context:\n")
-				for k, v := range evt.Context {
-					fmt.Printf("    %s: %v\n", k, v)
-				}
-			}
-			fmt.Println()
-		}
-	}()
-
 	// Allocate memory in chunks to trigger warnings
 	log.Println("\nStarting memory stress test...")
 	log.Println("Allocating memory in 10MB chunks every 2 seconds...")
@@ -124,6 +94,9 @@ func main() {
 		case <-sigCh:
 			log.Println("\nShutdown signal received...")
 			log.Printf("Dropped events: %d", eng.ErrorBus().DroppedCount())
+			for _, sink := range eng.ErrorSinks() {
+				log.Printf("Sink: %+v", sink.Stats())
+			}
 
 			// Shutdown engine
 			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
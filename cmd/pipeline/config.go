@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/BYTE-6D65/pipeline/pkg/engine"
+)
+
+// configCommand implements `pipeline config print|validate|defaults`.
+func configCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("config: missing subcommand (print, validate or defaults)")
+	}
+	sub := args[0]
+
+	fs := flag.NewFlagSet("config "+sub, flag.ExitOnError)
+	configPath := fs.String("config", "", "JSON or YAML config file")
+	fs.Parse(args[1:])
+
+	switch sub {
+	case "print":
+		cfg, sources, err := engine.LoadConfig(*configPath)
+		printConfig(cfg, sources)
+		if err != nil {
+			printConfigErrors(err)
+			os.Exit(1)
+		}
+	case "validate":
+		if _, _, err := engine.LoadConfig(*configPath); err != nil {
+			printConfigErrors(err)
+			os.Exit(1)
+		}
+		fmt.Println("Configuration OK")
+	case "defaults":
+		printDefaults()
+	default:
+		return fmt.Errorf("config: unknown subcommand %q (want print, validate or defaults)", sub)
+	}
+	return nil
+}
+
+// printConfig writes the effective configuration with the source of each value.
+func printConfig(cfg engine.Config, sources engine.ConfigSources) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tVALUE\tSOURCE\tENV")
+	for _, f := range engine.ConfigFields(cfg, sources) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.Name, f.Value, f.Source, f.Env)
+	}
+	w.Flush()
+}
+
+// printConfigErrors lists every configuration problem on stderr.
+func printConfigErrors(err error) {
+	lines := strings.Split(err.Error(), "\n")
+	fmt.Fprintf(os.Stderr, "Invalid configuration (%d problems):\n", len(lines))
+	for _, line := range lines {
+		fmt.Fprintf(os.Stderr, "  - %s\n", line)
+	}
+}
+
+// printDefaults writes the defaults as a YAML config file, ready to edit.
+func printDefaults() {
+	fmt.Println("# Pipeline configuration defaults")
+	fmt.Println("# Load with: pipeline run --config FILE (env vars override file values)")
+	cfg := engine.DefaultConfig()
+	fields := reflect.ValueOf(cfg)
+	for _, f := range engine.ConfigFields(cfg, nil) {
+		value := f.Value
+		if fields.FieldByName(f.Name).Kind() == reflect.String {
+			value = strconv.Quote(value)
+		}
+		fmt.Printf("%s: %s # %s\n", f.Name, value, f.Env)
+	}
+}
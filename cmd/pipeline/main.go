@@ -27,6 +27,16 @@ func main() {
 		fmt.Printf("pipeline v%s\n", version)
 		fmt.Printf("Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
 		return
+	case "run":
+		if err := runEngine(os.Args[2:]); err != nil {
+			log.Fatalf("ERROR: %v", err)
+		}
+		return
+	case "config":
+		if err := configCommand(os.Args[2:]); err != nil {
+			log.Fatalf("ERROR: %v", err)
+		}
+		return
 	case "help", "-h", "--help":
 		usage()
 		return
@@ -42,6 +52,14 @@ Usage:
   pipeline [demo]
       Launch interactive demo with performance tests
 
+  pipeline run [--config FILE]
+      Run an engine until interrupted (config from FILE or PIPELINE_* env)
+      Send SIGHUP to reload FILE without restarting
+
+  pipeline config print|validate|defaults [--config FILE]
+      Show the effective config and where each value came from,
+      check it for errors, or print the defaults as YAML
+
   pipeline version
       Show version and platform information
 
@@ -55,6 +73,12 @@ Examples:
   # Run specific demo mode
   pipeline demo
 
+  # Write a config file, check it, then run with it
+  pipeline config defaults > pipeline.yaml
+  pipeline config validate --config pipeline.yaml
+  pipeline run --config pipeline.yaml
+  kill -HUP <pid>
+
   # Show version
   pipeline version
 
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/BYTE-6D65/pipeline/pkg/engine"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+// runEngine starts an engine and runs it until SIGINT/SIGTERM.
+// With --config, SIGHUP reloads the file and applies it live.
+func runEngine(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	configPath := fs.String("config", "", "JSON or YAML config file (reloaded on SIGHUP)")
+	fs.Parse(args)
+
+	load := func() (engine.Config, error) {
+		if *configPath == "" {
+			return engine.LoadFromEnv()
+		}
+		return engine.LoadConfigFile(*configPath)
+	}
+
+	cfg, err := load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	eng, err := engine.NewWithConfig(cfg, engine.WithErrorSink(event.NewStderrSink()))
+	if err != nil {
+		return fmt.Errorf("create engine: %w", err)
+	}
+	fmt.Print(cfg.String())
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+
+	for sig := range sigCh {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		if *configPath == "" {
+			log.Printf("SIGHUP ignored: no --config file to reload")
+			continue
+		}
+		reloadConfig(eng, load)
+	}
+
+	log.Printf("Shutting down...")
+	return eng.Shutdown(context.Background())
+}
+
+// reloadConfig loads the config and applies it to eng, logging the outcome.
+// A config that fails to load or validate leaves the engine unchanged.
+func reloadConfig(eng *engine.Engine, load func() (engine.Config, error)) {
+	cfg, err := load()
+	if err != nil {
+		log.Printf("Config reload failed: %v", err)
+		return
+	}
+
+	update, err := eng.UpdateConfig(cfg)
+	if err != nil {
+		log.Printf("Config reload rejected: %v", err)
+		return
+	}
+
+	if len(update.Changes) == 0 {
+		log.Printf("Config reloaded: no changes")
+		return
+	}
+	for _, c := range update.Applied() {
+		log.Printf("  applied  %s", c)
+	}
+	for _, c := range update.RestartRequired() {
+		log.Printf("  restart  %s", c)
+	}
+}
@@ -30,16 +30,19 @@ func main() {
 
 	// Create engine with AIMD + RED enabled
 	log.Println("Creating engine with AIMD governor...")
-	eng, err := engine.NewWithConfig(cfg)
+	// Print control loop events to stdout
+	controlEvents := event.ErrorFilter{Components: []string{"control-lab*"}}
+	eng, err := engine.NewWithConfig(cfg,
+		engine.WithErrorSink(event.NewConsoleSink(os.Stdout, true), event.WithSinkFilter(controlEvents)))
 	if err != nil {
 		log.Fatalf("Failed to create engine: %v", err)
 	}
 
-	// Subscribe to error bus
+	// Subscribe to control events for tracking
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	sub, err := eng.ErrorBus().Subscribe(ctx)
+	sub, err := eng.ErrorBus().SubscribeFiltered(ctx, controlEvents)
 	if err != nil {
 		log.Fatalf("Failed to subscribe to error bus: %v", err)
 	}
@@ -69,8 +72,8 @@ func main() {
 		scaleChanges: make([]ScaleChange, 0),
 	}
 
-	// Start event printer
-	go printControlEvents(sub, tracker)
+	// Start event tracker
+	go trackControlEvents(sub, tracker)
 
 	// Handle shutdown gracefully
 	sigCh := make(chan os.Signal, 1)
@@ -325,27 +328,10 @@ func validateRecovery(tracker *EventTracker, eng *engine.Engine) error {
 	return nil
 }
 
-func printControlEvents(sub *event.ErrorSubscription, tracker *EventTracker) {
+func trackControlEvents(sub *event.ErrorSubscription, tracker *EventTracker) {
 	lastState := "NORMAL"
 
 	for evt := range sub.Events() {
-		// Only print control loop events
-		if evt.Component != "control-lab" && evt.Component != "control-lab:governor" {
-			continue
-		}
-
-		fmt.Printf("\n[CONTROL EVENT] %s\n", time.Now().Format("15:04:05.000"))
-		fmt.Printf("  Severity:    %s\n", evt.Severity)
-		fmt.Printf("  Code:        %s\n", evt.Code)
-		fmt.Printf("  Message:     %s\n", evt.Message)
-		if len(evt.Context) > 0 {
-			fmt.Printf("  Context:\n")
-			for k, v := range evt.Context {
-				fmt.Printf("    %s: %v\n", k, v)
-			}
-		}
-		fmt.Println()
-
 		// Track state changes
 		if evt.Code == event.CodeDegradedMode {
 			if state, ok := evt.Context["state"].(string); ok {
@@ -42,3 +42,20 @@ type Emitter interface {
 	// Safe to call multiple times (idempotent).
 	Close() error
 }
+
+// Flusher is implemented by emitters that buffer output (batching network
+// clients, buffered file writers). The EmitterManager calls Flush during
+// engine shutdown, after the bus has drained and before Close.
+type Flusher interface {
+	// Flush writes any buffered output, honoring ctx for cancellation.
+	Flush(ctx context.Context) error
+}
+
+// Partitioner is implemented by emitters that can emit unrelated events in
+// parallel. When the engine worker pool is enabled, events with the same
+// partition key are emitted in order, one at a time; Emit may be called
+// concurrently for different keys. Emitters without it get one partition.
+type Partitioner interface {
+	// PartitionKey returns the ordering key for evt (e.g. a device or tenant).
+	PartitionKey(evt *event.Event) string
+}
@@ -19,17 +19,22 @@ type AdapterManager struct {
 	ctx      context.Context
 	cancel   context.CancelFunc
 	wg       sync.WaitGroup
+
+	shutdownOnce sync.Once
+	shutdownErr  error
 }
 
 // NewAdapterManager creates a new adapter manager for the given engine.
 func NewAdapterManager(engine *Engine) *AdapterManager {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &AdapterManager{
+	m := &AdapterManager{
 		engine:   engine,
 		adapters: make(map[string]adapter.Adapter),
 		ctx:      ctx,
 		cancel:   cancel,
 	}
+	engine.attachAdapterManager(m)
+	return m
 }
 
 // Register registers an adapter with the manager.
@@ -94,8 +99,14 @@ func (m *AdapterManager) Start() (err error) {
 	var startErrors []error
 
 	for id, adapter := range m.adapters {
-		// Start the adapter with the engine's external bus and clock
-		if err := adapter.Start(m.ctx, m.engine.ExternalBus(), m.engine.Clock()); err != nil {
+		// Start the adapter with the engine's external bus and clock,
+		// rate limited per adapter when the engine has a limiter
+		bus := m.engine.ExternalBus()
+		if m.engine.rateLimiter != nil {
+			bus = m.engine.rateLimiter.Wrap(bus, id)
+		}
+
+		if err := adapter.Start(m.ctx, bus, m.engine.Clock()); err != nil {
 			startErrors = append(startErrors, fmt.Errorf("adapter %s: %w", id, err))
 		}
 	}
@@ -144,7 +155,16 @@ func (m *AdapterManager) stopAll() error {
 
 // Shutdown gracefully shuts down the adapter manager.
 // It stops all adapters and cancels the context.
-func (m *AdapterManager) Shutdown() (err error) {
+// Calling Shutdown more than once returns the first result.
+func (m *AdapterManager) Shutdown() error {
+	m.shutdownOnce.Do(func() {
+		m.shutdownErr = m.shutdown()
+	})
+	return m.shutdownErr
+}
+
+// shutdown cancels the adapter context, stops adapters and waits for them.
+func (m *AdapterManager) shutdown() (err error) {
 	start := time.Now()
 	defer func() {
 		recordEngineOperation(m.engine.metrics, "adapter.shutdown", start, err)
@@ -59,8 +59,11 @@ func (s GovernorState) String() string {
 // Hysteresis: 15% gap between enter (70%) and exit (55%) prevents oscillation.
 //
 // The governor can be controlled in two ways:
-//  1. Direct updates via Update(memPressure) - used by ControlLab
+//  1. Direct updates via Update(pressure) - used by ControlLab through AsController
 //  2. Event-driven commands via InternalBus - enables manual overrides and multiple controllers
+//
+// AsController adapts it to the Controller interface; it is the engine's
+// default control law.
 type AIMDGovernor struct {
 	// Configuration
 	enterThreshold    float64 // Enter degraded mode (e.g., 0.70)
@@ -70,6 +73,7 @@ type AIMDGovernor struct {
 	decrFactor        float64 // Multiplicative decrease factor (e.g., 0.5)
 	minScale          float64 // Minimum scale factor (e.g., 0.2 = 20%)
 	maxScale          float64 // Maximum scale factor (always 1.0)
+	maxStep           float64 // Max additive increase per update (0 = incrStep)
 	cooldown          time.Duration // Min time between scale changes (e.g., 30s)
 
 	// Time source
@@ -80,6 +84,7 @@ type AIMDGovernor struct {
 	scale           float64        // Current scale factor (0.0-1.0)
 	state           GovernorState  // Current state
 	lastScaleChange clock.MonoTime // Last time scale changed (for rate limiting)
+	explain         string         // Most recent decision (see Explain)
 }
 
 // NewAIMDGovernor creates an AIMD governor with the given thresholds.
@@ -101,7 +106,7 @@ func NewAIMDGovernor(clk clock.Clock, enterThreshold, exitThreshold, incrStep, d
 	return &AIMDGovernor{
 		enterThreshold:    enterThreshold,
 		exitThreshold:     exitThreshold,
-		criticalThreshold: 0.90, // Fixed critical threshold
+		criticalThreshold: 0.90, // Default critical threshold
 		incrStep:          incrStep,
 		decrFactor:        decrFactor,
 		minScale:          0.2, // Never go below 20% (prevents starvation)
@@ -111,6 +116,7 @@ func NewAIMDGovernor(clk clock.Clock, enterThreshold, exitThreshold, incrStep, d
 		scale:             1.0, // Start at full speed
 		state:             StateNormal,
 		lastScaleChange:   clk.Now(), // Initialize to now
+		explain:           "NORMAL: no pressure reading yet",
 	}
 }
 
@@ -131,9 +137,46 @@ func NewDefaultAIMDGovernor(clk clock.Clock, cooldown time.Duration) *AIMDGovern
 	return NewAIMDGovernor(clk, 0.70, 0.55, 0.05, 0.5, cooldown)
 }
 
-// Update processes a memory pressure reading and updates governor state.
+// NewAIMDGovernorFromConfig creates an AIMD governor from the engine config:
+// thresholds (MemoryEnterThreshold, MemoryExitThreshold, MemoryCriticalPct),
+// AIMD tuning (AIMDIncrStep, AIMDDecrFactor, AIMDMaxPerTick), the scale floor
+// (GovernorMinScale) and the cooldown (ControlCooldown).
+//
+// AIMDMaxPerTick caps the additive increase only; multiplicative decreases
+// stay uncapped so the response to pressure remains fast.
+func NewAIMDGovernorFromConfig(clk clock.Clock, cfg Config) *AIMDGovernor {
+	g := NewAIMDGovernor(clk, cfg.MemoryEnterThreshold, cfg.MemoryExitThreshold,
+		cfg.AIMDIncrStep, cfg.AIMDDecrFactor, cfg.ControlCooldown)
+	g.ApplyConfig(cfg)
+	return g
+}
+
+// ApplyConfig replaces the thresholds, AIMD tuning, scale floor and cooldown
+// of a running governor (see NewAIMDGovernorFromConfig). State and scale are
+// kept; a scale below the new floor is raised to it.
+//
+// Thread-safe: Can be called concurrently with Update().
+func (g *AIMDGovernor) ApplyConfig(cfg Config) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.enterThreshold = cfg.MemoryEnterThreshold
+	g.exitThreshold = cfg.MemoryExitThreshold
+	g.criticalThreshold = cfg.MemoryCriticalPct
+	g.incrStep = cfg.AIMDIncrStep
+	g.decrFactor = cfg.AIMDDecrFactor
+	g.minScale = cfg.GovernorMinScale
+	g.maxStep = cfg.AIMDMaxPerTick
+	g.cooldown = cfg.ControlCooldown
+
+	if g.scale < g.minScale {
+		g.scale = g.minScale
+	}
+}
+
+// Update processes a pressure reading and updates governor state.
 //
-// This can be called frequently (e.g., every 50ms) with current memory pressure.
+// This can be called frequently (e.g., every 50ms) with current pressure.
 // The governor internally rate-limits scale changes based on cooldown period.
 //
 // State transitions (NORMAL ↔ DEGRADED ↔ RECOVERING) happen immediately.
@@ -147,75 +190,135 @@ func NewDefaultAIMDGovernor(clk clock.Clock, cooldown time.Duration) *AIMDGovern
 //	governor.Update(memPressure)
 //	scale := governor.Scale()  // Apply this to publish rate
 func (g *AIMDGovernor) Update(memPressure float64) {
+	g.update(memPressure, "")
+}
+
+// AsController returns g as a Controller, for NewControlLab and
+// WithController. Its Update feeds g the combined pressure and records the
+// driver in Explain; the other methods are g's own.
+func (g *AIMDGovernor) AsController() Controller {
+	return aimdController{g}
+}
+
+// aimdController adapts an AIMDGovernor to the Controller interface.
+type aimdController struct {
+	*AIMDGovernor
+}
+
+// Update processes one set of control signals (Controller interface).
+func (c aimdController) Update(signals ControlSignals) {
+	c.update(signals.Pressure.Pressure, signals.Pressure.Driver)
+}
+
+// governorOf returns the AIMD governor behind c, or nil if c is not one.
+func governorOf(c Controller) *AIMDGovernor {
+	a, _ := c.(aimdController)
+	return a.AIMDGovernor
+}
+
+// update runs the state machine; driver (optional) labels the pressure in
+// Explain.
+func (g *AIMDGovernor) update(memPressure float64, driver PressureSource) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	from := g.state
+	reading := fmt.Sprintf("pressure %.1f%%", memPressure*100)
+	if driver != "" {
+		reading += fmt.Sprintf(" (%s)", driver)
+	}
+
+	var why string
 	switch g.state {
 	case StateNormal:
-		g.updateNormal(memPressure)
+		why = g.updateNormal(memPressure)
 	case StateDegraded:
-		g.updateDegraded(memPressure)
+		why = g.updateDegraded(memPressure)
 	case StateRecovering:
-		g.updateRecovering(memPressure)
+		why = g.updateRecovering(memPressure)
 	}
 
 	// Clamp scale to [minScale, maxScale]
 	if g.scale < g.minScale {
 		g.scale = g.minScale
+		why += fmt.Sprintf(", held at floor %.2f", g.minScale)
 	}
 	if g.scale > g.maxScale {
 		g.scale = g.maxScale
 	}
+
+	transition := g.state.String()
+	if g.state != from {
+		transition = from.String() + " -> " + transition
+	}
+	g.explain = fmt.Sprintf("%s: %s %s", transition, reading, why)
 }
 
-// updateNormal handles state transitions from Normal state.
-func (g *AIMDGovernor) updateNormal(memPressure float64) {
+// updateNormal handles state transitions from Normal state and returns the
+// reason for Explain.
+func (g *AIMDGovernor) updateNormal(memPressure float64) string {
 	if memPressure >= g.enterThreshold {
 		// Pressure detected - enter degraded mode
 		g.state = StateDegraded
 		// Multiplicative decrease (always allowed from NORMAL state)
 		g.scale *= g.decrFactor
 		g.lastScaleChange = g.clock.Now()
+		return fmt.Sprintf(">= enter %.1f%%, scale ×%.2f = %.2f", g.enterThreshold*100, g.decrFactor, g.scale)
 	}
 	// else: stay in normal, scale remains 1.0
+	return fmt.Sprintf("< enter %.1f%%, scale %.2f", g.enterThreshold*100, g.scale)
 }
 
-// updateDegraded handles state transitions from Degraded state.
-func (g *AIMDGovernor) updateDegraded(memPressure float64) {
+// updateDegraded handles state transitions from Degraded state and returns
+// the reason for Explain.
+func (g *AIMDGovernor) updateDegraded(memPressure float64) string {
 	if memPressure < g.exitThreshold {
 		// Pressure relieved - enter recovery (state transition only, no scale change)
 		g.state = StateRecovering
+		return fmt.Sprintf("< exit %.1f%%, scale %.2f", g.exitThreshold*100, g.scale)
 	} else if memPressure > g.criticalThreshold {
 		// Still high pressure - check if we can decrease more
-		if g.clock.Since(g.lastScaleChange) >= g.cooldown {
-			g.scale *= g.decrFactor
-			g.lastScaleChange = g.clock.Now()
+		if wait := g.cooldown - g.clock.Since(g.lastScaleChange); wait > 0 {
+			return fmt.Sprintf("> critical %.1f%%, decrease held by cooldown (%s left)", g.criticalThreshold*100, wait.Round(time.Millisecond))
 		}
+		g.scale *= g.decrFactor
+		g.lastScaleChange = g.clock.Now()
+		return fmt.Sprintf("> critical %.1f%%, scale ×%.2f = %.2f", g.criticalThreshold*100, g.decrFactor, g.scale)
 	}
 	// else: stay in degraded at current scale
+	return fmt.Sprintf("between exit %.1f%% and critical %.1f%%, holding scale %.2f", g.exitThreshold*100, g.criticalThreshold*100, g.scale)
 }
 
-// updateRecovering handles state transitions from Recovering state.
-func (g *AIMDGovernor) updateRecovering(memPressure float64) {
+// updateRecovering handles state transitions from Recovering state and
+// returns the reason for Explain.
+func (g *AIMDGovernor) updateRecovering(memPressure float64) string {
 	if memPressure < g.exitThreshold {
 		// Still below exit threshold - additive increase (rate-limited by cooldown)
-		if g.clock.Since(g.lastScaleChange) >= g.cooldown {
-			g.scale += g.incrStep
-			g.lastScaleChange = g.clock.Now()
-
-			// Check if fully recovered
-			if g.scale >= g.maxScale {
-				g.scale = g.maxScale
-				g.state = StateNormal
-			}
+		if wait := g.cooldown - g.clock.Since(g.lastScaleChange); wait > 0 {
+			return fmt.Sprintf("< exit %.1f%%, increase held by cooldown (%s left)", g.exitThreshold*100, wait.Round(time.Millisecond))
+		}
+		step := g.incrStep
+		if g.maxStep > 0 && step > g.maxStep {
+			step = g.maxStep
 		}
+		g.scale += step
+		g.lastScaleChange = g.clock.Now()
+
+		// Check if fully recovered
+		if g.scale >= g.maxScale {
+			g.scale = g.maxScale
+			g.state = StateNormal
+		}
+		return fmt.Sprintf("< exit %.1f%%, scale +%.2f = %.2f", g.exitThreshold*100, step, g.scale)
 	} else if memPressure >= g.enterThreshold {
 		// Pressure returned - back to degraded (always allowed)
 		g.state = StateDegraded
 		g.scale *= g.decrFactor
 		g.lastScaleChange = g.clock.Now()
+		return fmt.Sprintf(">= enter %.1f%%, scale ×%.2f = %.2f", g.enterThreshold*100, g.decrFactor, g.scale)
 	}
 	// else: stay in recovering at current scale (pressure between exit and enter)
+	return fmt.Sprintf("between exit %.1f%% and enter %.1f%%, holding scale %.2f", g.exitThreshold*100, g.enterThreshold*100, g.scale)
 }
 
 // Scale returns the current scale factor (0.0-1.0).
@@ -244,26 +347,65 @@ func (g *AIMDGovernor) State() GovernorState {
 	return g.state
 }
 
+// Explain describes the most recent decision (Controller interface).
+//
+// Thread-safe: Can be called concurrently.
+func (g *AIMDGovernor) Explain() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.explain
+}
+
 // EnterThreshold returns the configured enter threshold.
 func (g *AIMDGovernor) EnterThreshold() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	return g.enterThreshold
 }
 
 // ExitThreshold returns the configured exit threshold.
 func (g *AIMDGovernor) ExitThreshold() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	return g.exitThreshold
 }
 
 // IncrStep returns the configured additive increase step.
 func (g *AIMDGovernor) IncrStep() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	return g.incrStep
 }
 
 // DecrFactor returns the configured multiplicative decrease factor.
 func (g *AIMDGovernor) DecrFactor() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	return g.decrFactor
 }
 
+// CriticalThreshold returns the pressure above which a degraded governor
+// keeps decreasing.
+func (g *AIMDGovernor) CriticalThreshold() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.criticalThreshold
+}
+
+// MinScale returns the scale floor.
+func (g *AIMDGovernor) MinScale() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.minScale
+}
+
+// MaxStep returns the cap on additive increase per update (0 = uncapped).
+func (g *AIMDGovernor) MaxStep() float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.maxStep
+}
+
 // Start subscribes to control events on the internal bus and applies governor scale commands.
 //
 // This enables event-driven control of the governor, allowing:
@@ -279,13 +421,13 @@ func (g *AIMDGovernor) DecrFactor() float64 {
 // Usage:
 //
 //	go governor.Start(ctx, internalBus)
-func (g *AIMDGovernor) Start(ctx context.Context, bus *event.InMemoryBus) error {
+func (g *AIMDGovernor) Start(ctx context.Context, bus event.Bus) error {
 	// Subscribe to governor scale commands
 	filter := event.Filter{
 		Types: []string{event.EventTypeGovernorScale},
 	}
 
-	sub, err := bus.Subscribe(ctx, filter)
+	sub, err := event.SubscribeNamed(ctx, bus, "governor", filter)
 	if err != nil {
 		return fmt.Errorf("governor: failed to subscribe to internal bus: %w", err)
 	}
@@ -296,7 +438,10 @@ func (g *AIMDGovernor) Start(ctx context.Context, bus *event.InMemoryBus) error
 
 		for {
 			select {
-			case evt := <-sub.Events():
+			case evt, ok := <-sub.Events():
+				if !ok {
+					return // Bus closed
+				}
 				g.applyScaleCommand(evt)
 			case <-ctx.Done():
 				return
@@ -353,4 +498,8 @@ func (g *AIMDGovernor) applyScaleCommand(evt *event.Event) {
 
 	// Update cooldown timer to prevent oscillation
 	g.lastScaleChange = g.clock.Now()
+
+	if g.scale != oldScale {
+		g.explain = fmt.Sprintf("%s: scale set to %.2f by %s (%s)", g.state, g.scale, cmd.Source, cmd.Reason)
+	}
 }
@@ -0,0 +1,603 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+	"github.com/go-json-experiment/json"
+)
+
+// AlertKind selects how an alert rule evaluates matching events.
+type AlertKind string
+
+const (
+	// AlertThreshold fires when Count matching events arrive within Window.
+	AlertThreshold AlertKind = "threshold"
+
+	// AlertRate fires when matching events exceed Rate per second, averaged
+	// over Window.
+	AlertRate AlertKind = "rate"
+
+	// AlertAbsence fires when no matching event arrives for Window
+	// (e.g., a missing heartbeat). It re-arms on the next matching event.
+	AlertAbsence AlertKind = "absence"
+
+	// AlertSequence fires when events matching each Sequence step arrive in
+	// order, with the whole sequence completing within Window.
+	AlertSequence AlertKind = "sequence"
+)
+
+// alertComponentPrefix marks events published by alert rules. The manager
+// ignores them, so rules cannot trigger each other in a loop.
+const alertComponentPrefix = "alert:"
+
+// alertTickInterval is how often the engine checks absence rules.
+const alertTickInterval = 100 * time.Millisecond
+
+// AlertMatch selects the error events a rule counts.
+// Codes and Components support wildcards ("MEM_*", "emitter:*").
+type AlertMatch struct {
+	Codes       []string `json:"codes,omitempty"`
+	Components  []string `json:"components,omitempty"`
+	MinSeverity string   `json:"min_severity,omitempty"` // e.g. "WARNING" (default: all)
+}
+
+// filter converts the match to an ErrorFilter.
+func (m AlertMatch) filter() (event.ErrorFilter, error) {
+	f := event.ErrorFilter{Codes: m.Codes, Components: m.Components}
+	if m.MinSeverity != "" {
+		sev, err := event.ParseErrorSeverity(m.MinSeverity)
+		if err != nil {
+			return f, err
+		}
+		f.MinSeverity = sev
+	}
+	return f, nil
+}
+
+// AlertAction describes what a rule publishes when it fires.
+//
+// An ErrorEvent is always published to the ErrorBus with component
+// "alert:<rule name>". If ControlType is set, a control event with
+// ControlPayload is also published to the internal bus.
+type AlertAction struct {
+	Severity       string         `json:"severity,omitempty"` // Default "WARNING"
+	Code           string         `json:"code,omitempty"`     // Default ALERT_FIRED
+	Signal         string         `json:"signal,omitempty"`   // e.g. "BREAKER_OPEN"
+	Message        string         `json:"message,omitempty"`  // Default "Alert <name> fired"
+	ControlType    string         `json:"control_type,omitempty"`
+	ControlPayload map[string]any `json:"control_payload,omitempty"`
+}
+
+// AlertRule is a declarative condition over ErrorBus events.
+//
+// Examples:
+//
+//	// 3 EMITTER_FAIL from the same component in 60s open the breaker
+//	engine.AlertRule{
+//	    Name:    "emitter-breaker",
+//	    Kind:    engine.AlertThreshold,
+//	    Match:   engine.AlertMatch{Codes: []string{event.CodeEmitterFail}},
+//	    Count:   3,
+//	    Window:  time.Minute,
+//	    GroupBy: "component",
+//	    Action:  engine.AlertAction{Severity: "ERROR", Code: event.CodeBreakerOpen, Signal: "BREAKER_OPEN"},
+//	}
+//
+//	// PSI_PRE_OOM twice in 5m escalates to Critical
+//	engine.AlertRule{
+//	    Name:   "psi-escalation",
+//	    Kind:   engine.AlertThreshold,
+//	    Match:  engine.AlertMatch{Codes: []string{event.CodePSIPreOOM}},
+//	    Count:  2,
+//	    Window: 5 * time.Minute,
+//	    Action: engine.AlertAction{Severity: "CRITICAL", Code: event.CodeOOMImminent},
+//	}
+type AlertRule struct {
+	Name     string        `json:"name"`
+	Kind     AlertKind     `json:"kind"`
+	Match    AlertMatch    `json:"match,omitzero"`                 // Threshold, rate and absence
+	Sequence []AlertMatch  `json:"sequence,omitempty"`             // Sequence steps, in order
+	Count    int           `json:"count,omitempty"`                // Threshold: events needed
+	Rate     float64       `json:"rate,omitempty"`                 // Rate: events/sec to exceed
+	Window   time.Duration `json:"window,format:units"`            // Sliding window
+	Cooldown time.Duration `json:"cooldown,omitzero,format:units"` // Min time between firings per group (default Window)
+	GroupBy  string        `json:"group_by,omitempty"`             // "", "code" or "component"
+	Action   AlertAction   `json:"action"`
+}
+
+// Validate checks that the rule is well-formed.
+func (r AlertRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("alert rule: name is required")
+	}
+	if r.Window <= 0 {
+		return fmt.Errorf("alert rule %s: window must be > 0", r.Name)
+	}
+	switch r.GroupBy {
+	case "", "code", "component":
+	default:
+		return fmt.Errorf("alert rule %s: group_by must be \"code\" or \"component\", got %q", r.Name, r.GroupBy)
+	}
+
+	switch r.Kind {
+	case AlertThreshold:
+		if r.Count < 1 {
+			return fmt.Errorf("alert rule %s: threshold count must be >= 1", r.Name)
+		}
+	case AlertRate:
+		if r.Rate <= 0 {
+			return fmt.Errorf("alert rule %s: rate must be > 0", r.Name)
+		}
+	case AlertAbsence:
+		if r.GroupBy != "" {
+			return fmt.Errorf("alert rule %s: absence rules cannot be grouped", r.Name)
+		}
+	case AlertSequence:
+		if len(r.Sequence) < 2 {
+			return fmt.Errorf("alert rule %s: sequence needs at least 2 steps", r.Name)
+		}
+	default:
+		return fmt.Errorf("alert rule %s: unknown kind %q", r.Name, r.Kind)
+	}
+
+	if _, err := r.compile(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// LoadAlertRules reads rules from a JSON file of the form
+// {"rules": [{"name": ..., "kind": "threshold", "window": "60s", ...}]}.
+// All invalid rules are reported, not just the first.
+func LoadAlertRules(path string) ([]AlertRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("alert rules: %w", err)
+	}
+
+	var file struct {
+		Rules []AlertRule `json:"rules"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("alert rules: parse %s: %w", path, err)
+	}
+
+	var errs []error
+	for _, r := range file.Rules {
+		if err := r.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return file.Rules, nil
+}
+
+// AlertState is a point-in-time view of one rule.
+type AlertState struct {
+	Rule      string
+	Kind      AlertKind
+	Fired     uint64         // Times the rule has fired
+	LastFired clock.MonoTime // Engine clock reading of the last firing (0 = never)
+	Groups    map[string]int // Events in window (threshold/rate) or steps matched (sequence), by group
+	Absent    bool           // Absence rules: currently firing (no event within window)
+}
+
+// AlertManager evaluates alert rules against ErrorBus events.
+//
+// Windows are measured with the injected clock, so rules can be tested with
+// DeltaClock. An event carrying repeat_count (an ErrorBus sampler summary)
+// counts as that many occurrences. Events published by rules carry component "alert:<name>" and
+// are never evaluated, which prevents alert loops.
+//
+// Usage:
+//
+//	alerts, err := engine.NewAlertManager(eng.Clock(), eng.ErrorBus(), eng.InternalBus(), rules)
+//	go alerts.Start(ctx, 100*time.Millisecond)
+type AlertManager struct {
+	clock       clock.Clock
+	errorBus    *event.ErrorBus
+	internalBus event.Bus
+
+	mu    sync.Mutex
+	rules []*alertRule
+}
+
+// alertRule is a validated rule with its runtime state.
+type alertRule struct {
+	AlertRule
+	filter   event.ErrorFilter
+	steps    []event.ErrorFilter
+	severity event.ErrorSeverity
+	signal   event.ControlSignal
+
+	groups    map[string]*alertGroup
+	fired     uint64
+	lastFired clock.MonoTime
+	lastSeen  clock.MonoTime // Absence: last matching event (or start)
+	absent    bool           // Absence: fired and not yet re-armed
+}
+
+// alertGroup holds per-group window state.
+type alertGroup struct {
+	hits      []alertHit     // Threshold/rate: matching events in window
+	step      int            // Sequence: next step to match
+	stepStart clock.MonoTime // Sequence: when step 0 matched
+	lastFired clock.MonoTime
+	hasFired  bool
+}
+
+// alertHit is a matching event: n occurrences observed at at.
+type alertHit struct {
+	at clock.MonoTime
+	n  int
+}
+
+// alertFiring is a rule firing collected under lock and published after.
+type alertFiring struct {
+	rule  *alertRule
+	group string
+	count int
+}
+
+// compile validates matchers and action fields.
+func (r AlertRule) compile() (*alertRule, error) {
+	c := &alertRule{AlertRule: r, groups: make(map[string]*alertGroup)}
+
+	var err error
+	if c.filter, err = r.Match.filter(); err != nil {
+		return nil, fmt.Errorf("alert rule %s: match: %w", r.Name, err)
+	}
+	for i, step := range r.Sequence {
+		f, err := step.filter()
+		if err != nil {
+			return nil, fmt.Errorf("alert rule %s: sequence step %d: %w", r.Name, i, err)
+		}
+		c.steps = append(c.steps, f)
+	}
+
+	c.severity = event.WarningSeverity
+	if r.Action.Severity != "" {
+		if c.severity, err = event.ParseErrorSeverity(r.Action.Severity); err != nil {
+			return nil, fmt.Errorf("alert rule %s: action: %w", r.Name, err)
+		}
+	}
+	if r.Action.Signal != "" {
+		if c.signal, err = event.ParseControlSignal(r.Action.Signal); err != nil {
+			return nil, fmt.Errorf("alert rule %s: action: %w", r.Name, err)
+		}
+	}
+	if c.Cooldown <= 0 {
+		c.Cooldown = c.Window
+	}
+	return c, nil
+}
+
+// NewAlertManager validates rules and creates a manager. internalBus may be
+// nil if no rule publishes control events.
+func NewAlertManager(clk clock.Clock, errorBus *event.ErrorBus, internalBus event.Bus, rules []AlertRule) (*AlertManager, error) {
+	m := &AlertManager{
+		clock:       clk,
+		errorBus:    errorBus,
+		internalBus: internalBus,
+	}
+
+	now := clk.Now()
+	var errs []error
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		c, _ := r.compile() // Validated above
+		c.lastSeen = now
+		m.rules = append(m.rules, c)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return m, nil
+}
+
+// Start subscribes to the ErrorBus and evaluates rules until ctx is cancelled
+// or the bus closes. Absence rules are checked every interval.
+func (m *AlertManager) Start(ctx context.Context, interval time.Duration) error {
+	sub, err := m.errorBus.Subscribe(ctx)
+	if err != nil {
+		return fmt.Errorf("alerts: subscribe: %w", err)
+	}
+	defer m.errorBus.Unsubscribe(sub)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case evt, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+			m.Observe(evt)
+		case <-ticker.C:
+			m.Tick()
+		}
+	}
+}
+
+// Observe evaluates one error event against all rules and publishes any
+// resulting alerts.
+func (m *AlertManager) Observe(evt event.ErrorEvent) {
+	if strings.HasPrefix(evt.Component, alertComponentPrefix) {
+		return
+	}
+
+	m.mu.Lock()
+	now := m.clock.Now()
+	var firings []alertFiring
+	for _, r := range m.rules {
+		if f, ok := r.observe(evt, now); ok {
+			firings = append(firings, f)
+		}
+	}
+	m.mu.Unlock()
+
+	m.publish(firings)
+}
+
+// Tick checks absence rules and publishes any that fire.
+func (m *AlertManager) Tick() {
+	m.mu.Lock()
+	now := m.clock.Now()
+	var firings []alertFiring
+	for _, r := range m.rules {
+		if r.Kind != AlertAbsence || r.absent {
+			continue
+		}
+		if clock.ToDuration(now-r.lastSeen) >= r.Window {
+			r.absent = true
+			r.fired++
+			r.lastFired = now
+			firings = append(firings, alertFiring{rule: r})
+		}
+	}
+	m.mu.Unlock()
+
+	m.publish(firings)
+}
+
+// observe updates rule state for evt. Caller must hold the manager lock.
+func (r *alertRule) observe(evt event.ErrorEvent, now clock.MonoTime) (alertFiring, bool) {
+	if r.Kind == AlertAbsence {
+		if r.filter.Matches(evt) {
+			r.lastSeen = now
+			r.absent = false
+		}
+		return alertFiring{}, false
+	}
+
+	key := r.groupKey(evt)
+	if r.Kind == AlertSequence {
+		return r.observeSequence(evt, key, now)
+	}
+	if !r.filter.Matches(evt) {
+		return alertFiring{}, false
+	}
+
+	g := r.group(key)
+	g.hits = append(g.hits, alertHit{at: now, n: occurrences(evt)})
+	g.prune(now, r.Window)
+
+	count := g.count()
+	var met bool
+	switch r.Kind {
+	case AlertThreshold:
+		met = count >= r.Count
+	case AlertRate:
+		met = float64(count)/r.Window.Seconds() > r.Rate
+	}
+	if !met || !r.ready(g, now) {
+		return alertFiring{}, false
+	}
+
+	g.hits = g.hits[:0]
+	r.fire(g, now)
+	return alertFiring{rule: r, group: key, count: count}, true
+}
+
+// observeSequence advances a sequence rule. Caller must hold the manager lock.
+func (r *alertRule) observeSequence(evt event.ErrorEvent, key string, now clock.MonoTime) (alertFiring, bool) {
+	g := r.group(key)
+	if g.step > 0 && clock.ToDuration(now-g.stepStart) > r.Window {
+		g.step = 0 // Sequence took too long; start over
+	}
+
+	switch {
+	case r.steps[g.step].Matches(evt):
+		if g.step == 0 {
+			g.stepStart = now
+		}
+		g.step++
+	case g.step > 0 && r.steps[0].Matches(evt):
+		g.step, g.stepStart = 1, now // Restart from this event
+	}
+
+	if g.step < len(r.steps) {
+		return alertFiring{}, false
+	}
+	g.step = 0
+	if !r.ready(g, now) {
+		return alertFiring{}, false
+	}
+	r.fire(g, now)
+	return alertFiring{rule: r, group: key, count: len(r.steps)}, true
+}
+
+// groupKey returns the group an event belongs to.
+func (r *alertRule) groupKey(evt event.ErrorEvent) string {
+	switch r.GroupBy {
+	case "code":
+		return evt.Code
+	case "component":
+		return evt.Component
+	default:
+		return ""
+	}
+}
+
+// group returns (creating if needed) the state for key.
+func (r *alertRule) group(key string) *alertGroup {
+	g, ok := r.groups[key]
+	if !ok {
+		g = &alertGroup{}
+		r.groups[key] = g
+	}
+	return g
+}
+
+// ready reports whether the group is out of cooldown.
+func (r *alertRule) ready(g *alertGroup, now clock.MonoTime) bool {
+	return !g.hasFired || clock.ToDuration(now-g.lastFired) >= r.Cooldown
+}
+
+// fire records a firing on the rule and group.
+func (r *alertRule) fire(g *alertGroup, now clock.MonoTime) {
+	g.hasFired = true
+	g.lastFired = now
+	r.fired++
+	r.lastFired = now
+}
+
+// prune drops hits older than window.
+func (g *alertGroup) prune(now clock.MonoTime, window time.Duration) {
+	i := 0
+	for i < len(g.hits) && clock.ToDuration(now-g.hits[i].at) > window {
+		i++
+	}
+	g.hits = g.hits[i:]
+}
+
+// count returns the occurrences in the window.
+func (g *alertGroup) count() int {
+	n := 0
+	for _, h := range g.hits {
+		n += h.n
+	}
+	return n
+}
+
+// occurrences returns how many errors evt stands for: its repeat_count when
+// the ErrorBus sampler collapsed a burst into it, otherwise 1.
+func occurrences(evt event.ErrorEvent) int {
+	switch n := evt.Context[event.ContextRepeatCount].(type) {
+	case int:
+		return max(n, 1)
+	case float64: // Decoded from JSON
+		return max(int(n), 1)
+	}
+	return 1
+}
+
+// publish emits the alert events for firings.
+func (m *AlertManager) publish(firings []alertFiring) {
+	for _, f := range firings {
+		r := f.rule
+		code := r.Action.Code
+		if code == "" {
+			code = event.CodeAlertFired
+		}
+		message := r.Action.Message
+		if message == "" {
+			message = fmt.Sprintf("Alert %s fired", r.Name)
+		}
+
+		evt := event.NewErrorEvent(r.severity, code, alertComponentPrefix+r.Name, message).
+			WithSignal(r.signal).
+			WithContext("rule", r.Name).
+			WithContext("kind", string(r.Kind)).
+			WithContext("window", r.Window.String())
+		if f.group != "" {
+			evt = evt.WithContext("group", f.group)
+		}
+		if f.count > 0 {
+			evt = evt.WithContext("count", f.count)
+		}
+		m.errorBus.Publish(evt)
+
+		if r.Action.ControlType != "" && m.internalBus != nil {
+			ctrl := event.NewControlEvent(r.Action.ControlType, r.Action.ControlPayload)
+			ctrl.SetSource(alertComponentPrefix + r.Name)
+			if err := m.internalBus.Publish(context.Background(), ctrl); err != nil {
+				m.errorBus.Publish(event.NewErrorEvent(
+					event.WarningSeverity,
+					event.CodeHealthCheck,
+					alertComponentPrefix+r.Name,
+					fmt.Sprintf("Failed to publish alert control event: %v", err),
+				))
+			}
+		}
+	}
+}
+
+// States returns a snapshot of every rule, in definition order.
+func (m *AlertManager) States() []AlertState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	states := make([]AlertState, 0, len(m.rules))
+	for _, r := range m.rules {
+		st := AlertState{
+			Rule:      r.Name,
+			Kind:      r.Kind,
+			Fired:     r.fired,
+			LastFired: r.lastFired,
+			Groups:    make(map[string]int, len(r.groups)),
+			Absent:    r.absent,
+		}
+		for key, g := range r.groups {
+			if r.Kind == AlertSequence {
+				st.Groups[key] = g.step
+				continue
+			}
+			g.prune(now, r.Window)
+			st.Groups[key] = g.count()
+		}
+		states = append(states, st)
+	}
+	return states
+}
+
+// State returns the snapshot of one rule by name.
+func (m *AlertManager) State(name string) (AlertState, bool) {
+	for _, st := range m.States() {
+		if st.Rule == name {
+			return st, true
+		}
+	}
+	return AlertState{}, false
+}
+
+// Rules returns the configured rule names, sorted.
+func (m *AlertManager) Rules() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, len(m.rules))
+	for i, r := range m.rules {
+		names[i] = r.Name
+	}
+	sort.Strings(names)
+	return names
+}
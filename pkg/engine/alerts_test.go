@@ -0,0 +1,380 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+// newTestAlerts creates a manager on a DeltaClock and a subscription that
+// captures the alerts it publishes.
+func newTestAlerts(t *testing.T, rules ...AlertRule) (*AlertManager, *clock.DeltaClock, *event.ErrorSubscription) {
+	t.Helper()
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+
+	bus := event.NewErrorBus(64)
+	t.Cleanup(func() { bus.Close() })
+	sub, _ := bus.SubscribeFiltered(t.Context(), event.ErrorFilter{Components: []string{"alert:*"}})
+
+	m, err := NewAlertManager(clk, bus, nil, rules)
+	if err != nil {
+		t.Fatalf("NewAlertManager failed: %v", err)
+	}
+	return m, clk, sub
+}
+
+// drainAlerts returns the alerts published so far.
+func drainAlerts(sub *event.ErrorSubscription) []event.ErrorEvent {
+	var out []event.ErrorEvent
+	for {
+		select {
+		case evt := <-sub.Events():
+			out = append(out, evt)
+		default:
+			return out
+		}
+	}
+}
+
+func TestAlertManager_ThresholdGroupedByComponent(t *testing.T) {
+	m, clk, sub := newTestAlerts(t, AlertRule{
+		Name:    "emitter-breaker",
+		Kind:    AlertThreshold,
+		Match:   AlertMatch{Codes: []string{event.CodeEmitterFail}},
+		Count:   3,
+		Window:  time.Minute,
+		GroupBy: "component",
+		Action:  AlertAction{Severity: "ERROR", Code: event.CodeBreakerOpen, Signal: "BREAKER_OPEN"},
+	})
+	clk.Load(0, []time.Duration{30 * time.Second, 40 * time.Second})
+
+	fail := func(component string) {
+		m.Observe(event.NewErrorEvent(event.Error, event.CodeEmitterFail, component, "send failed"))
+	}
+
+	fail("emitter:http")
+	fail("emitter:http")
+	fail("emitter:file") // Different group
+	if alerts := drainAlerts(sub); len(alerts) != 0 {
+		t.Fatalf("Expected no alert before threshold, got %v", alerts)
+	}
+
+	clk.Advance() // t=30s
+	fail("emitter:http")
+	alerts := drainAlerts(sub)
+	if len(alerts) != 1 {
+		t.Fatalf("Expected 1 alert, got %d", len(alerts))
+	}
+	a := alerts[0]
+	if a.Code != event.CodeBreakerOpen || a.Signal != event.SignalBreakerOpen || a.Severity != event.Error {
+		t.Errorf("Unexpected alert: %+v", a)
+	}
+	if a.Component != "alert:emitter-breaker" || a.Context["group"] != "emitter:http" || a.Context["count"] != 3 {
+		t.Errorf("Unexpected alert context: %s %v", a.Component, a.Context)
+	}
+
+	// Alerts are not fed back into rules
+	m.Observe(a)
+
+	// Old hits slide out of the window: t=70s, only the emitter:file hit at t=0 expired
+	clk.Advance()
+	fail("emitter:file")
+	fail("emitter:file")
+	if alerts := drainAlerts(sub); len(alerts) != 0 {
+		t.Errorf("Expired hits should not count, got %v", alerts)
+	}
+
+	st, _ := m.State("emitter-breaker")
+	if st.Fired != 1 || st.Groups["emitter:file"] != 2 {
+		t.Errorf("Unexpected state: %+v", st)
+	}
+}
+
+func TestAlertManager_ThresholdCountsSampledRepeats(t *testing.T) {
+	m, clk, alerts := newTestAlerts(t, AlertRule{
+		Name:   "emitter-burst",
+		Kind:   AlertThreshold,
+		Match:  AlertMatch{Codes: []string{event.CodeEmitterFail}},
+		Count:  10,
+		Window: time.Minute,
+	})
+	clk.Load(0, []time.Duration{2 * time.Second})
+
+	// ErrorBusSampling collapses the burst: the first error and a summary
+	errorBus := event.NewErrorBus(64)
+	defer errorBus.Close()
+	errorBus.SetSampler(event.NewErrorSampler(event.WithSampleWindow(time.Second), event.WithSampleClock(clk)))
+	sub, _ := errorBus.SubscribeFiltered(t.Context(), event.ErrorFilter{Codes: []string{event.CodeEmitterFail}})
+	for i := 0; i < 12; i++ {
+		errorBus.Publish(event.NewErrorEvent(event.Error, event.CodeEmitterFail, "emitter:http", "send failed"))
+	}
+	clk.Advance()
+	errorBus.FlushSampler()
+
+	if n := len(sub.Events()); n != 2 {
+		t.Fatalf("Expected the burst sampled to 2 events, got %d", n)
+	}
+	for len(sub.Events()) > 0 {
+		m.Observe(<-sub.Events())
+	}
+
+	got := drainAlerts(alerts)
+	if len(got) != 1 || got[0].Context["count"] != 12 {
+		t.Fatalf("Expected 1 alert counting all 12 occurrences, got %v", got)
+	}
+}
+
+func TestAlertManager_EscalatesToCritical(t *testing.T) {
+	m, clk, sub := newTestAlerts(t, AlertRule{
+		Name:   "psi-escalation",
+		Kind:   AlertThreshold,
+		Match:  AlertMatch{Codes: []string{event.CodePSIPreOOM}},
+		Count:  2,
+		Window: 5 * time.Minute,
+		Action: AlertAction{Severity: "CRITICAL", Code: event.CodeOOMImminent, Message: "Repeated PSI pre-OOM"},
+	})
+	clk.Load(0, []time.Duration{4 * time.Minute, 4 * time.Minute, 2 * time.Minute})
+
+	psi := event.NewErrorEvent(event.WarningSeverity, event.CodePSIPreOOM, "monitor:psi", "pre-oom")
+	m.Observe(psi)
+	clk.Advance() // t=4m
+	m.Observe(psi)
+
+	alerts := drainAlerts(sub)
+	if len(alerts) != 1 || alerts[0].Severity != event.CriticalSeverity || alerts[0].Message != "Repeated PSI pre-OOM" {
+		t.Fatalf("Expected one critical escalation, got %v", alerts)
+	}
+
+	// Cooldown defaults to the window
+	clk.Advance() // t=8m
+	m.Observe(psi)
+	m.Observe(psi)
+	if alerts := drainAlerts(sub); len(alerts) != 0 {
+		t.Errorf("Expected cooldown to suppress re-firing, got %v", alerts)
+	}
+	clk.Advance() // t=10m
+	m.Observe(psi)
+	if alerts := drainAlerts(sub); len(alerts) != 1 {
+		t.Errorf("Expected re-fire after cooldown, got %d", len(alerts))
+	}
+}
+
+func TestAlertManager_Rate(t *testing.T) {
+	m, _, sub := newTestAlerts(t, AlertRule{
+		Name:   "drop-storm",
+		Kind:   AlertRate,
+		Match:  AlertMatch{Codes: []string{"DROP_*"}},
+		Rate:   2, // > 2/s over 10s = more than 20 events
+		Window: 10 * time.Second,
+	})
+
+	for i := 0; i < 20; i++ {
+		m.Observe(event.NewErrorEvent(event.WarningSeverity, event.CodeDropSlow, "bus:external", "drop"))
+	}
+	if alerts := drainAlerts(sub); len(alerts) != 0 {
+		t.Fatalf("Expected no alert at 2/s, got %d", len(alerts))
+	}
+
+	m.Observe(event.NewErrorEvent(event.WarningSeverity, event.CodeDropSlow, "bus:external", "drop"))
+	alerts := drainAlerts(sub)
+	if len(alerts) != 1 || alerts[0].Code != event.CodeAlertFired || alerts[0].Severity != event.WarningSeverity {
+		t.Errorf("Expected default ALERT_FIRED warning, got %v", alerts)
+	}
+}
+
+func TestAlertManager_Absence(t *testing.T) {
+	m, clk, sub := newTestAlerts(t, AlertRule{
+		Name:   "heartbeat",
+		Kind:   AlertAbsence,
+		Match:  AlertMatch{Codes: []string{event.CodeHealthCheck}},
+		Window: 30 * time.Second,
+	})
+	clk.Load(0, []time.Duration{20 * time.Second, 20 * time.Second, 20 * time.Second, 40 * time.Second})
+
+	clk.Advance() // t=20s
+	m.Tick()
+	m.Observe(event.NewErrorEvent(event.InfoSeverity, event.CodeHealthCheck, "engine", "ok"))
+	clk.Advance() // t=40s, last heartbeat 20s ago
+	m.Tick()
+	if alerts := drainAlerts(sub); len(alerts) != 0 {
+		t.Fatalf("Expected no alert while heartbeats arrive, got %v", alerts)
+	}
+
+	clk.Advance() // t=60s, silent for 40s
+	m.Tick()
+	m.Tick() // Fires once per silence
+	if alerts := drainAlerts(sub); len(alerts) != 1 {
+		t.Fatalf("Expected 1 absence alert, got %d", len(alerts))
+	}
+	if st, _ := m.State("heartbeat"); !st.Absent {
+		t.Error("Expected rule to report absence")
+	}
+
+	// Re-arms on the next heartbeat
+	m.Observe(event.NewErrorEvent(event.InfoSeverity, event.CodeHealthCheck, "engine", "ok"))
+	clk.Advance() // t=100s
+	m.Tick()
+	if alerts := drainAlerts(sub); len(alerts) != 1 {
+		t.Errorf("Expected re-armed absence alert, got %d", len(alerts))
+	}
+}
+
+func TestAlertManager_Sequence(t *testing.T) {
+	m, clk, sub := newTestAlerts(t, AlertRule{
+		Name: "pressure-then-drop",
+		Kind: AlertSequence,
+		Sequence: []AlertMatch{
+			{Codes: []string{event.CodeMemPressure}},
+			{Codes: []string{event.CodeBufSat}},
+			{Codes: []string{"DROP_*"}},
+		},
+		Window: 10 * time.Second,
+	})
+	clk.Load(0, []time.Duration{5 * time.Second, 10 * time.Second, time.Second})
+
+	mem := event.NewErrorEvent(event.WarningSeverity, event.CodeMemPressure, "monitor:memory", "high")
+	sat := event.NewErrorEvent(event.WarningSeverity, event.CodeBufSat, "bus:external", "saturated")
+	drop := event.NewErrorEvent(event.WarningSeverity, event.CodeDropSlow, "bus:external", "drop")
+
+	// Out of order does not fire
+	m.Observe(sat)
+	m.Observe(drop)
+	m.Observe(mem)
+	m.Observe(sat)
+	if st, _ := m.State("pressure-then-drop"); st.Groups[""] != 2 {
+		t.Errorf("Expected 2 steps matched, got %+v", st)
+	}
+
+	// Too slow: the sequence restarts
+	clk.Advance() // t=5s
+	clk.Advance() // t=15s
+	m.Observe(drop)
+	if alerts := drainAlerts(sub); len(alerts) != 0 {
+		t.Fatalf("Expected no alert for a sequence exceeding the window, got %v", alerts)
+	}
+
+	m.Observe(mem)
+	m.Observe(sat)
+	clk.Advance() // t=16s
+	m.Observe(drop)
+	if alerts := drainAlerts(sub); len(alerts) != 1 {
+		t.Errorf("Expected sequence alert, got %d", len(alerts))
+	}
+}
+
+func TestAlertManager_ControlEvent(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	errorBus := event.NewErrorBus(16)
+	defer errorBus.Close()
+	internalBus := event.NewInMemoryBus()
+	defer internalBus.Close()
+	sub, _ := internalBus.Subscribe(t.Context(), event.Filter{Types: []string{event.EventTypeGovernorScale}})
+
+	m, err := NewAlertManager(clk, errorBus, internalBus, []AlertRule{{
+		Name:   "shed-on-oom",
+		Kind:   AlertThreshold,
+		Match:  AlertMatch{Codes: []string{event.CodeOOMImminent}},
+		Count:  1,
+		Window: time.Minute,
+		Action: AlertAction{
+			ControlType:    event.EventTypeGovernorScale,
+			ControlPayload: map[string]any{"scale": 0.25},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("NewAlertManager failed: %v", err)
+	}
+
+	m.Observe(event.NewErrorEvent(event.CriticalSeverity, event.CodeOOMImminent, "monitor:psi", "oom"))
+
+	select {
+	case evt := <-sub.Events():
+		if evt.Source != "alert:shed-on-oom" {
+			t.Errorf("Expected alert source, got %q", evt.Source)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected control event on the internal bus")
+	}
+}
+
+func TestAlertRule_Validate(t *testing.T) {
+	bad := []AlertRule{
+		{Kind: AlertThreshold, Count: 1, Window: time.Second},
+		{Name: "no-window", Kind: AlertThreshold, Count: 1},
+		{Name: "no-count", Kind: AlertThreshold, Window: time.Second},
+		{Name: "short-seq", Kind: AlertSequence, Window: time.Second, Sequence: []AlertMatch{{}}},
+		{Name: "bad-kind", Kind: "spike", Window: time.Second},
+		{Name: "bad-sev", Kind: AlertRate, Rate: 1, Window: time.Second, Action: AlertAction{Severity: "LOUD"}},
+		{Name: "bad-group", Kind: AlertRate, Rate: 1, Window: time.Second, GroupBy: "host"},
+	}
+	for _, r := range bad {
+		if err := r.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", r)
+		}
+	}
+}
+
+func TestLoadAlertRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.json")
+	os.WriteFile(path, []byte(`{
+		"rules": [{
+			"name": "emitter-breaker",
+			"kind": "threshold",
+			"match": {"codes": ["EMITTER_FAIL"]},
+			"count": 3,
+			"window": "1m0s",
+			"group_by": "component",
+			"action": {"severity": "ERROR", "code": "BREAKER_OPEN", "signal": "BREAKER_OPEN"}
+		}]
+	}`), 0644)
+
+	rules, err := LoadAlertRules(path)
+	if err != nil {
+		t.Fatalf("LoadAlertRules failed: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Window != time.Minute || rules[0].Action.Signal != "BREAKER_OPEN" {
+		t.Errorf("Unexpected rules: %+v", rules)
+	}
+
+	os.WriteFile(path, []byte(`{"rules": [{"name": "a", "kind": "threshold"}, {"name": "b", "kind": "rate"}]}`), 0644)
+	if _, err := LoadAlertRules(path); err == nil {
+		t.Error("Expected invalid rules to be rejected")
+	}
+}
+
+func TestEngine_WithAlertRules(t *testing.T) {
+	cfg := DefaultConfig()
+	eng, err := NewWithConfig(cfg, WithAlertRules(AlertRule{
+		Name:   "any-warning",
+		Kind:   AlertThreshold,
+		Match:  AlertMatch{MinSeverity: "WARNING"},
+		Count:  1,
+		Window: time.Minute,
+	}))
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer eng.Shutdown(t.Context())
+
+	if eng.Alerts() == nil || len(eng.Alerts().Rules()) != 1 {
+		t.Fatal("Expected alert manager with 1 rule")
+	}
+
+	sub, _ := eng.ErrorBus().SubscribeFiltered(t.Context(), event.ErrorFilter{Codes: []string{event.CodeAlertFired}})
+	deadline := time.After(2 * time.Second)
+	for {
+		eng.ErrorBus().Publish(event.NewErrorEvent(event.WarningSeverity, event.CodeEmitterFail, "emitter:test", "fail"))
+		select {
+		case <-sub.Events():
+			return
+		case <-time.After(50 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("Expected alert from engine-managed rules")
+		}
+	}
+}
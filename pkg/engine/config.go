@@ -1,9 +1,9 @@
 package engine
 
 import (
+	"errors"
 	"fmt"
-	"os"
-	"strconv"
+	"slices"
 	"time"
 )
 
@@ -11,7 +11,7 @@ import (
 // Values can be set via:
 //  1. Code (programmatic configuration)
 //  2. Environment variables (PIPELINE_*)
-//  3. Config file (future: YAML/TOML)
+//  3. Config file (LoadConfigFile)
 //
 // Precedence: Code > Env Vars > Config File > Defaults
 type Config struct {
@@ -20,11 +20,25 @@ type Config struct {
 	MemoryExitThreshold  float64       `env:"PIPELINE_MEM_EXIT_PCT" default:"0.55"`      // Exit degraded mode
 	MemoryCriticalPct    float64       `env:"PIPELINE_MEM_CRITICAL_PCT" default:"0.90"`  // Critical threshold
 	GovernorPollInterval time.Duration `env:"PIPELINE_GOVERNOR_POLL_MS" default:"50ms"`  // How often to check
+	GovernorMinScale     float64       `env:"PIPELINE_GOVERNOR_MIN_SCALE" default:"0.2"` // Scale floor (prevents starvation)
 
 	// Control Lab
 	ControlLoopInterval time.Duration `env:"PIPELINE_CONTROL_INTERVAL" default:"3s"`  // Control lab tick (kept as ControlLoopInterval for compatibility)
 	ControlCooldown     time.Duration `env:"PIPELINE_CONTROL_COOLDOWN" default:"30s"` // Min time between actions
 	MaxActionsPerLoop   int           `env:"PIPELINE_MAX_ACTIONS" default:"1"`        // Max actions per tick
+	Controller          string        `env:"PIPELINE_CONTROLLER" default:"aimd"`      // Control law: "aimd" (pressure; also "") or "pid" (lag)
+
+	// PID Controller (Controller = "pid"; targets TargetLagMs)
+	PIDKp               float64       `env:"PIPELINE_PID_KP" default:"0.05"`        // Proportional gain
+	PIDKi               float64       `env:"PIPELINE_PID_KI" default:"0.05"`        // Integral gain (per second)
+	PIDKd               float64       `env:"PIPELINE_PID_KD" default:"0.005"`       // Derivative gain (seconds)
+	PIDDerivativeFilter time.Duration `env:"PIPELINE_PID_D_FILTER" default:"200ms"` // Derivative low-pass time constant
+
+	// Scheduler
+	SchedulerTickInterval time.Duration `env:"PIPELINE_SCHEDULER_TICK" default:"10ms"` // How often due events are published
+
+	// Shutdown
+	DrainTimeout time.Duration `env:"PIPELINE_DRAIN_TIMEOUT" default:"5s"` // Max time buses drain on Shutdown (0 = close immediately)
 
 	// Queue/Buffer Sizing
 	QueueSizeStart int     `env:"PIPELINE_QUEUE_START" default:"128"`    // Initial queue size
@@ -34,26 +48,51 @@ type Config struct {
 	REDMaxDropProb float64 `env:"PIPELINE_RED_MAX_PROB" default:"0.3"`   // Max drop probability (30%)
 
 	// Worker Scaling
-	TargetLagMs int `env:"PIPELINE_TARGET_LAG_MS" default:"10"` // Target queue lag in ms
-	MinWorkers  int `env:"PIPELINE_MIN_WORKERS" default:"2"`    // Minimum worker count
-	MaxWorkers  int `env:"PIPELINE_MAX_WORKERS" default:"8"`    // Maximum worker count
+	TargetLagMs int  `env:"PIPELINE_TARGET_LAG_MS" default:"10"`  // Target queue lag in ms
+	MinWorkers  int  `env:"PIPELINE_MIN_WORKERS" default:"2"`     // Minimum worker count
+	MaxWorkers  int  `env:"PIPELINE_MAX_WORKERS" default:"8"`     // Maximum worker count
+	WorkerPool  bool `env:"PIPELINE_WORKER_POOL" default:"false"` // Run emitters on the engine worker pool
+
+	// Adapter Rate Limiting (baseline at governor scale 1.0)
+	AdapterRateLimit    float64 `env:"PIPELINE_ADAPTER_RATE" default:"0"`            // Events/sec per adapter (0 = unlimited)
+	AdapterRateBurst    int     `env:"PIPELINE_ADAPTER_BURST" default:"100"`          // Token bucket capacity
+	AdapterThrottleMode string  `env:"PIPELINE_ADAPTER_THROTTLE_MODE" default:"block"` // "block" or "reject"
 
 	// Memory Budget
 	BufferMemoryBudgetPct float64 `env:"PIPELINE_BUFFER_MEMORY_PCT" default:"0.50"` // % of limit for buffers
 
+	// GC Assist
+	GCCooldown        time.Duration `env:"PIPELINE_GC_COOLDOWN" default:"30s"`        // Min time between forced GCs
+	GCPercentDegraded int           `env:"PIPELINE_GC_PERCENT_DEGRADED" default:"50"` // GOGC while degraded (0 = don't tune)
+
 	// PSI (Pressure Stall Information) - Linux only
 	PSIEnabled       bool          `env:"PIPELINE_PSI_ENABLED" default:"true"`       // Enable PSI monitoring
 	PSIThreshold     float64       `env:"PIPELINE_PSI_THRESHOLD" default:"0.2"`      // avg10 threshold (20%)
 	PSISustainWindow time.Duration `env:"PIPELINE_PSI_SUSTAIN" default:"2s"`         // Sustain duration
-	PSIPollInterval  time.Duration `env:"PIPELINE_PSI_POLL_INTERVAL" default:"1s"`   // Polling interval
+	PSIPollInterval  time.Duration `env:"PIPELINE_PSI_POLL_INTERVAL" default:"1s"` // Polling interval
+
+	// Pressure Model (signals combined into the pressure the governor sees)
+	PressureMode              string  `env:"PIPELINE_PRESSURE_MODE" default:"max"`       // "max" or "weighted"
+	PressureWeightHeap        float64 `env:"PIPELINE_PRESSURE_W_HEAP" default:"1.0"`     // Heap usage vs memory limit
+	PressureWeightPSI         float64 `env:"PIPELINE_PRESSURE_W_PSI" default:"1.0"`      // PSI memory avg10
+	PressureWeightQueue       float64 `env:"PIPELINE_PRESSURE_W_QUEUE" default:"1.0"`    // Fullest subscription buffer
+	PressureWeightGC          float64 `env:"PIPELINE_PRESSURE_W_GC" default:"1.0"`       // GC CPU fraction
+	PressureWeightCPUThrottle float64 `env:"PIPELINE_PRESSURE_W_THROTTLE" default:"1.0"` // cgroup CPU throttling
 
 	// Flight Recorder
 	FlightRecorderSize     int           `env:"PIPELINE_FLIGHT_RECORDER_SIZE" default:"100"` // Number of snapshots
 	FlightRecorderInterval time.Duration `env:"PIPELINE_FLIGHT_RECORDER_INTERVAL" default:"1s"`
 
 	// Error Bus
-	ErrorBusBufferSize int  `env:"PIPELINE_ERROR_BUS_BUFFER" default:"32"`   // Error event buffer per sub
-	ErrorBusSampling   bool `env:"PIPELINE_ERROR_SAMPLING" default:"false"`  // Sample high-frequency errors
+	ErrorBusBufferSize int           `env:"PIPELINE_ERROR_BUS_BUFFER" default:"32"`    // Error event buffer per sub
+	ErrorBusSampling   bool          `env:"PIPELINE_ERROR_SAMPLING" default:"false"`   // Sample high-frequency errors
+	ErrorSampleWindow  time.Duration `env:"PIPELINE_ERROR_SAMPLE_WINDOW" default:"1s"` // Collapse identical errors within this window
+	ErrorSampleRate    float64       `env:"PIPELINE_ERROR_SAMPLE_RATE" default:"10"`   // Errors/sec per code before collapsing
+	ErrorSampleBurst   int           `env:"PIPELINE_ERROR_SAMPLE_BURST" default:"20"`  // Token bucket capacity per code
+	ErrorHistorySize   int           `env:"PIPELINE_ERROR_HISTORY" default:"256"`      // Recent errors kept for queries and crash reports (0 = off)
+
+	// Alerting
+	AlertRulesFile string `env:"PIPELINE_ALERT_RULES" default:""` // JSON alert rules file (see LoadAlertRules)
 
 	// AIMD Governor Tuning
 	AIMDIncrStep   float64 `env:"PIPELINE_AIMD_INCR" default:"0.05"`  // Additive increase per tick
@@ -76,11 +115,25 @@ func DefaultConfig() Config {
 		MemoryExitThreshold:  0.55,
 		MemoryCriticalPct:    0.90,
 		GovernorPollInterval: 50 * time.Millisecond,
+		GovernorMinScale:     0.2,
 
 		// Control Lab
 		ControlLoopInterval: 3 * time.Second,
 		ControlCooldown:     30 * time.Second,
 		MaxActionsPerLoop:   1,
+		Controller:          ControllerAIMD,
+
+		// PID controller
+		PIDKp:               0.05,
+		PIDKi:               0.05,
+		PIDKd:               0.005,
+		PIDDerivativeFilter: 200 * time.Millisecond,
+
+		// Scheduler
+		SchedulerTickInterval: 10 * time.Millisecond,
+
+		// Shutdown
+		DrainTimeout: 5 * time.Second,
 
 		// Queues
 		QueueSizeStart: 128,
@@ -93,16 +146,34 @@ func DefaultConfig() Config {
 		TargetLagMs: 10,
 		MinWorkers:  2,
 		MaxWorkers:  8,
+		WorkerPool:  false,
+
+		// Adapter rate limiting
+		AdapterRateLimit:    0,
+		AdapterRateBurst:    100,
+		AdapterThrottleMode: "block",
 
 		// Memory
 		BufferMemoryBudgetPct: 0.50,
 
+		// GC assist
+		GCCooldown:        30 * time.Second,
+		GCPercentDegraded: 50,
+
 		// PSI
 		PSIEnabled:       true,
 		PSIThreshold:     0.2,
 		PSISustainWindow: 2 * time.Second,
 		PSIPollInterval:  1 * time.Second,
 
+		// Pressure model
+		PressureMode:              "max",
+		PressureWeightHeap:        1.0,
+		PressureWeightPSI:         1.0,
+		PressureWeightQueue:       1.0,
+		PressureWeightGC:          1.0,
+		PressureWeightCPUThrottle: 1.0,
+
 		// Flight Recorder
 		FlightRecorderSize:     100,
 		FlightRecorderInterval: 1 * time.Second,
@@ -110,6 +181,13 @@ func DefaultConfig() Config {
 		// Error Bus
 		ErrorBusBufferSize: 32,
 		ErrorBusSampling:   false,
+		ErrorSampleWindow:  1 * time.Second,
+		ErrorSampleRate:    10,
+		ErrorSampleBurst:   20,
+		ErrorHistorySize:   256,
+
+		// Alerting
+		AlertRulesFile: "",
 
 		// AIMD
 		AIMDIncrStep:   0.05,
@@ -121,207 +199,160 @@ func DefaultConfig() Config {
 	}
 }
 
-// LoadFromEnv loads configuration from environment variables.
-// Returns a Config with defaults, overridden by any PIPELINE_* env vars found.
-func LoadFromEnv() (Config, error) {
-	cfg := DefaultConfig()
-
-	// Memory thresholds
-	if v := os.Getenv("PIPELINE_MEM_ENTER_PCT"); v != "" {
-		if pct, err := strconv.ParseFloat(v, 64); err == nil && pct > 0 && pct < 1 {
-			cfg.MemoryEnterThreshold = pct
-		}
-	}
-	if v := os.Getenv("PIPELINE_MEM_EXIT_PCT"); v != "" {
-		if pct, err := strconv.ParseFloat(v, 64); err == nil && pct > 0 && pct < 1 {
-			cfg.MemoryExitThreshold = pct
-		}
+// Validate checks that configuration values are sensible. All problems are
+// reported, joined with errors.Join.
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
 	}
-	if v := os.Getenv("PIPELINE_MEM_CRITICAL_PCT"); v != "" {
-		if pct, err := strconv.ParseFloat(v, 64); err == nil && pct > 0 && pct < 1 {
-			cfg.MemoryCriticalPct = pct
-		}
+
+	if c.MemoryEnterThreshold <= 0 || c.MemoryEnterThreshold >= 1 ||
+		c.MemoryExitThreshold <= 0 || c.MemoryExitThreshold >= 1 ||
+		c.MemoryCriticalPct <= 0 || c.MemoryCriticalPct >= 1 {
+		add("memory thresholds must be 0 < pct < 1, got enter %.2f exit %.2f critical %.2f",
+			c.MemoryEnterThreshold, c.MemoryExitThreshold, c.MemoryCriticalPct)
 	}
-	if v := os.Getenv("PIPELINE_GOVERNOR_POLL_MS"); v != "" {
-		if d, err := time.ParseDuration(v); err == nil {
-			cfg.GovernorPollInterval = d
-		}
+
+	if c.MemoryEnterThreshold <= c.MemoryExitThreshold {
+		add("memory enter threshold (%.2f) must be > exit threshold (%.2f)",
+			c.MemoryEnterThreshold, c.MemoryExitThreshold)
 	}
 
-	// Control lab
-	if v := os.Getenv("PIPELINE_CONTROL_INTERVAL"); v != "" {
-		if d, err := time.ParseDuration(v); err == nil {
-			cfg.ControlLoopInterval = d
-		}
+	if c.MemoryCriticalPct < c.MemoryEnterThreshold {
+		add("critical threshold (%.2f) must be >= enter threshold (%.2f)",
+			c.MemoryCriticalPct, c.MemoryEnterThreshold)
 	}
-	if v := os.Getenv("PIPELINE_CONTROL_COOLDOWN"); v != "" {
-		if d, err := time.ParseDuration(v); err == nil {
-			cfg.ControlCooldown = d
-		}
+
+	if c.GovernorPollInterval <= 0 {
+		add("governor poll interval must be > 0, got %s", c.GovernorPollInterval)
 	}
-	if v := os.Getenv("PIPELINE_MAX_ACTIONS"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			cfg.MaxActionsPerLoop = n
-		}
+
+	if c.GovernorMinScale <= 0 || c.GovernorMinScale > 1 {
+		add("governor min scale must be 0 < scale <= 1, got %.2f", c.GovernorMinScale)
 	}
 
-	// Queue sizing
-	if v := os.Getenv("PIPELINE_QUEUE_START"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			cfg.QueueSizeStart = n
-		}
+	if c.ControlLoopInterval <= 0 {
+		add("control loop interval must be > 0, got %s", c.ControlLoopInterval)
 	}
-	if v := os.Getenv("PIPELINE_QUEUE_MIN"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			cfg.QueueSizeMin = n
-		}
+
+	if c.MaxActionsPerLoop < 1 {
+		add("max actions per loop must be >= 1, got %d", c.MaxActionsPerLoop)
 	}
-	if v := os.Getenv("PIPELINE_QUEUE_MAX"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			cfg.QueueSizeMax = n
-		}
+
+	if c.Controller != "" && c.Controller != ControllerAIMD && c.Controller != ControllerPID {
+		add("controller must be %q or %q, got %q", ControllerAIMD, ControllerPID, c.Controller)
 	}
-	if v := os.Getenv("PIPELINE_RED_MIN_FILL"); v != "" {
-		if pct, err := strconv.ParseFloat(v, 64); err == nil && pct >= 0 && pct <= 1 {
-			cfg.REDMinFill = pct
-		}
+
+	if c.PIDKp < 0 || c.PIDKi < 0 || c.PIDKd < 0 || c.PIDDerivativeFilter < 0 {
+		add("PID gains and derivative filter must be >= 0, got %.3f/%.3f/%.3f/%s", c.PIDKp, c.PIDKi, c.PIDKd, c.PIDDerivativeFilter)
 	}
-	if v := os.Getenv("PIPELINE_RED_MAX_PROB"); v != "" {
-		if pct, err := strconv.ParseFloat(v, 64); err == nil && pct >= 0 && pct <= 1 {
-			cfg.REDMaxDropProb = pct
-		}
+
+	if c.QueueSizeMin < 1 || c.TargetLagMs < 1 || c.MinWorkers < 1 || c.MaxWorkers < 1 {
+		add("queue min, target lag and worker counts must be > 0, got %d/%dms/%d/%d",
+			c.QueueSizeMin, c.TargetLagMs, c.MinWorkers, c.MaxWorkers)
 	}
 
-	// Workers
-	if v := os.Getenv("PIPELINE_TARGET_LAG_MS"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			cfg.TargetLagMs = n
-		}
+	if c.QueueSizeMin > c.QueueSizeStart {
+		add("queue min (%d) must be <= start (%d)", c.QueueSizeMin, c.QueueSizeStart)
 	}
-	if v := os.Getenv("PIPELINE_MIN_WORKERS"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			cfg.MinWorkers = n
-		}
+
+	if c.QueueSizeStart > c.QueueSizeMax {
+		add("queue start (%d) must be <= max (%d)", c.QueueSizeStart, c.QueueSizeMax)
 	}
-	if v := os.Getenv("PIPELINE_MAX_WORKERS"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			cfg.MaxWorkers = n
-		}
+
+	if c.MinWorkers > c.MaxWorkers {
+		add("min workers (%d) must be <= max workers (%d)", c.MinWorkers, c.MaxWorkers)
 	}
 
-	// Memory budget
-	if v := os.Getenv("PIPELINE_BUFFER_MEMORY_PCT"); v != "" {
-		if pct, err := strconv.ParseFloat(v, 64); err == nil && pct > 0 && pct <= 1 {
-			cfg.BufferMemoryBudgetPct = pct
-		}
+	if c.WorkerPool && (c.MinWorkers < 1 || c.TargetLagMs <= 0) {
+		add("worker pool needs min workers >= 1 and target lag > 0, got %d/%dms", c.MinWorkers, c.TargetLagMs)
 	}
 
-	// PSI
-	if v := os.Getenv("PIPELINE_PSI_ENABLED"); v != "" {
-		cfg.PSIEnabled = v == "true" || v == "1"
+	if c.SchedulerTickInterval <= 0 {
+		add("scheduler tick interval must be > 0, got %s", c.SchedulerTickInterval)
 	}
-	if v := os.Getenv("PIPELINE_PSI_THRESHOLD"); v != "" {
-		if pct, err := strconv.ParseFloat(v, 64); err == nil && pct >= 0 && pct <= 1 {
-			cfg.PSIThreshold = pct
-		}
+
+	if c.DrainTimeout < 0 {
+		add("drain timeout must be >= 0, got %s", c.DrainTimeout)
 	}
-	if v := os.Getenv("PIPELINE_PSI_SUSTAIN"); v != "" {
-		if d, err := time.ParseDuration(v); err == nil {
-			cfg.PSISustainWindow = d
-		}
+
+	if c.AdapterRateLimit < 0 {
+		add("adapter rate limit must be >= 0, got %.2f", c.AdapterRateLimit)
 	}
-	if v := os.Getenv("PIPELINE_PSI_POLL_INTERVAL"); v != "" {
-		if d, err := time.ParseDuration(v); err == nil {
-			cfg.PSIPollInterval = d
-		}
+
+	if c.AdapterRateBurst < 1 {
+		add("adapter rate burst must be >= 1, got %d", c.AdapterRateBurst)
 	}
 
-	// Flight recorder
-	if v := os.Getenv("PIPELINE_FLIGHT_RECORDER_SIZE"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			cfg.FlightRecorderSize = n
-		}
+	if _, err := ParseThrottleMode(c.AdapterThrottleMode); err != nil {
+		errs = append(errs, err)
 	}
-	if v := os.Getenv("PIPELINE_FLIGHT_RECORDER_INTERVAL"); v != "" {
-		if d, err := time.ParseDuration(v); err == nil {
-			cfg.FlightRecorderInterval = d
-		}
+
+	if c.BufferMemoryBudgetPct <= 0 || c.BufferMemoryBudgetPct > 1 {
+		add("buffer memory budget must be 0 < pct <= 1, got %.2f", c.BufferMemoryBudgetPct)
 	}
 
-	// Error bus
-	if v := os.Getenv("PIPELINE_ERROR_BUS_BUFFER"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			cfg.ErrorBusBufferSize = n
-		}
+	if c.GCCooldown <= 0 {
+		add("GC cooldown must be > 0, got %s", c.GCCooldown)
 	}
-	if v := os.Getenv("PIPELINE_ERROR_SAMPLING"); v != "" {
-		cfg.ErrorBusSampling = v == "true" || v == "1"
+	if c.GCPercentDegraded < 0 {
+		add("GC percent while degraded must be >= 0, got %d", c.GCPercentDegraded)
 	}
 
-	// AIMD
-	if v := os.Getenv("PIPELINE_AIMD_INCR"); v != "" {
-		if val, err := strconv.ParseFloat(v, 64); err == nil && val > 0 {
-			cfg.AIMDIncrStep = val
-		}
+	if c.PSIThreshold < 0 || c.PSIThreshold > 1 {
+		add("PSI threshold must be 0 <= pct <= 1, got %.2f", c.PSIThreshold)
 	}
-	if v := os.Getenv("PIPELINE_AIMD_DECR"); v != "" {
-		if val, err := strconv.ParseFloat(v, 64); err == nil && val > 0 && val <= 1 {
-			cfg.AIMDDecrFactor = val
-		}
-	}
-	if v := os.Getenv("PIPELINE_AIMD_MAX_TICK"); v != "" {
-		if val, err := strconv.ParseFloat(v, 64); err == nil && val > 0 {
-			cfg.AIMDMaxPerTick = val
-		}
+	if c.PSIEnabled && c.PSIPollInterval <= 0 {
+		add("PSI poll interval must be > 0, got %s", c.PSIPollInterval)
 	}
 
-	// Memory limit override
-	if v := os.Getenv("PIPELINE_MEMORY_LIMIT_BYTES"); v != "" {
-		if n, err := strconv.ParseUint(v, 10, 64); err == nil {
-			cfg.MemoryLimitBytes = n
-		}
+	if _, err := ParsePressureMode(c.PressureMode); err != nil {
+		errs = append(errs, err)
+	}
+	weights := []float64{c.PressureWeightHeap, c.PressureWeightPSI, c.PressureWeightQueue, c.PressureWeightGC, c.PressureWeightCPUThrottle}
+	if slices.Min(weights) < 0 || slices.Max(weights) <= 0 {
+		add("pressure weights must be >= 0 with at least one > 0, got %v", weights)
 	}
 
-	return cfg, cfg.Validate()
-}
-
-// Validate checks that configuration values are sensible.
-func (c *Config) Validate() error {
-	if c.MemoryEnterThreshold <= c.MemoryExitThreshold {
-		return fmt.Errorf("memory enter threshold (%.2f) must be > exit threshold (%.2f)",
-			c.MemoryEnterThreshold, c.MemoryExitThreshold)
+	if c.FlightRecorderSize < 1 || c.FlightRecorderInterval <= 0 {
+		add("flight recorder needs size >= 1 and interval > 0, got %d/%s", c.FlightRecorderSize, c.FlightRecorderInterval)
 	}
 
-	if c.MemoryCriticalPct < c.MemoryEnterThreshold {
-		return fmt.Errorf("critical threshold (%.2f) must be >= enter threshold (%.2f)",
-			c.MemoryCriticalPct, c.MemoryEnterThreshold)
+	if c.ErrorBusBufferSize < 1 {
+		add("error bus buffer size must be >= 1, got %d", c.ErrorBusBufferSize)
 	}
 
-	if c.QueueSizeMin > c.QueueSizeStart {
-		return fmt.Errorf("queue min (%d) must be <= start (%d)", c.QueueSizeMin, c.QueueSizeStart)
+	if c.ErrorHistorySize < 0 {
+		add("error history size must be >= 0, got %d", c.ErrorHistorySize)
 	}
 
-	if c.QueueSizeStart > c.QueueSizeMax {
-		return fmt.Errorf("queue start (%d) must be <= max (%d)", c.QueueSizeStart, c.QueueSizeMax)
+	if c.ErrorBusSampling {
+		if c.ErrorSampleWindow <= 0 {
+			add("error sample window must be > 0, got %s", c.ErrorSampleWindow)
+		}
+		if c.ErrorSampleRate <= 0 || c.ErrorSampleBurst < 1 {
+			add("error sample rate must be > 0 with burst >= 1, got %.2f/%d", c.ErrorSampleRate, c.ErrorSampleBurst)
+		}
 	}
 
-	if c.MinWorkers > c.MaxWorkers {
-		return fmt.Errorf("min workers (%d) must be <= max workers (%d)", c.MinWorkers, c.MaxWorkers)
+	if c.REDMinFill < 0 || c.REDMinFill >= 1.0 {
+		add("RED min fill must be 0 <= fill < 1.0, got %.2f", c.REDMinFill)
 	}
 
-	if c.BufferMemoryBudgetPct <= 0 || c.BufferMemoryBudgetPct > 1 {
-		return fmt.Errorf("buffer memory budget must be 0 < pct <= 1, got %.2f", c.BufferMemoryBudgetPct)
+	if c.REDMaxDropProb < 0 || c.REDMaxDropProb > 1 {
+		add("RED max drop probability must be 0 <= prob <= 1, got %.2f", c.REDMaxDropProb)
 	}
 
-	if c.REDMinFill >= 1.0 {
-		return fmt.Errorf("RED min fill must be < 1.0, got %.2f", c.REDMinFill)
+	if c.AIMDIncrStep <= 0 || c.AIMDMaxPerTick <= 0 {
+		add("AIMD increase step and max per tick must be > 0, got %.2f/%.2f", c.AIMDIncrStep, c.AIMDMaxPerTick)
 	}
 
 	if c.AIMDDecrFactor <= 0 || c.AIMDDecrFactor > 1 {
-		return fmt.Errorf("AIMD decrease factor must be 0 < factor <= 1, got %.2f", c.AIMDDecrFactor)
+		add("AIMD decrease factor must be 0 < factor <= 1, got %.2f", c.AIMDDecrFactor)
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // String returns a human-readable summary of the configuration.
@@ -331,6 +362,7 @@ func (c *Config) String() string {
     Enter Degraded: %.0f%%
     Exit Degraded:  %.0f%%
     Critical:       %.0f%%
+    Min Scale:      %.0f%%
 
   Queues:
     Start Size: %d
@@ -342,16 +374,21 @@ func (c *Config) String() string {
     Min: %d
     Max: %d
     Target Lag: %dms
+    Pool: %t
 
   Control Lab:
     Interval: %s
     Cooldown: %s
+    Max Actions: %d
+    Controller: %s
+    Pressure: %s (heap %.1f, psi %.1f, queue %.1f, gc %.1f, throttle %.1f)
 
   Memory Limit: %s
 `,
 		c.MemoryEnterThreshold*100,
 		c.MemoryExitThreshold*100,
 		c.MemoryCriticalPct*100,
+		c.GovernorMinScale*100,
 		c.QueueSizeStart,
 		c.QueueSizeMin,
 		c.QueueSizeMax,
@@ -359,8 +396,17 @@ func (c *Config) String() string {
 		c.MinWorkers,
 		c.MaxWorkers,
 		c.TargetLagMs,
+		c.WorkerPool,
 		c.ControlLoopInterval,
 		c.ControlCooldown,
+		c.MaxActionsPerLoop,
+		c.Controller,
+		c.PressureMode,
+		c.PressureWeightHeap,
+		c.PressureWeightPSI,
+		c.PressureWeightQueue,
+		c.PressureWeightGC,
+		c.PressureWeightCPUThrottle,
 		formatMemoryLimit(c.MemoryLimitBytes),
 	)
 }
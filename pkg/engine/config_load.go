@@ -0,0 +1,282 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+	"go.yaml.in/yaml/v2"
+)
+
+// ConfigSource records where a Config value came from.
+type ConfigSource string
+
+const (
+	SourceDefault ConfigSource = "default" // DefaultConfig
+	SourceFile    ConfigSource = "file"    // Config file passed to LoadConfig
+	SourceEnv     ConfigSource = "env"     // PIPELINE_* environment variable
+)
+
+// ConfigSources maps Config field names to the source of their value.
+type ConfigSources map[string]ConfigSource
+
+// ConfigField describes one Config field and its effective value.
+type ConfigField struct {
+	Name    string // Go field name (also accepted as a file key)
+	Env     string // Environment variable (from the env tag)
+	Default string // Documented default (from the default tag)
+	Value   string // Effective value, formatted
+	Source  ConfigSource
+}
+
+// configFieldMeta is the reflected name and tags of a Config field.
+type configFieldMeta struct {
+	index int
+	name  string
+	env   string
+	def   string
+}
+
+// configMeta lists Config fields in declaration order.
+func configMeta() []configFieldMeta {
+	t := reflect.TypeOf(Config{})
+	meta := make([]configFieldMeta, t.NumField())
+	for i := range meta {
+		f := t.Field(i)
+		meta[i] = configFieldMeta{index: i, name: f.Name, env: f.Tag.Get("env"), def: f.Tag.Get("default")}
+	}
+	return meta
+}
+
+// LoadFromEnv loads configuration from environment variables.
+// Returns a Config with defaults, overridden by any PIPELINE_* env vars found.
+// Unparsable values are reported rather than ignored (see LoadConfig).
+func LoadFromEnv() (Config, error) {
+	cfg, _, err := LoadConfig("")
+	return cfg, err
+}
+
+// LoadConfigFile loads configuration from a JSON or YAML file with env
+// overlay (see LoadConfig).
+func LoadConfigFile(path string) (Config, error) {
+	cfg, _, err := LoadConfig(path)
+	return cfg, err
+}
+
+// LoadConfig builds a Config from DefaultConfig, then the file at path (if
+// not empty), then PIPELINE_* environment variables named by the `env`
+// struct tags. It returns where each value came from.
+//
+// The file is YAML for .yaml/.yml paths and JSON otherwise: a flat object
+// keyed by field name (MemoryEnterThreshold) or env var name
+// (PIPELINE_MEM_ENTER_PCT). Durations are strings such as "10s".
+//
+// Every unknown key, unparsable value and Validate failure is reported,
+// joined with errors.Join. The returned Config holds all values that parsed.
+func LoadConfig(path string) (Config, ConfigSources, error) {
+	cfg := DefaultConfig()
+	meta := configMeta()
+	sources := make(ConfigSources, len(meta))
+	for _, m := range meta {
+		sources[m.name] = SourceDefault
+	}
+
+	var errs []error
+	if path != "" {
+		// Keep going on file errors so env and field errors are reported too
+		values, err := readConfigFile(path)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		keys := make(map[string]configFieldMeta, 2*len(meta))
+		for _, m := range meta {
+			keys[strings.ToLower(m.name)] = m
+			keys[m.env] = m
+		}
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			raw := values[key]
+			m, ok := keys[key]
+			if !ok {
+				m, ok = keys[strings.ToLower(key)]
+			}
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown key %q", path, key))
+				continue
+			}
+			if err := setConfigField(&cfg, m, raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s: %w", path, key, err))
+				continue
+			}
+			sources[m.name] = SourceFile
+		}
+	}
+
+	for _, m := range meta {
+		raw := os.Getenv(m.env)
+		if m.env == "" || raw == "" {
+			continue
+		}
+		if err := setConfigField(&cfg, m, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.env, err))
+			continue
+		}
+		sources[m.name] = SourceEnv
+	}
+
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return cfg, sources, errors.Join(errs...)
+}
+
+// readConfigFile reads a flat JSON or YAML object as key → raw string value.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config file: %w", err)
+	}
+
+	var doc map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	default:
+		doc, err = unmarshalJSONObject(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file: parse %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(doc))
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(doc)) {
+		switch v := doc[key].(type) {
+		case jsonNumber:
+			values[key] = string(v)
+		case string:
+			values[key] = v
+		case bool:
+			values[key] = strconv.FormatBool(v)
+		case int:
+			values[key] = strconv.Itoa(v)
+		case int64:
+			values[key] = strconv.FormatInt(v, 10)
+		case uint64:
+			values[key] = strconv.FormatUint(v, 10)
+		case float64:
+			values[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		case nil:
+			values[key] = ""
+		default:
+			errs = append(errs, fmt.Errorf("%s: %s: want a string, number or bool, got %T", path, key, v))
+		}
+	}
+	return values, errors.Join(errs...)
+}
+
+// jsonNumber is a JSON number literal, kept as text.
+type jsonNumber string
+
+// unmarshalJSONObject decodes a JSON object like json.Unmarshal into
+// map[string]any, except that numbers stay jsonNumber literals so large
+// integers (MemoryLimitBytes) keep full precision.
+func unmarshalJSONObject(data []byte) (map[string]any, error) {
+	var raw map[string]jsontext.Value
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	doc := make(map[string]any, len(raw))
+	for key, v := range raw {
+		if v.Kind() == '0' {
+			doc[key] = jsonNumber(v)
+			continue
+		}
+		var x any
+		if err := json.Unmarshal(v, &x); err != nil {
+			return nil, err
+		}
+		doc[key] = x
+	}
+	return doc, nil
+}
+
+// setConfigField parses raw into the field described by m.
+func setConfigField(cfg *Config, m configFieldMeta, raw string) error {
+	v := reflect.ValueOf(cfg).Elem().Field(m.index)
+	raw = strings.TrimSpace(raw)
+
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(f)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(int64(n))
+	case reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", raw)
+		}
+		v.SetUint(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", raw)
+		}
+		v.SetBool(b)
+	case reflect.String:
+		v.SetString(raw)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// ConfigFields lists every Config field with its env var, documented
+// default, effective value in cfg and source. Fields missing from sources
+// are reported as SourceDefault.
+func ConfigFields(cfg Config, sources ConfigSources) []ConfigField {
+	v := reflect.ValueOf(cfg)
+	meta := configMeta()
+	fields := make([]ConfigField, len(meta))
+	for i, m := range meta {
+		source := sources[m.name]
+		if source == "" {
+			source = SourceDefault
+		}
+		fields[i] = ConfigField{
+			Name:    m.name,
+			Env:     m.env,
+			Default: m.def,
+			Value:   fmt.Sprint(v.Field(m.index).Interface()),
+			Source:  source,
+		}
+	}
+	return fields
+}
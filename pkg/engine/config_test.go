@@ -0,0 +1,401 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+	"github.com/BYTE-6D65/pipeline/pkg/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// newEnvEngine builds an engine from LoadFromEnv with env applied. The
+// control lab poll is parked so only the test drives the governor.
+func newEnvEngine(t *testing.T, env map[string]string, opts ...EngineOption) *Engine {
+	t.Helper()
+	t.Setenv("PIPELINE_GOVERNOR_POLL_MS", "1h")
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv failed: %v", err)
+	}
+	eng, err := NewWithConfig(cfg, opts...)
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	t.Cleanup(func() { eng.Shutdown(context.Background()) })
+	return eng
+}
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestEngine_EnvConfiguresGovernor(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		check func(t *testing.T, eng *Engine, clk *clock.DeltaClock)
+	}{
+		{
+			name: "PIPELINE_MEM_ENTER_PCT",
+			env:  map[string]string{"PIPELINE_MEM_ENTER_PCT": "0.60"},
+			check: func(t *testing.T, eng *Engine, clk *clock.DeltaClock) {
+				eng.Governor().Update(0.65) // Below the 0.70 default
+				if eng.Governor().State() != StateDegraded {
+					t.Errorf("Expected DEGRADED at 65%%, got %s", eng.Governor().State())
+				}
+			},
+		},
+		{
+			name: "PIPELINE_MEM_EXIT_PCT",
+			env:  map[string]string{"PIPELINE_MEM_EXIT_PCT": "0.40"},
+			check: func(t *testing.T, eng *Engine, clk *clock.DeltaClock) {
+				eng.Governor().Update(0.75)
+				eng.Governor().Update(0.50) // Below the 0.55 default
+				if eng.Governor().State() != StateDegraded {
+					t.Errorf("Expected to stay DEGRADED at 50%%, got %s", eng.Governor().State())
+				}
+			},
+		},
+		{
+			name: "PIPELINE_MEM_CRITICAL_PCT",
+			env: map[string]string{
+				"PIPELINE_MEM_CRITICAL_PCT": "0.80",
+				"PIPELINE_CONTROL_COOLDOWN": "1s",
+			},
+			check: func(t *testing.T, eng *Engine, clk *clock.DeltaClock) {
+				eng.Governor().Update(0.75) // 0.5
+				clk.Advance()
+				eng.Governor().Update(0.85) // Critical below the 0.90 default
+				if s := eng.Governor().Scale(); !approx(s, 0.25) {
+					t.Errorf("Expected critical decrease to 0.25, got %.2f", s)
+				}
+			},
+		},
+		{
+			name: "PIPELINE_CONTROL_COOLDOWN",
+			env:  map[string]string{"PIPELINE_CONTROL_COOLDOWN": "1s"},
+			check: func(t *testing.T, eng *Engine, clk *clock.DeltaClock) {
+				eng.Governor().Update(0.75) // 0.5
+				eng.Governor().Update(0.10) // Recovering
+				clk.Advance()
+				eng.Governor().Update(0.10) // 30s default would block this
+				if s := eng.Governor().Scale(); !approx(s, 0.55) {
+					t.Errorf("Expected increase to 0.55, got %.2f", s)
+				}
+			},
+		},
+		{
+			name: "PIPELINE_GOVERNOR_MIN_SCALE",
+			env: map[string]string{
+				"PIPELINE_GOVERNOR_MIN_SCALE": "0.4",
+				"PIPELINE_CONTROL_COOLDOWN":   "1s",
+			},
+			check: func(t *testing.T, eng *Engine, clk *clock.DeltaClock) {
+				for i := 0; i < 5; i++ {
+					eng.Governor().Update(0.95)
+					clk.Advance()
+				}
+				if s := eng.Governor().Scale(); !approx(s, 0.4) {
+					t.Errorf("Expected scale floor 0.4, got %.2f", s)
+				}
+			},
+		},
+		{
+			name: "PIPELINE_AIMD_DECR",
+			env:  map[string]string{"PIPELINE_AIMD_DECR": "0.8"},
+			check: func(t *testing.T, eng *Engine, clk *clock.DeltaClock) {
+				eng.Governor().Update(0.75)
+				if s := eng.Governor().Scale(); !approx(s, 0.8) {
+					t.Errorf("Expected decrease to 0.8, got %.2f", s)
+				}
+			},
+		},
+		{
+			name: "PIPELINE_AIMD_INCR",
+			env: map[string]string{
+				"PIPELINE_AIMD_INCR":        "0.08",
+				"PIPELINE_CONTROL_COOLDOWN": "1s",
+			},
+			check: func(t *testing.T, eng *Engine, clk *clock.DeltaClock) {
+				eng.Governor().Update(0.75) // 0.5
+				eng.Governor().Update(0.10) // Recovering
+				clk.Advance()
+				eng.Governor().Update(0.10)
+				if s := eng.Governor().Scale(); !approx(s, 0.58) {
+					t.Errorf("Expected increase to 0.58, got %.2f", s)
+				}
+			},
+		},
+		{
+			name: "PIPELINE_AIMD_MAX_TICK",
+			env: map[string]string{
+				"PIPELINE_AIMD_INCR":        "0.3",
+				"PIPELINE_AIMD_MAX_TICK":    "0.15",
+				"PIPELINE_CONTROL_COOLDOWN": "1s",
+			},
+			check: func(t *testing.T, eng *Engine, clk *clock.DeltaClock) {
+				eng.Governor().Update(0.75) // 0.5, uncapped decrease
+				eng.Governor().Update(0.10) // Recovering
+				clk.Advance()
+				eng.Governor().Update(0.10)
+				if s := eng.Governor().Scale(); !approx(s, 0.65) {
+					t.Errorf("Expected increase capped to 0.65, got %.2f", s)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Each Advance steps one second, past the 1s test cooldowns
+			clk := clock.NewDeltaClock()
+			clk.SetNoSleep(true)
+			clk.Load(0, []time.Duration{time.Second, time.Second, time.Second, time.Second, time.Second})
+			tt.check(t, newEnvEngine(t, tt.env, WithClock(clk)), clk)
+		})
+	}
+}
+
+func TestEngine_EnvConfiguresRED(t *testing.T) {
+	eng := newEnvEngine(t, map[string]string{
+		"PIPELINE_RED_MIN_FILL": "0.8",
+		"PIPELINE_RED_MAX_PROB": "0.5",
+	})
+
+	if p := eng.RED().DropProbability(0.7); p != 0 {
+		t.Errorf("Expected no drops below 80%% fill, got %.2f", p)
+	}
+	if p := eng.RED().DropProbability(0.9); !approx(p, 0.25) {
+		t.Errorf("Expected 0.25 drop probability at 90%% fill, got %.2f", p)
+	}
+	if p := eng.RED().DropProbability(1.0); !approx(p, 0.5) {
+		t.Errorf("Expected 0.5 drop probability when full, got %.2f", p)
+	}
+}
+
+func TestEngine_EnvConfiguresMonitors(t *testing.T) {
+	t.Run("PIPELINE_MEMORY_LIMIT_BYTES", func(t *testing.T) {
+		eng := newEnvEngine(t, map[string]string{"PIPELINE_MEMORY_LIMIT_BYTES": "1073741824"})
+		if eng.memoryLimit != 1<<30 || eng.memoryLimitSrc != "config" {
+			t.Errorf("Expected 1GiB limit from config, got %d (%s)", eng.memoryLimit, eng.memoryLimitSrc)
+		}
+	})
+
+	t.Run("PIPELINE_PSI_ENABLED", func(t *testing.T) {
+		if eng := newEnvEngine(t, nil); eng.PSIMonitor() == nil {
+			t.Error("Expected PSI monitor by default")
+		}
+		if eng := newEnvEngine(t, map[string]string{"PIPELINE_PSI_ENABLED": "false"}); eng.PSIMonitor() != nil {
+			t.Error("Expected no PSI monitor when disabled")
+		}
+	})
+
+	t.Run("PIPELINE_MAX_ACTIONS", func(t *testing.T) {
+		lab := newEnvEngine(t, map[string]string{"PIPELINE_MAX_ACTIONS": "2"}).ControlLab()
+		for i := 0; i < 2; i++ {
+			if !lab.allowAction() {
+				t.Fatalf("Expected action %d within budget", i+1)
+			}
+		}
+		if lab.allowAction() || lab.SkippedActions() != 1 {
+			t.Errorf("Expected third action skipped, skipped=%d", lab.SkippedActions())
+		}
+	})
+
+	t.Run("PIPELINE_CONTROL_INTERVAL", func(t *testing.T) {
+		clk := clock.NewDeltaClock()
+		clk.SetNoSleep(true)
+		clk.Load(0, []time.Duration{time.Second})
+		lab := newEnvEngine(t, map[string]string{
+			"PIPELINE_CONTROL_INTERVAL": "1s",
+			"PIPELINE_MAX_ACTIONS":      "1",
+		}, WithClock(clk)).ControlLab()
+		lab.allowAction()
+		if lab.allowAction() {
+			t.Fatal("Expected the budget spent within the control interval")
+		}
+		clk.Advance()
+		if !lab.allowAction() {
+			t.Error("Expected a new action window after the control interval")
+		}
+	})
+}
+
+func TestConfig_ValidateWiredFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Config)
+		want   string
+	}{
+		{"min scale", func(c *Config) { c.GovernorMinScale = 0 }, "governor min scale"},
+		{"control interval", func(c *Config) { c.ControlLoopInterval = 0 }, "control loop interval"},
+		{"max actions", func(c *Config) { c.MaxActionsPerLoop = 0 }, "max actions"},
+		{"RED max prob", func(c *Config) { c.REDMaxDropProb = 1.5 }, "RED max drop probability"},
+		{"AIMD max tick", func(c *Config) { c.AIMDMaxPerTick = 0 }, "AIMD increase step"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.mutate(&cfg)
+			if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected %q error, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_FileAndEnvSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipeline.yaml")
+	data := "MemoryEnterThreshold: 0.65\ncontrolcooldown: 10s\nPIPELINE_PSI_ENABLED: false\nMemoryLimitBytes: 2147483648\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PIPELINE_CONTROL_COOLDOWN", "20s") // Env wins over the file
+
+	cfg, sources, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.MemoryEnterThreshold != 0.65 || cfg.ControlCooldown != 20*time.Second || cfg.PSIEnabled || cfg.MemoryLimitBytes != 2<<30 {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+
+	want := map[string]ConfigSource{
+		"MemoryEnterThreshold": SourceFile,
+		"ControlCooldown":      SourceEnv,
+		"PSIEnabled":           SourceFile,
+		"MemoryExitThreshold":  SourceDefault,
+	}
+	for field, source := range want {
+		if sources[field] != source {
+			t.Errorf("Expected %s from %s, got %s", field, source, sources[field])
+		}
+	}
+
+	for _, f := range ConfigFields(cfg, sources) {
+		if f.Name == "ControlCooldown" && (f.Value != "20s" || f.Env != "PIPELINE_CONTROL_COOLDOWN" || f.Default != "30s") {
+			t.Errorf("Unexpected field description: %+v", f)
+		}
+	}
+}
+
+func TestLoadConfig_ReportsAllErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipeline.json")
+	data := `{"MemoryEnterThreshold": 0.4, "NoSuchField": 1, "DrainTimeout": "soon"}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PIPELINE_RED_MAX_PROB", "lots")
+	t.Setenv("PIPELINE_PSI_ENABLED", "maybe")
+
+	_, _, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("Expected errors")
+	}
+	for _, want := range []string{
+		`unknown key "NoSuchField"`,
+		`DrainTimeout: invalid duration "soon"`,
+		`PIPELINE_RED_MAX_PROB: invalid number "lots"`,
+		`PIPELINE_PSI_ENABLED: invalid bool "maybe"`,
+		"memory enter threshold (0.40) must be > exit threshold (0.55)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error list to contain %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadConfig_JSONTypesAndPrecision(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipeline.json")
+	data := `{"MemoryLimitBytes": 9007199254740993, "QueueSizeStart": [1], "AlertRulesFile": {"path": "x"}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PIPELINE_PSI_ENABLED", "maybe")
+
+	cfg, _, err := LoadConfig(path)
+	if cfg.MemoryLimitBytes != 9007199254740993 { // 2^53 + 1: lost as float64
+		t.Errorf("Expected MemoryLimitBytes at full precision, got %d", cfg.MemoryLimitBytes)
+	}
+
+	// Type errors in the file do not hide env errors; file errors are in key order
+	msg := fmt.Sprint(err)
+	alerts, queue := strings.Index(msg, "AlertRulesFile: want"), strings.Index(msg, "QueueSizeStart: want")
+	if alerts < 0 || queue < alerts || !strings.Contains(msg, `PIPELINE_PSI_ENABLED: invalid bool "maybe"`) {
+		t.Errorf("Expected sorted file type errors and the env error, got:\n%v", err)
+	}
+}
+
+func TestConfig_TagsMatchDefaultConfig(t *testing.T) {
+	tagged := DefaultConfig()
+	envs := make(map[string]bool)
+	for _, m := range configMeta() {
+		if m.env == "" || envs[m.env] {
+			t.Errorf("%s: missing or duplicate env tag %q", m.name, m.env)
+		}
+		envs[m.env] = true
+		if err := setConfigField(&tagged, m, m.def); err != nil {
+			t.Errorf("%s: default tag: %v", m.name, err)
+		}
+	}
+	if tagged != DefaultConfig() {
+		t.Errorf("Default tags disagree with DefaultConfig:\n%+v\n%+v", tagged, DefaultConfig())
+	}
+}
+
+func TestNewWithConfig_UsesOptionClock(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	eng := newEnvEngine(t, nil, WithClock(clk))
+
+	if g := eng.Governor(); g.clock != clock.Clock(clk) {
+		t.Error("Expected the governor on the option clock")
+	}
+	if eng.RateLimiter().clock != clock.Clock(clk) || eng.ControlLab().clock != clock.Clock(clk) {
+		t.Error("Expected the rate limiter and control lab on the option clock")
+	}
+}
+
+func TestNewWithConfig_UsesOptionMetrics(t *testing.T) {
+	metrics := telemetry.InitMetrics(prometheus.NewRegistry())
+	telemetry.InitMetrics(prometheus.NewRegistry()) // Default no longer matches the option
+	eng := newEnvEngine(t, nil, WithMetrics(metrics))
+
+	if eng.RateLimiter().metrics != metrics {
+		t.Error("Expected the rate limiter to report to the option metrics")
+	}
+}
+
+func TestNewWithConfig_ReleasesOnError(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AlertRulesFile = filepath.Join(t.TempDir(), "missing.yaml")
+	sink := &closeCountingSink{}
+	if _, err := NewWithConfig(cfg, WithErrorSink(sink)); err == nil {
+		t.Fatal("Expected a missing alert rules file to fail")
+	}
+	if sink.closed.Load() != 1 {
+		t.Errorf("Expected the attached sink closed once, got %d", sink.closed.Load())
+	}
+}
+
+// closeCountingSink discards events and counts Close calls.
+type closeCountingSink struct {
+	closed atomic.Int32
+}
+
+func (s *closeCountingSink) Write(event.ErrorEvent) error { return nil }
+func (s *closeCountingSink) Close() error                 { s.closed.Add(1); return nil }
@@ -3,6 +3,8 @@ package engine
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/BYTE-6D65/pipeline/pkg/clock"
@@ -16,21 +18,22 @@ import (
 // analyzes system state and produces control decisions, not a loop.
 //
 // Event Flow:
-//   - Input: Reads memory stats directly (no event subscription)
-//   - Analysis: Calculates desired governor scale based on AIMD algorithm
+//   - Input: Samples pressure signals directly (no event subscription)
+//   - Analysis: Combines them in the PressureModel, then lets the Controller
+//     (AIMD governor by default) calculate the desired scale
 //   - Output: Publishes GovernorScaleCommand to InternalBus
 //   - Observability: Publishes state changes to ErrorBus
 //
 // The control lab manages:
-//   - AIMD Governor: Scales based on memory pressure (governor handles cooldown internally)
+//   - Controller: Scales based on combined pressure (AIMD handles cooldown internally)
 //   - RED Dropper: Tracks buffer saturation (future integration)
 //
 // It emits control events when state changes occur (e.g., entering degraded mode).
 type ControlLab struct {
 	// Components
-	errorBus    *event.ErrorBus       // Write-only (emit observability events)
-	internalBus *event.InMemoryBus    // Write-only (emit control commands)
-	governor    *AIMDGovernor
+	errorBus    *event.ErrorBus // Write-only (emit observability events)
+	internalBus event.Bus       // Write-only (emit control commands)
+	controller  Controller
 	red         *REDDropper
 
 	// Time source
@@ -40,9 +43,33 @@ type ControlLab struct {
 	memoryLimit  uint64        // Memory limit for polling ReadMemoryStatsFast
 	pollInterval time.Duration // How often to poll and update (e.g., 50ms)
 
+	// Pressure input (see SetPressureModel, SetPressureSampler)
+	pressure    *PressureModel
+	sample      func() PressureSignals
+	lastReading atomic.Pointer[PressureReading]
+
+	// Lag input (see SetLagSampler)
+	sampleLag func() time.Duration
+
 	// State tracking
 	lastState GovernorState
 	lastScale float64
+
+	// Settings replaced while running (SetForceGC, SetActionBudget)
+	settingsMu sync.Mutex
+
+	// Forced GC requests (see SetForceGC)
+	gcCriticalPct float64       // Request GC at or above this pressure (0 = never)
+	gcInterval    time.Duration // Min time between requests
+	lastGCRequest clock.MonoTime
+	gcRequested   bool
+
+	// Action budget (see SetActionBudget)
+	maxActions     int           // Commands allowed per window (0 = unlimited)
+	actionWindow   time.Duration // Window length (Config.ControlLoopInterval)
+	windowStart    clock.MonoTime
+	windowActions  int
+	skippedActions atomic.Uint64
 }
 
 // NewControlLab creates a new control lab.
@@ -51,46 +78,138 @@ type ControlLab struct {
 //   - clk: Clock for time tracking (use engine's clock for consistency)
 //   - errorBus: Error bus for emitting observability events (write-only)
 //   - internalBus: Internal bus for emitting control commands (write-only)
-//   - governor: AIMD governor to read state (governor subscribes to internalBus separately)
+//   - controller: Control law to update (AIMDGovernor subscribes to internalBus separately)
 //   - red: RED dropper for future integration
 //   - memoryLimit: Memory limit for polling state
 //   - pollInterval: How often to poll and update (e.g., 50ms)
-func NewControlLab(clk clock.Clock, errorBus *event.ErrorBus, internalBus *event.InMemoryBus, governor *AIMDGovernor, red *REDDropper, memoryLimit uint64, pollInterval time.Duration) *ControlLab {
+//
+// The lab starts with HeapOnlyPressureModel; the engine installs the
+// configured model and sampler.
+func NewControlLab(clk clock.Clock, errorBus *event.ErrorBus, internalBus event.Bus, controller Controller, red *REDDropper, memoryLimit uint64, pollInterval time.Duration) *ControlLab {
 	return &ControlLab{
 		clock:        clk,
 		errorBus:     errorBus,
 		internalBus:  internalBus,
-		governor:     governor,
+		controller:   controller,
 		red:          red,
 		memoryLimit:  memoryLimit,
 		pollInterval: pollInterval,
-		lastState:    governor.State(),
-		lastScale:    governor.Scale(),
+		pressure:     HeapOnlyPressureModel(),
+		sample:       NewPressureSampler(memoryLimit).Sample,
+		lastState:    controller.State(),
+		lastScale:    controller.Scale(),
+	}
+}
+
+// SetPressureModel replaces the model that combines pressure signals.
+// Call before Start; use PressureModel().ApplyConfig to retune while running.
+func (cl *ControlLab) SetPressureModel(model *PressureModel) {
+	cl.pressure = model
+}
+
+// SetPressureSampler replaces the function that reads pressure signals each
+// poll (NewPressureSampler(...).Sample by default). Call before Start.
+func (cl *ControlLab) SetPressureSampler(sample func() PressureSignals) {
+	cl.sample = sample
+}
+
+// SetLagSampler sets the function that measures subscription lag each poll
+// for ControlSignals.Lag (e.g. LagMeter.Measure). Without one, Lag is 0.
+// Call before Start.
+func (cl *ControlLab) SetLagSampler(sample func() time.Duration) {
+	cl.sampleLag = sample
+}
+
+// PressureModel returns the model that combines pressure signals.
+func (cl *ControlLab) PressureModel() *PressureModel {
+	return cl.pressure
+}
+
+// LastPressure returns the most recent pressure reading, including the
+// signal that drove it. ok is false before the first poll.
+func (cl *ControlLab) LastPressure() (reading PressureReading, ok bool) {
+	if r := cl.lastReading.Load(); r != nil {
+		return *r, true
+	}
+	return PressureReading{}, false
+}
+
+// SetForceGC makes the lab publish a ForceGCCommand to the internal bus when
+// memory pressure reaches criticalPct, at most once per interval while it
+// stays there. Safe to call while running.
+func (cl *ControlLab) SetForceGC(criticalPct float64, interval time.Duration) {
+	cl.settingsMu.Lock()
+	defer cl.settingsMu.Unlock()
+	cl.gcCriticalPct = criticalPct
+	cl.gcInterval = interval
+}
+
+// SetActionBudget limits the lab to maxActions control commands (governor
+// scale and forced GC requests) per window. Commands over budget are skipped
+// and counted (see SkippedActions); a skipped GC request is retried on the
+// next poll. Safe to call while running; the current window restarts.
+func (cl *ControlLab) SetActionBudget(maxActions int, window time.Duration) {
+	cl.settingsMu.Lock()
+	defer cl.settingsMu.Unlock()
+	cl.maxActions = maxActions
+	cl.actionWindow = window
+	cl.windowStart = cl.clock.Now()
+	cl.windowActions = 0
+}
+
+// allowAction spends one action from the current window's budget.
+func (cl *ControlLab) allowAction() bool {
+	cl.settingsMu.Lock()
+	defer cl.settingsMu.Unlock()
+	if cl.maxActions <= 0 {
+		return true
+	}
+	now := cl.clock.Now()
+	if clock.ToDuration(now-cl.windowStart) >= cl.actionWindow {
+		cl.windowStart = now
+		cl.windowActions = 0
 	}
+	if cl.windowActions >= cl.maxActions {
+		cl.skippedActions.Add(1)
+		return false
+	}
+	cl.windowActions++
+	return true
+}
+
+// SkippedActions returns how many control commands the action budget skipped.
+func (cl *ControlLab) SkippedActions() uint64 {
+	return cl.skippedActions.Load()
 }
 
 // Start begins the control lab's analysis in a background goroutine.
 //
 // The lab:
 //  1. Polls memory state directly (no event subscription)
-//  2. Updates governor based on current pressure (governor handles cooldown internally)
+//  2. Updates the controller based on current pressure (AIMD handles cooldown internally)
 //  3. Emits observability events when state changes (one-way out)
 //
 // Stops when context is cancelled.
 func (cl *ControlLab) Start(ctx context.Context) {
+	cl.settingsMu.Lock()
+	maxActions, window := cl.maxActions, cl.actionWindow
+	cl.settingsMu.Unlock()
+
 	// Emit startup event (observability)
 	cl.errorBus.Publish(event.NewErrorEvent(
 		event.InfoSeverity,
 		event.CodeHealthCheck,
 		"control-lab",
 		"Control lab started",
-	).WithContext("poll_interval", cl.pollInterval.String()))
+	).WithContext("poll_interval", cl.pollInterval.String()).
+		WithContext("max_actions", maxActions).
+		WithContext("action_window", window.String()))
 
 	// Start periodic governor updates
 	go cl.runGovernor(ctx)
 }
 
-// runGovernor periodically polls state and updates the AIMD governor.
+// runGovernor periodically polls state and updates the controller.
 func (cl *ControlLab) runGovernor(ctx context.Context) {
 	ticker := time.NewTicker(cl.pollInterval)
 	defer ticker.Stop()
@@ -105,48 +224,59 @@ func (cl *ControlLab) runGovernor(ctx context.Context) {
 	}
 }
 
-// updateGovernor polls memory state, calculates desired scale, and publishes control commands.
+// updateGovernor polls pressure, calculates desired scale, and publishes control commands.
 //
 // Hybrid approach (Phase 3 transition):
-//  1. Read memory pressure
-//  2. Calculate desired scale using AIMD logic
+//  1. Sample pressure signals and combine them (PressureModel)
+//  2. Calculate desired scale using the controller (AIMD by default)
 //  3. Publish GovernorScaleCommand to InternalBus (event-driven)
-//  4. Also call governor.Update() directly (for backward compatibility during transition)
+//  4. Also call controller.Update() directly (for backward compatibility during transition)
 //  5. Emit observability events when state changes
 //
-// TODO: Remove direct governor.Update() call once fully event-driven (Phase 4+)
+// TODO: Remove direct controller.Update() call once fully event-driven (Phase 4+)
 func (cl *ControlLab) updateGovernor() {
-	// Poll memory state directly (no events)
-	stats := ReadMemoryStatsFast(cl.memoryLimit)
-	memPressure := stats.UsagePct
+	// Poll pressure signals directly (no events)
+	reading := cl.pressure.Combine(cl.sample())
+	cl.lastReading.Store(&reading)
 
 	// Save previous state/scale to detect changes
-	prevScale := cl.governor.Scale()
+	prevScale := cl.controller.Scale()
 
-	// Update governor directly (backward compatibility - will be removed in Phase 4+)
-	cl.governor.Update(memPressure)
+	// Update controller directly (backward compatibility - will be removed in Phase 4+)
+	signals := ControlSignals{Pressure: reading}
+	if cl.sampleLag != nil {
+		signals.Lag = cl.sampleLag()
+	}
+	cl.controller.Update(signals)
 
 	// Check for changes
-	currentState := cl.governor.State()
-	currentScale := cl.governor.Scale()
+	currentState := cl.controller.State()
+	currentScale := cl.controller.Scale()
 
 	// Emit event on state transition (observability - one-way out)
 	if currentState != cl.lastState {
-		cl.emitStateChange(currentState, currentScale, memPressure)
+		cl.emitStateChange(currentState, currentScale, reading)
 		cl.lastState = currentState
 	}
 
+	// Ask for a forced GC under critical heap pressure (other signals don't
+	// respond to GC)
+	cl.requestGC(reading.Signals.Heap)
+
 	// Emit event on significant scale change (>5%)
 	scaleChange := currentScale - prevScale
 	if scaleChange > 0.05 || scaleChange < -0.05 {
-		cl.emitScaleChange(currentScale, scaleChange, memPressure)
+		cl.emitScaleChange(currentScale, scaleChange, reading)
 		cl.lastScale = currentScale
+		if !cl.allowAction() {
+			return
+		}
 
 		// Publish scale command to InternalBus (event-driven control)
 		// This enables observability and allows other components to react
 		cmd := event.GovernorScaleCommand{
 			Scale:     currentScale,
-			Reason:    fmt.Sprintf("Memory pressure %.1f%% (state: %s)", memPressure*100, currentState),
+			Reason:    fmt.Sprintf("Pressure %s (state: %s)", reading, currentState),
 			Source:    "control-lab",
 			Timestamp: time.Now(),
 		}
@@ -164,8 +294,42 @@ func (cl *ControlLab) updateGovernor() {
 	}
 }
 
+// requestGC publishes a ForceGCCommand when pressure is critical and the
+// previous request is older than the interval.
+func (cl *ControlLab) requestGC(pressure float64) {
+	cl.settingsMu.Lock()
+	criticalPct, interval := cl.gcCriticalPct, cl.gcInterval
+	cl.settingsMu.Unlock()
+
+	if criticalPct <= 0 || pressure < criticalPct {
+		return
+	}
+	now := cl.clock.Now()
+	if cl.gcRequested && clock.ToDuration(now-cl.lastGCRequest) < interval {
+		return
+	}
+	if !cl.allowAction() {
+		return
+	}
+	cl.lastGCRequest = now
+	cl.gcRequested = true
+
+	cmd := event.ForceGCCommand{
+		Reason:    fmt.Sprintf("Memory pressure %.1f%% >= critical %.1f%%", pressure*100, criticalPct*100),
+		Timestamp: time.Now(),
+	}
+	if err := cl.internalBus.Publish(context.Background(), event.NewControlEvent(event.EventTypeForceGC, cmd)); err != nil {
+		cl.errorBus.Publish(event.NewErrorEvent(
+			event.WarningSeverity,
+			event.CodeHealthCheck,
+			"control-lab",
+			fmt.Sprintf("Failed to publish force GC command: %v", err),
+		))
+	}
+}
+
 // emitStateChange emits an event when governor state changes.
-func (cl *ControlLab) emitStateChange(state GovernorState, scale, pressure float64) {
+func (cl *ControlLab) emitStateChange(state GovernorState, scale float64, reading PressureReading) {
 	var severity event.ErrorSeverity
 	var signal event.ControlSignal
 	var message string
@@ -195,11 +359,14 @@ func (cl *ControlLab) emitStateChange(state GovernorState, scale, pressure float
 	).WithSignal(signal).
 		WithContext("state", state.String()).
 		WithContext("scale", fmt.Sprintf("%.2f", scale)).
-		WithContext("pressure", fmt.Sprintf("%.1f%%", pressure*100)))
+		WithContext("pressure", fmt.Sprintf("%.1f%%", reading.Pressure*100)).
+		WithContext("driver", string(reading.Driver)).
+		WithContext("signals", reading.Signals.String()).
+		WithContext("explain", cl.controller.Explain()))
 }
 
 // emitScaleChange emits an event when scale changes significantly.
-func (cl *ControlLab) emitScaleChange(scale, change, pressure float64) {
+func (cl *ControlLab) emitScaleChange(scale, change float64, reading PressureReading) {
 	var code string
 	var message string
 
@@ -218,12 +385,19 @@ func (cl *ControlLab) emitScaleChange(scale, change, pressure float64) {
 		message,
 	).WithContext("scale", fmt.Sprintf("%.2f", scale)).
 		WithContext("change", fmt.Sprintf("%+.2f", change)).
-		WithContext("pressure", fmt.Sprintf("%.1f%%", pressure*100)))
+		WithContext("pressure", fmt.Sprintf("%.1f%%", reading.Pressure*100)).
+		WithContext("driver", string(reading.Driver)).
+		WithContext("explain", cl.controller.Explain()))
+}
+
+// Controller returns the control law the lab updates.
+func (cl *ControlLab) Controller() Controller {
+	return cl.controller
 }
 
-// Governor returns the AIMD governor.
+// Governor returns the AIMD governor, or nil if the controller is not one.
 func (cl *ControlLab) Governor() *AIMDGovernor {
-	return cl.governor
+	return governorOf(cl.controller)
 }
 
 // RED returns the RED dropper.
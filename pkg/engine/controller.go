@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+)
+
+// Controller names accepted by Config.Controller.
+const (
+	ControllerAIMD = "aimd" // AIMDGovernor on pressure (default)
+	ControllerPID  = "pid"  // PIDController on subscription lag
+)
+
+// ControlSignals is the input to one Controller update.
+type ControlSignals struct {
+	Pressure PressureReading // Combined pressure and the signal that drove it (see PressureModel)
+	Lag      time.Duration   // Measured subscription lag (see LagMeter; 0 if not measured)
+}
+
+// Controller is the control law that turns pressure signals into the scale
+// factor the engine throttles by (rate limiter, worker pool, retention).
+//
+// The control lab calls Update on every poll (GovernorPollInterval); Scale,
+// State and Explain may be called concurrently from other goroutines.
+//
+// AIMDGovernor (through AsController) is the default implementation and
+// PIDController the alternative selected by Config.Controller. Install any other with
+// WithController. Optional methods the engine uses when present:
+//   - ApplyConfig(Config): live config updates (see Engine.UpdateConfig)
+//   - Start(context.Context, event.Bus) error: GovernorScaleCommand overrides
+//     from the internal bus
+type Controller interface {
+	// Update feeds one set of signals and may change scale and state.
+	Update(signals ControlSignals)
+
+	// Scale returns the current scale factor (0.0-1.0).
+	Scale() float64
+
+	// State returns the current state (NORMAL, DEGRADED or RECOVERING).
+	State() GovernorState
+
+	// Explain describes the most recent decision, e.g.
+	// "NORMAL -> DEGRADED: pressure 72.0% (heap) >= enter 70.0%, scale ×0.50 = 0.50".
+	Explain() string
+}
+
+// NewControllerFromConfig creates the controller named by cfg.Controller.
+func NewControllerFromConfig(clk clock.Clock, cfg Config) (Controller, error) {
+	switch cfg.Controller {
+	case ControllerAIMD, "":
+		return NewAIMDGovernorFromConfig(clk, cfg).AsController(), nil
+	case ControllerPID:
+		return NewPIDControllerFromConfig(clk, cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown controller %q (want %q or %q)", cfg.Controller, ControllerAIMD, ControllerPID)
+	}
+}
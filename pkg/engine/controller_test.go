@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+)
+
+// fixedController holds a constant scale and records its inputs.
+type fixedController struct {
+	mu      sync.Mutex
+	scale   float64
+	updates []ControlSignals
+}
+
+func (c *fixedController) Update(signals ControlSignals) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.updates = append(c.updates, signals)
+}
+
+func (c *fixedController) Scale() float64       { return c.scale }
+func (c *fixedController) State() GovernorState { return StateDegraded }
+func (c *fixedController) Explain() string      { return "fixed" }
+
+func TestAIMDGovernor_Explain(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	clk.Load(0, []time.Duration{time.Second})
+	g := NewDefaultAIMDGovernor(clk, time.Second)
+	c := g.AsController()
+
+	steps := []struct {
+		signals ControlSignals
+		advance bool // Step the clock past the cooldown first
+		want    string
+	}{
+		{ControlSignals{Pressure: PressureReading{Pressure: 0.40, Driver: PressureHeap}}, false,
+			"NORMAL: pressure 40.0% (heap) < enter 70.0%, scale 1.00"},
+		{ControlSignals{Pressure: PressureReading{Pressure: 0.75, Driver: PressureQueue}}, false,
+			"NORMAL -> DEGRADED: pressure 75.0% (queue) >= enter 70.0%, scale ×0.50 = 0.50"},
+		{ControlSignals{Pressure: PressureReading{Pressure: 0.95, Driver: PressureHeap}}, false,
+			"DEGRADED: pressure 95.0% (heap) > critical 90.0%, decrease held by cooldown (1s left)"},
+		{ControlSignals{Pressure: PressureReading{Pressure: 0.40, Driver: PressureHeap}}, false,
+			"DEGRADED -> RECOVERING: pressure 40.0% (heap) < exit 55.0%, scale 0.50"},
+		{ControlSignals{Pressure: PressureReading{Pressure: 0.40, Driver: PressureHeap}}, true,
+			"RECOVERING: pressure 40.0% (heap) < exit 55.0%, scale +0.05 = 0.55"},
+	}
+
+	for i, step := range steps {
+		if step.advance {
+			clk.Advance()
+		}
+		c.Update(step.signals)
+		if got := c.Explain(); got != step.want {
+			t.Errorf("Step %d: expected %q, got %q", i, step.want, got)
+		}
+	}
+
+	// Plain pressure readings have no driver
+	g.Update(0.60)
+	if got := g.Explain(); !strings.HasPrefix(got, "RECOVERING: pressure 60.0% between") {
+		t.Errorf("Expected hold explanation without driver, got %q", got)
+	}
+}
+
+func TestEngine_WithController(t *testing.T) {
+	ctrl := &fixedController{scale: 0.5}
+	cfg := DefaultConfig()
+	cfg.GovernorPollInterval = time.Hour // Only the test drives the control lab
+	eng, err := NewWithConfig(cfg, WithController(ctrl))
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	t.Cleanup(func() { eng.Shutdown(t.Context()) })
+
+	if eng.Controller() != Controller(ctrl) || eng.Governor() != nil {
+		t.Fatalf("Expected injected controller and no AIMD governor, got %T / %v", eng.Controller(), eng.Governor())
+	}
+	if eng.controllerScale() != 0.5 {
+		t.Errorf("Expected engine scale to follow the controller, got %.2f", eng.controllerScale())
+	}
+
+	eng.controlLab.SetPressureSampler(func() PressureSignals { return PressureSignals{Queue: 0.8} })
+	eng.controlLab.updateGovernor()
+	if len(ctrl.updates) != 1 || ctrl.updates[0].Pressure.Driver != PressureQueue {
+		t.Errorf("Expected one queue-driven update, got %+v", ctrl.updates)
+	}
+
+	// Controllers without ApplyConfig still accept live updates
+	next := eng.Config()
+	next.MemoryEnterThreshold = 0.8
+	if _, err := eng.UpdateConfig(next); err != nil {
+		t.Errorf("UpdateConfig failed: %v", err)
+	}
+}
+
+func TestEngine_WithAIMDController(t *testing.T) {
+	cfg := DefaultConfig()
+	gov := NewAIMDGovernorFromConfig(clock.NewSystemClock(), cfg)
+	eng, err := NewWithConfig(cfg, WithController(gov.AsController()))
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	t.Cleanup(func() { eng.Shutdown(t.Context()) })
+
+	// The adapter keeps the governor reachable, with its optional methods
+	if eng.Governor() != gov {
+		t.Fatalf("Expected Governor() to return the wrapped governor, got %v", eng.Governor())
+	}
+	if _, ok := eng.Controller().(interface{ ApplyConfig(Config) }); !ok {
+		t.Error("Expected ApplyConfig promoted through the adapter")
+	}
+}
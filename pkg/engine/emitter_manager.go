@@ -3,11 +3,13 @@ package engine
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/BYTE-6D65/pipeline/pkg/emitter"
 	"github.com/BYTE-6D65/pipeline/pkg/event"
+	"github.com/BYTE-6D65/pipeline/pkg/trace"
 )
 
 // EmitterManager manages the lifecycle of emitters attached to the engine.
@@ -22,12 +24,15 @@ type EmitterManager struct {
 	ctx           context.Context
 	cancel        context.CancelFunc
 	wg            sync.WaitGroup
+
+	shutdownOnce sync.Once
+	shutdownErr  error
 }
 
 // NewEmitterManager creates a new emitter manager for the given engine.
 func NewEmitterManager(engine *Engine) *EmitterManager {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &EmitterManager{
+	m := &EmitterManager{
 		engine:        engine,
 		emitters:      make(map[string]emitter.Emitter),
 		filters:       make(map[string]event.Filter),
@@ -35,6 +40,8 @@ func NewEmitterManager(engine *Engine) *EmitterManager {
 		ctx:           ctx,
 		cancel:        cancel,
 	}
+	engine.attachEmitterManager(m)
+	return m
 }
 
 // Register registers an emitter with the manager and its event filter.
@@ -81,6 +88,9 @@ func (m *EmitterManager) Unregister(emitterID string) (err error) {
 		sub.Close()
 		delete(m.subscriptions, emitterID)
 	}
+	if pool := m.engine.workerPool; pool != nil {
+		pool.Wait(context.Background()) // Pooled emits must finish before Close
+	}
 
 	// Close the emitter
 	if closeErr := emitter.Close(); closeErr != nil {
@@ -113,7 +123,7 @@ func (m *EmitterManager) Start() (err error) {
 			// No filter specified, match all events
 			filter = event.Filter{}
 		}
-		sub, err := m.engine.ExternalBus().Subscribe(m.ctx, filter)
+		sub, err := event.SubscribeNamed(m.ctx, m.engine.ExternalBus(), "emitter:"+id, filter)
 		if err != nil {
 			startErrors = append(startErrors, fmt.Errorf("emitter %s: failed to subscribe: %w", id, err))
 			continue
@@ -138,9 +148,10 @@ func (m *EmitterManager) Start() (err error) {
 
 // processEvents is the event processing loop for an emitter.
 // It runs in a goroutine and processes events from the subscription.
-func (m *EmitterManager) processEvents(id string, emitter emitter.Emitter, sub event.Subscription) {
+func (m *EmitterManager) processEvents(id string, emit emitter.Emitter, sub event.Subscription) {
 	defer m.wg.Done()
 
+	partitioner, _ := emit.(emitter.Partitioner)
 	for {
 		select {
 		case <-m.ctx.Done():
@@ -153,17 +164,78 @@ func (m *EmitterManager) processEvents(id string, emitter emitter.Emitter, sub e
 				return
 			}
 
-			// Emit the event via the emitter
-			if err := emitter.Emit(m.ctx, evt); err != nil {
-				// Log error but continue processing
-				// In production, this would use a proper logger
-				// For now, we silently continue
-				continue
+			// Hand off to the engine worker pool when enabled, without
+			// waiting: the backlog queues in the pool, whose lag drives its
+			// scaling. Events stay in order per emitter (per partition for an
+			// emitter.Partitioner), and Emit is never called concurrently
+			// within one.
+			if pool := m.engine.workerPool; pool != nil {
+				key := id
+				if partitioner != nil {
+					key += "\x00" + partitioner.PartitionKey(evt)
+				}
+				if err := pool.SubmitKeyed(m.ctx, key, func() { m.handle(id, emit, evt) }); err == nil {
+					continue
+				}
 			}
+
+			m.handle(id, emit, evt)
 		}
 	}
 }
 
+// handle emits evt unless it went stale while queued.
+func (m *EmitterManager) handle(id string, emit emitter.Emitter, evt *event.Event) {
+	if now := m.engine.now(); evt.Expired(now) {
+		m.recordExpired(id, evt, now)
+		return
+	}
+
+	// Emit the event via the emitter; the context carries the event so
+	// follow-up publishes are linked to it. Errors are not reported yet:
+	// processing continues
+	m.emit(id, emit, evt)
+}
+
+// emit calls the emitter, recording queue wait and emit spans when tracing.
+func (m *EmitterManager) emit(id string, emit emitter.Emitter, evt *event.Event) error {
+	ctx := event.ContextWithEvent(m.ctx, evt)
+
+	tracer := m.engine.tracer
+	if tracer == nil {
+		return emit.Emit(ctx, evt)
+	}
+
+	parent, _ := trace.Extract(evt.Metadata)
+
+	// Queue wait: from fan-out on the bus until this emitter dequeued the event
+	if ns, err := strconv.ParseInt(evt.Metadata[trace.EnqueuedAtKey], 10, 64); err == nil {
+		tracer.StartAt(parent, trace.StageQueueWait, trace.SpanKindInternal, time.Unix(0, ns)).
+			SetAttribute("emitter.id", id).
+			End()
+	}
+
+	span := tracer.Start(parent, trace.StageEmit+" "+evt.Type, trace.SpanKindConsumer).
+		SetAttribute("emitter.id", id).
+		SetAttribute("emitter.type", emit.Type()).
+		SetAttribute("event.id", evt.ID)
+	defer span.End()
+
+	err := emit.Emit(ctx, evt)
+	span.SetError(err)
+	return err
+}
+
+// recordExpired counts and reports an event skipped before Emit.
+func (m *EmitterManager) recordExpired(id string, evt *event.Event, now time.Time) {
+	if m.engine.metrics != nil {
+		m.engine.metrics.EventsExpired.WithLabelValues("emitter:"+id, evt.Type, "emit").Inc()
+	}
+	if m.engine.errorBus != nil {
+		m.engine.errorBus.Publish(event.NewExpiredEvent("emitter:"+id, "emit", evt, now))
+	}
+}
+
 // Stop stops all running emitters.
 func (m *EmitterManager) Stop() (err error) {
 	start := time.Now()
@@ -191,6 +263,9 @@ func (m *EmitterManager) stopAll() error {
 	// We need to unlock before waiting, otherwise we'll deadlock
 	m.mu.Unlock()
 	m.wg.Wait()
+	if pool := m.engine.workerPool; pool != nil {
+		pool.Wait(context.Background()) // Pooled emits must finish before Close
+	}
 	m.mu.Lock()
 
 	// Close all emitters
@@ -208,9 +283,64 @@ func (m *EmitterManager) stopAll() error {
 	return nil
 }
 
+// Flush waits for the processing goroutines to consume everything their
+// subscriptions delivered (they exit once the bus has drained and closed),
+// then flushes emitters that implement emitter.Flusher. Waiting stops when
+// ctx is done; flushing is still attempted.
+func (m *EmitterManager) Flush(ctx context.Context) (err error) {
+	start := time.Now()
+	defer func() {
+		recordEngineOperation(m.engine.metrics, "emitter.flush", start, err)
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	// Pooled emits still queued or running
+	if pool := m.engine.workerPool; pool != nil {
+		pool.Wait(ctx)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var flushErrors []error
+	for id, emit := range m.emitters {
+		if f, ok := emit.(emitter.Flusher); ok {
+			if err := f.Flush(ctx); err != nil {
+				flushErrors = append(flushErrors, fmt.Errorf("emitter %s: %w", id, err))
+			}
+		}
+	}
+
+	if len(flushErrors) > 0 {
+		err = fmt.Errorf("errors flushing emitters: %v", flushErrors)
+		return
+	}
+
+	return
+}
+
 // Shutdown gracefully shuts down the emitter manager.
 // It stops all emitters and cancels the context.
-func (m *EmitterManager) Shutdown() (err error) {
+// Calling Shutdown more than once returns the first result.
+func (m *EmitterManager) Shutdown() error {
+	m.shutdownOnce.Do(func() {
+		m.shutdownErr = m.shutdown()
+	})
+	return m.shutdownErr
+}
+
+// shutdown cancels processing, then stops and closes all emitters.
+func (m *EmitterManager) shutdown() (err error) {
 	start := time.Now()
 	defer func() {
 		recordEngineOperation(m.engine.metrics, "emitter.shutdown", start, err)
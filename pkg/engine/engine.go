@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"runtime/debug"
 	"sync"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/BYTE-6D65/pipeline/pkg/event"
 	"github.com/BYTE-6D65/pipeline/pkg/registry"
 	"github.com/BYTE-6D65/pipeline/pkg/telemetry"
+	"github.com/BYTE-6D65/pipeline/pkg/trace"
 )
 
 // Engine wires together core infrastructure components.
@@ -27,19 +29,69 @@ type Engine struct {
 	// Error signaling and fault tolerance (Phase 1)
 	errorBus         *event.ErrorBus
 	config           Config
+	requested        Config       // Last config passed to UpdateConfig (diff base)
+	configMu         sync.RWMutex // Guards config and requested (replaced by UpdateConfig)
 	flightRecorder   *FlightRecorder
 	memoryLimit      uint64
 	memoryLimitSrc   string
 	psiMonitor       *PSIMonitor
 	monitorCtx       context.Context
 	monitorCancel    context.CancelFunc
+	monitorWG        sync.WaitGroup // Monitor goroutines (waited on by Shutdown)
 	lastCrashDump    time.Time
 	crashDumpMu      sync.Mutex
 
 	// Graceful degradation (Phase 2)
-	redDropper  *REDDropper
-	aimdGovernor *AIMDGovernor
-	controlLab  *ControlLab
+	redDropper *REDDropper
+	controller Controller // AIMDGovernor unless WithController
+	controlLab *ControlLab
+
+	// Delayed publishing
+	scheduler *Scheduler
+
+	// Event expiry (type-level default TTLs; wall time from the engine clock)
+	ttlPolicy *event.TTLPolicy
+	expiryNow func() time.Time
+
+	// Per-adapter ingestion throttling (scaled by governor)
+	rateLimiter *RateLimiter
+
+	// Event tracing (publish → filter → deliver → queue wait → emit)
+	tracer *trace.Tracer
+
+	// Default bus implementation: 0 = InMemoryBus, > 0 = ShardedBus with N shards
+	busShards int
+
+	// Late-subscriber history on default buses (shrunk while degraded)
+	retentionOpts []event.RetentionOption
+	retentions    []*event.Retention
+
+	// Duplicate suppression on the default external bus (nil opts = off)
+	dedupOpts []event.DedupOption
+	dedup     *event.Deduplicator
+
+	// Error sinks attached to the error bus (closed after it by Shutdown)
+	sinkSpecs  []errorSinkSpec
+	errorSinks []*event.AttachedSink
+
+	// Error bus ↔ event bus bridges (closed before buses drain)
+	bridgeSpecs  []errorBridgeSpec
+	errorBridges []*event.ErrorBridge
+
+	// Forced GC and GC percent tuning under memory pressure
+	gcAssist *GCAssist
+
+	// Emitter worker pool (Config.WorkerPool)
+	workerPool *WorkerPool
+
+	// Declarative alert rules over the error bus
+	alertRules []AlertRule
+	alerts     *AlertManager
+
+	// Managers stopped in order by Shutdown
+	managersMu      sync.Mutex
+	adapterManagers []*AdapterManager
+	emitterManagers []*EmitterManager
 }
 
 // EngineOption configures an Engine instance.
@@ -80,24 +132,172 @@ func WithMetrics(metrics *telemetry.Metrics) EngineOption {
 	}
 }
 
+// WithDefaultTTL sets the default TTL for events whose type matches pattern.
+// Applied by the engine's buses to events that carry no TTL or deadline.
+func WithDefaultTTL(pattern string, ttl time.Duration) EngineOption {
+	return func(e *Engine) {
+		if e.ttlPolicy == nil {
+			e.ttlPolicy = event.NewTTLPolicy()
+		}
+		e.ttlPolicy.Set(pattern, ttl)
+	}
+}
+
+// WithTTLPolicy sets the type-level TTL policy used by the engine's buses.
+func WithTTLPolicy(policy *event.TTLPolicy) EngineOption {
+	return func(e *Engine) {
+		e.ttlPolicy = policy
+	}
+}
+
+// WithRateLimiter sets the rate limiter applied to adapter publishes.
+func WithRateLimiter(rl *RateLimiter) EngineOption {
+	return func(e *Engine) {
+		e.rateLimiter = rl
+	}
+}
+
+// WithController replaces the AIMD governor with another control law (see
+// Controller). Only engines created with NewWithConfig run a controller;
+// Governor returns nil unless c comes from AIMDGovernor.AsController.
+func WithController(c Controller) EngineOption {
+	return func(e *Engine) {
+		e.controller = c
+	}
+}
+
+// WithTracer enables span recording on the engine's buses and emitters.
+func WithTracer(tracer *trace.Tracer) EngineOption {
+	return func(e *Engine) {
+		e.tracer = tracer
+	}
+}
+
+// WithShardedBuses makes the engine create event.ShardedBus instances for
+// buses not supplied via WithInternalBus/WithExternalBus. shards <= 0 uses
+// GOMAXPROCS shards.
+func WithShardedBuses(shards int) EngineOption {
+	return func(e *Engine) {
+		if shards <= 0 {
+			shards = runtime.GOMAXPROCS(0)
+		}
+		e.busShards = shards
+	}
+}
+
+// WithRetention keeps a bounded history on the buses the engine creates, so
+// late subscribers can replay it (see event.WithRetainedLatest). Each bus gets
+// its own store built from opts. Under NewWithConfig the stores share the
+// buffer memory budget and shrink with the governor scale while degraded.
+func WithRetention(opts ...event.RetentionOption) EngineOption {
+	return func(e *Engine) {
+		if opts == nil {
+			opts = []event.RetentionOption{}
+		}
+		e.retentionOpts = opts
+	}
+}
+
+// WithDedup suppresses events already published to the external bus the
+// engine creates (adapter input) within the deduplicator's window. The
+// window runs on the engine clock unless opts set another, and under
+// NewWithConfig duplicates are reported on the error bus.
+func WithDedup(opts ...event.DedupOption) EngineOption {
+	return func(e *Engine) {
+		if opts == nil {
+			opts = []event.DedupOption{}
+		}
+		e.dedupOpts = opts
+	}
+}
+
+// errorSinkSpec is a sink registered by WithErrorSink, attached by NewWithConfig.
+type errorSinkSpec struct {
+	sink event.ErrorSink
+	opts []event.SinkOption
+}
+
+// WithErrorSink attaches sink to the engine's error bus at construction, so
+// it also receives startup events. Shutdown closes the sink after the error
+// bus. Ignored by New(), which has no error bus.
+//
+// Example:
+//
+//	eng, err := engine.NewWithConfig(cfg,
+//	    engine.WithErrorSink(event.NewStderrSink()),
+//	    engine.WithErrorSink(jsonl, event.WithSinkFilter(event.ErrorFilter{MinSeverity: event.WarningSeverity})))
+func WithErrorSink(sink event.ErrorSink, opts ...event.SinkOption) EngineOption {
+	return func(e *Engine) {
+		e.sinkSpecs = append(e.sinkSpecs, errorSinkSpec{sink: sink, opts: opts})
+	}
+}
+
+// errorBridgeSpec is a bridge requested with WithErrorBridge.
+type errorBridgeSpec struct {
+	bus  string
+	opts []event.BridgeOption
+}
+
+// WithErrorBridge republishes error bus events on the "internal" or
+// "external" bus as "system.error.<code>" events (see event.ErrorBridge).
+// Ignored by New(), which has no error bus.
+//
+// Example:
+//
+//	// Record warnings and above alongside domain events
+//	eng, err := engine.NewWithConfig(cfg, engine.WithErrorBridge("external",
+//	    event.WithBridgeFilter(event.ErrorFilter{MinSeverity: event.WarningSeverity})))
+func WithErrorBridge(bus string, opts ...event.BridgeOption) EngineOption {
+	return func(e *Engine) {
+		e.bridgeSpecs = append(e.bridgeSpecs, errorBridgeSpec{bus: bus, opts: opts})
+	}
+}
+
+// WithAlertRules adds alert rules evaluated against the engine's error bus.
+// Rules from Config.AlertRulesFile are added after these. Ignored by New(),
+// which has no error bus.
+//
+// Example:
+//
+//	eng, err := engine.NewWithConfig(cfg, engine.WithAlertRules(engine.AlertRule{
+//	    Name:    "emitter-breaker",
+//	    Kind:    engine.AlertThreshold,
+//	    Match:   engine.AlertMatch{Codes: []string{event.CodeEmitterFail}},
+//	    Count:   3,
+//	    Window:  time.Minute,
+//	    GroupBy: "component",
+//	    Action:  engine.AlertAction{Severity: "ERROR", Code: event.CodeBreakerOpen, Signal: "BREAKER_OPEN"},
+//	}))
+func WithAlertRules(rules ...AlertRule) EngineOption {
+	return func(e *Engine) {
+		e.alertRules = append(e.alertRules, rules...)
+	}
+}
+
 // NewWithConfig creates a new Engine with the given configuration.
 // This constructor enables error signaling, memory monitoring, and fault tolerance.
+// The governor, RED dropper, control lab and monitors are all built from cfg
+// (see NewAIMDGovernorFromConfig and NewREDDropperFromConfig); WithController
+// replaces the governor with another control law.
 //
 // Monitors started:
 //   - Flight recorder (continuous snapshots for crash forensics)
 //   - Memory monitor (emits warnings at thresholds)
-//   - PSI monitor (pre-OOM detection on Linux)
+//   - PSI monitor (pre-OOM detection on Linux, if PSIEnabled)
 //
 // Use Shutdown() to clean up monitors.
-func NewWithConfig(cfg Config, opts ...EngineOption) (*Engine, error) {
+func NewWithConfig(cfg Config, opts ...EngineOption) (_ *Engine, err error) {
 	// Validate config
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	// Detect memory limit
+	// Detect memory limit (Config.MemoryLimitBytes overrides detection)
 	memLimit, memSrc, memOk := DetectMemoryLimit()
-	if !memOk {
+	if cfg.MemoryLimitBytes > 0 {
+		memLimit = cfg.MemoryLimitBytes
+		memSrc = "config"
+	} else if !memOk {
 		memLimit = 0 // Unlimited
 		memSrc = "none"
 	}
@@ -105,48 +305,30 @@ func NewWithConfig(cfg Config, opts ...EngineOption) (*Engine, error) {
 	// Create monitor context
 	monitorCtx, monitorCancel := context.WithCancel(context.Background())
 
-	// Create engine with clock (used by all components for consistency)
-	engineClock := clock.NewSystemClock()
 	errorBus := event.NewErrorBus(cfg.ErrorBusBufferSize)
-
-	// Create Phase 2 components (graceful degradation)
-	redDropper := NewDefaultREDDropper()
-	aimdGovernor := NewDefaultAIMDGovernor(engineClock, cfg.ControlCooldown)
-
-	// Create internal bus early (needed by control lab)
-	internalBus := event.NewInMemoryBus(
-		event.WithBufferSize(32),
-		event.WithDropSlow(false),
-		event.WithBusName("internal"),
-		event.WithMetrics(telemetry.Default()),
-	)
+	redDropper := NewREDDropperFromConfig(cfg)
 
 	engine := &Engine{
-		clock:          engineClock,
+		clock:          clock.NewSystemClock(),
 		registry:       registry.NewInMemoryRegistry(),
 		metrics:        telemetry.Default(),
 		errorBus:       errorBus,
 		config:         cfg,
+		requested:      cfg,
 		flightRecorder: NewFlightRecorder(cfg.FlightRecorderSize),
 		memoryLimit:    memLimit,
 		memoryLimitSrc: memSrc,
 		monitorCtx:     monitorCtx,
 		monitorCancel:  monitorCancel,
 		redDropper:     redDropper,
-		aimdGovernor:   aimdGovernor,
-		internalBus:    internalBus,
 	}
 
-	// Create control lab (analyzes state, publishes to internal bus)
-	engine.controlLab = NewControlLab(
-		engineClock,
-		errorBus,
-		internalBus,
-		aimdGovernor,
-		redDropper,
-		memLimit, // Memory limit for direct polling
-		cfg.GovernorPollInterval,
-	)
+	// Release whatever was set up if construction fails part way
+	defer func() {
+		if err != nil {
+			engine.abortStart()
+		}
+	}()
 
 	// Apply options
 	for _, opt := range opts {
@@ -158,39 +340,161 @@ func NewWithConfig(cfg Config, opts ...EngineOption) (*Engine, error) {
 		engine.metrics = telemetry.Default()
 	}
 
-	// Internal bus was created earlier for control lab
-	// Apply options can override it if needed
-	if engine.internalBus == nil {
-		engine.internalBus = event.NewInMemoryBus(
-			event.WithBufferSize(32),
-			event.WithDropSlow(false),
-			event.WithBusName("internal"),
-			event.WithMetrics(engine.metrics),
-		)
+	// Everything below uses the engine clock set by options
+
+	// Create Phase 2 components (graceful degradation)
+	if engine.controller == nil {
+		controller, err := NewControllerFromConfig(engine.clock, cfg)
+		if err != nil {
+			return nil, err
+		}
+		engine.controller = controller
 	}
 
-	if engine.externalBus == nil {
-		engine.externalBus = event.NewInMemoryBus(
-			event.WithBufferSize(32),
-			event.WithDropSlow(false),
-			event.WithBusName("external"),
-			event.WithMetrics(engine.metrics),
-		)
+	// Adapter rate limiter follows the controller scale
+	if engine.rateLimiter == nil {
+		throttleMode, _ := ParseThrottleMode(cfg.AdapterThrottleMode) // Validated above
+		engine.rateLimiter = NewRateLimiter(engine.clock, RateLimit{
+			Rate:  cfg.AdapterRateLimit,
+			Burst: cfg.AdapterRateBurst,
+			Mode:  throttleMode,
+		}, engine.controllerScale, engine.metrics)
+	}
+
+	// Collapse repetitive errors
+	if cfg.ErrorBusSampling {
+		errorBus.SetSampler(event.NewErrorSampler(
+			event.WithSampleWindow(cfg.ErrorSampleWindow),
+			event.WithSampleRate(cfg.ErrorSampleRate, cfg.ErrorSampleBurst),
+			event.WithSampleClock(engine.clock),
+		))
+	}
+
+	// Keep the recent error timeline for queries and crash reports
+	if cfg.ErrorHistorySize > 0 {
+		errorBus.SetHistory(event.NewErrorHistory(cfg.ErrorHistorySize))
+	}
+
+	for _, spec := range engine.sinkSpecs {
+		attached, err := event.AttachSink(errorBus, spec.sink, spec.opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to attach error sink: %w", err)
+		}
+		engine.errorSinks = append(engine.errorSinks, attached)
+	}
+
+	engine.createBuses()
+	engine.applyRetentionBudget()
+	internalBus := engine.internalBus
+
+	for _, spec := range engine.bridgeSpecs {
+		var target event.Bus
+		switch spec.bus {
+		case "internal":
+			target = internalBus
+		case "external":
+			target = engine.externalBus
+		default:
+			return nil, fmt.Errorf("error bridge: unknown bus %q (want internal or external)", spec.bus)
+		}
+		bridge, err := event.NewErrorBridge(errorBus, target, spec.opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create error bridge: %w", err)
+		}
+		engine.errorBridges = append(engine.errorBridges, bridge)
+	}
+
+	// Alert rules (Go-defined first, then the rules file)
+	if cfg.AlertRulesFile != "" {
+		fileRules, err := LoadAlertRules(cfg.AlertRulesFile)
+		if err != nil {
+			return nil, err
+		}
+		engine.alertRules = append(engine.alertRules, fileRules...)
+	}
+	if len(engine.alertRules) > 0 {
+		alerts, err := NewAlertManager(engine.clock, errorBus, internalBus, engine.alertRules)
+		if err != nil {
+			return nil, err
+		}
+		engine.alerts = alerts
 	}
 
+	// Create control lab (analyzes state, publishes to internal bus)
+	engine.controlLab = NewControlLab(
+		engine.clock,
+		errorBus,
+		internalBus,
+		engine.controller,
+		redDropper,
+		memLimit, // Memory limit for direct polling
+		cfg.GovernorPollInterval,
+	)
+	engine.controlLab.SetActionBudget(cfg.MaxActionsPerLoop, cfg.ControlLoopInterval)
+	engine.controlLab.SetPressureModel(NewPressureModelFromConfig(cfg))
+	engine.controlLab.SetPressureSampler(NewPressureSampler(memLimit, engine.externalBus).Sample)
+	engine.controlLab.SetLagSampler(engine.measureLag(NewLagMeter(engine.clock, engine.externalBus, internalBus)))
+
+	// Scheduler publishes delayed events to the external bus
+	engine.scheduler = NewScheduler(engine.clock, engine.externalBus, errorBus, engine.metrics)
+
 	// Start governor subscription to internal bus (Phase 2)
-	if aimdGovernor != nil {
-		if err := aimdGovernor.Start(monitorCtx, internalBus); err != nil {
+	if l, ok := engine.controller.(interface {
+		Start(context.Context, event.Bus) error
+	}); ok {
+		if err := l.Start(monitorCtx, internalBus); err != nil {
 			return nil, fmt.Errorf("failed to start governor subscription: %w", err)
 		}
 	}
 
+	// Forced GC on critical pressure (requested by the control lab)
+	engine.gcAssist = NewGCAssist(engine.clock, cfg.GCCooldown, cfg.GCPercentDegraded, errorBus, engine.flightRecorder, memLimit)
+	engine.controlLab.SetForceGC(cfg.MemoryCriticalPct, cfg.GCCooldown)
+	if err := engine.gcAssist.Listen(monitorCtx, internalBus); err != nil {
+		return nil, err
+	}
+
+	// Worker pool between the external bus and emitters, capped by the governor
+	if cfg.WorkerPool {
+		engine.workerPool = NewWorkerPool(engine.clock, WorkerPoolConfig{
+			MinWorkers: cfg.MinWorkers,
+			MaxWorkers: cfg.MaxWorkers,
+			TargetLag:  time.Duration(cfg.TargetLagMs) * time.Millisecond,
+			QueueSize:  cfg.QueueSizeMax,
+		}, engine.controllerScale, errorBus)
+		if err := engine.workerPool.Listen(monitorCtx, internalBus); err != nil {
+			return nil, err
+		}
+	}
+
 	// Start monitors
 	engine.startMonitors()
 
 	return engine, nil
 }
 
+// abortStart releases what NewWithConfig set up before it failed: monitor
+// goroutines and subscriptions, bridges, the worker pool, buses, the error
+// bus and its sinks.
+func (e *Engine) abortStart() {
+	e.monitorCancel()
+	for _, bridge := range e.errorBridges {
+		bridge.Close()
+	}
+	if e.workerPool != nil {
+		e.workerPool.Close()
+	}
+	for _, bus := range []event.Bus{e.externalBus, e.internalBus} {
+		if bus != nil {
+			bus.Close()
+		}
+	}
+	e.errorBus.Close()
+	for _, sink := range e.errorSinks {
+		sink.Close()
+	}
+}
+
 // New creates a new Engine with sensible defaults.
 // Default configuration:
 // - InternalBus: InMemoryBus with 64 buffer, drop-slow disabled
@@ -212,25 +516,139 @@ func New(opts ...EngineOption) *Engine {
 		engine.metrics = telemetry.Default()
 	}
 
-	if engine.internalBus == nil {
-		engine.internalBus = event.NewInMemoryBus(
-			event.WithBufferSize(32),
-			event.WithDropSlow(false),
-			event.WithBusName("internal"),
-			event.WithMetrics(engine.metrics),
-		)
+	engine.createBuses()
+
+	return engine
+}
+
+// createBuses creates the internal and external buses not supplied by options,
+// then configures expiry and tracing on all of them.
+func (e *Engine) createBuses() {
+	e.expiryNow = wallClock(e.clock)
+	if e.internalBus == nil {
+		e.internalBus = e.newBus("internal")
 	}
+	if e.externalBus == nil {
+		e.externalBus = e.newBus("external")
+	}
+	e.configureBuses()
+}
 
-	if engine.externalBus == nil {
-		engine.externalBus = event.NewInMemoryBus(
-			event.WithBufferSize(32),
-			event.WithDropSlow(false),
-			event.WithBusName("external"),
-			event.WithMetrics(engine.metrics),
-		)
+// newBus creates a default bus of the configured implementation.
+func (e *Engine) newBus(name string) event.Bus {
+	bufferSize := 32
+	if e.config.QueueSizeStart > 0 {
+		bufferSize = e.config.QueueSizeStart // NewWithConfig
+	}
+	opts := []event.BusOption{
+		event.WithBufferSize(bufferSize),
+		event.WithDropSlow(false),
+		event.WithBusName(name),
+		event.WithMetrics(e.metrics),
 	}
+	if e.retentionOpts != nil {
+		r := event.NewRetention(e.retentionOpts...)
+		e.retentions = append(e.retentions, r)
+		opts = append(opts, event.WithRetention(r))
+	}
+	if name == "external" && e.dedupOpts != nil {
+		dedupOpts := []event.DedupOption{event.WithDedupClock(e.clock)}
+		if e.errorBus != nil {
+			dedupOpts = append(dedupOpts, event.WithDedupErrorBus(e.errorBus))
+		}
+		e.dedup = event.NewDeduplicator(append(dedupOpts, e.dedupOpts...)...)
+		opts = append(opts, event.WithDeduplicator(e.dedup))
+	}
+	if e.busShards > 0 {
+		return event.NewShardedBus(e.busShards, opts...)
+	}
+	return event.NewInMemoryBus(opts...)
+}
 
-	return engine
+// configurableBus is implemented by buses that accept expiry and tracing settings.
+type configurableBus interface {
+	SetTTLPolicy(policy *event.TTLPolicy)
+	SetErrorBus(errorBus *event.ErrorBus)
+	SetTracer(tracer *trace.Tracer)
+}
+
+// configureBuses hands the TTL policy, expiry clock, error bus and tracer to
+// the buses.
+func (e *Engine) configureBuses() {
+	for _, bus := range []event.Bus{e.internalBus, e.externalBus} {
+		if b, ok := bus.(interface{ SetExpiryClock(func() time.Time) }); ok {
+			b.SetExpiryClock(e.expiryNow)
+		}
+		mb, ok := bus.(configurableBus)
+		if !ok {
+			continue
+		}
+		if e.ttlPolicy != nil {
+			mb.SetTTLPolicy(e.ttlPolicy)
+		}
+		if e.errorBus != nil {
+			mb.SetErrorBus(e.errorBus)
+		}
+		if e.tracer != nil {
+			mb.SetTracer(e.tracer)
+		}
+	}
+}
+
+// wallClock maps clk onto wall time starting now, so event deadlines follow
+// the engine clock (and DeltaClock in tests).
+func wallClock(clk clock.Clock) func() time.Time {
+	start, mono := time.Now(), clk.Now()
+	return func() time.Time {
+		return start.Add(clk.Since(mono))
+	}
+}
+
+// now returns the wall time used for event expiry.
+func (e *Engine) now() time.Time {
+	if e.expiryNow == nil {
+		return time.Now()
+	}
+	return e.expiryNow()
+}
+
+// applyRetentionBudget caps retention stores so that together they stay within
+// the buffer memory budget (memory limit × BufferMemoryBudgetPct).
+func (e *Engine) applyRetentionBudget() {
+	if len(e.retentions) == 0 || e.memoryLimit == 0 {
+		return
+	}
+
+	share := int64(float64(e.memoryLimit)*e.config.BufferMemoryBudgetPct) / int64(len(e.retentions))
+	for _, r := range e.retentions {
+		if limit := r.MaxBytes(); limit == 0 || limit > share {
+			r.SetMaxBytes(share)
+		}
+	}
+}
+
+// RetainedBytes returns the approximate memory held by bus retention stores.
+func (e *Engine) RetainedBytes() int64 {
+	var total int64
+	for _, r := range e.retentions {
+		total += r.Bytes()
+	}
+	return total
+}
+
+// Tracer returns the event tracer, or nil if tracing is disabled.
+func (e *Engine) Tracer() *trace.Tracer {
+	return e.tracer
+}
+
+// Deduplicator returns the external bus deduplicator, or nil without WithDedup.
+func (e *Engine) Deduplicator() *event.Deduplicator {
+	return e.dedup
+}
+
+// TTLPolicy returns the type-level TTL policy, or nil if none was configured.
+func (e *Engine) TTLPolicy() *event.TTLPolicy {
+	return e.ttlPolicy
 }
 
 // InternalBus returns the internal event bus (for system/coordination events).
@@ -258,6 +676,43 @@ func (e *Engine) Metrics() *telemetry.Metrics {
 	return e.metrics
 }
 
+// ErrorSinks returns the sinks attached with WithErrorSink.
+func (e *Engine) ErrorSinks() []*event.AttachedSink {
+	return e.errorSinks
+}
+
+// ErrorHistory returns the recent error timeline (see event.ErrorHistory.Query).
+// Returns nil if Config.ErrorHistorySize is 0 or the engine was created with New().
+func (e *Engine) ErrorHistory() *event.ErrorHistory {
+	if e.errorBus == nil {
+		return nil
+	}
+	return e.errorBus.History()
+}
+
+// ErrorBridges returns the bridges created with WithErrorBridge.
+func (e *Engine) ErrorBridges() []*event.ErrorBridge {
+	return e.errorBridges
+}
+
+// GCAssist returns the forced GC / GC tuning component.
+// Returns nil if engine was created with New() instead of NewWithConfig().
+func (e *Engine) GCAssist() *GCAssist {
+	return e.gcAssist
+}
+
+// WorkerPool returns the emitter worker pool.
+// Returns nil unless Config.WorkerPool is set.
+func (e *Engine) WorkerPool() *WorkerPool {
+	return e.workerPool
+}
+
+// Alerts returns the alert manager for rule state inspection.
+// Returns nil if no alert rules are configured.
+func (e *Engine) Alerts() *AlertManager {
+	return e.alerts
+}
+
 // ErrorBus returns the error bus for observability.
 // Returns nil if engine was created with New() instead of NewWithConfig().
 func (e *Engine) ErrorBus() *event.ErrorBus {
@@ -267,13 +722,42 @@ func (e *Engine) ErrorBus() *event.ErrorBus {
 // Config returns the engine configuration.
 // Returns zero value if engine was created with New() instead of NewWithConfig().
 func (e *Engine) Config() Config {
+	e.configMu.RLock()
+	defer e.configMu.RUnlock()
 	return e.config
 }
 
-// Governor returns the AIMD governor.
+// Controller returns the control law that sets the engine scale.
 // Returns nil if engine was created with New() instead of NewWithConfig().
+func (e *Engine) Controller() Controller {
+	return e.controller
+}
+
+// Governor returns the AIMD governor.
+// Returns nil if engine was created with New() instead of NewWithConfig(),
+// or if WithController installed a different control law.
 func (e *Engine) Governor() *AIMDGovernor {
-	return e.aimdGovernor
+	return governorOf(e.controller)
+}
+
+// measureLag returns a lag sampler for the control lab: the larger of the
+// bus subscription lag and the worker pool queue lag.
+func (e *Engine) measureLag(meter *LagMeter) func() time.Duration {
+	return func() time.Duration {
+		lag := meter.Measure()
+		if e.workerPool != nil {
+			lag = max(lag, e.workerPool.Stats().Lag)
+		}
+		return lag
+	}
+}
+
+// controllerScale returns the controller scale, or 1.0 without a controller.
+func (e *Engine) controllerScale() float64 {
+	if e.controller == nil {
+		return 1.0
+	}
+	return e.controller.Scale()
 }
 
 // RED returns the RED dropper.
@@ -282,14 +766,57 @@ func (e *Engine) RED() *REDDropper {
 	return e.redDropper
 }
 
+// RateLimiter returns the adapter rate limiter.
+// Returns nil if engine was created with New() without WithRateLimiter.
+func (e *Engine) RateLimiter() *RateLimiter {
+	return e.rateLimiter
+}
+
+// PSIMonitor returns the PSI monitor.
+// Returns nil if Config.PSIEnabled is false or the engine was created with New().
+func (e *Engine) PSIMonitor() *PSIMonitor {
+	return e.psiMonitor
+}
+
 // ControlLab returns the control lab.
 // Returns nil if engine was created with New() instead of NewWithConfig().
 func (e *Engine) ControlLab() *ControlLab {
 	return e.controlLab
 }
 
+// BusStats returns introspection snapshots for the internal and external buses.
+// Buses that do not implement event.StatsProvider are skipped.
+func (e *Engine) BusStats() []event.BusStats {
+	var stats []event.BusStats
+	for _, bus := range []event.Bus{e.internalBus, e.externalBus} {
+		if sp, ok := bus.(event.StatsProvider); ok {
+			stats = append(stats, sp.Stats())
+		}
+	}
+	return stats
+}
+
+// queueDepths aggregates subscription buffer depths across both buses.
+func (e *Engine) queueDepths() map[string]int {
+	depths := make(map[string]int)
+	for _, bs := range e.BusStats() {
+		for k, v := range bs.QueueDepths() {
+			depths[k] = v
+		}
+	}
+	return depths
+}
+
+// Scheduler returns the delayed/scheduled event publisher.
+// Returns nil if engine was created with New() instead of NewWithConfig().
+func (e *Engine) Scheduler() *Scheduler {
+	return e.scheduler
+}
+
 // startMonitors starts background monitoring goroutines.
 func (e *Engine) startMonitors() {
+	cfg := e.config // Not yet shared: UpdateConfig needs the engine returned
+
 	// Emit startup event
 	e.errorBus.Publish(event.NewErrorEvent(
 		event.InfoSeverity,
@@ -299,34 +826,97 @@ func (e *Engine) startMonitors() {
 	).WithContext("memory_limit", FormatBytes(e.memoryLimit)).
 		WithContext("memory_source", e.memoryLimitSrc))
 
-	// Start flight recorder
-	e.WrapGoroutine("flight-recorder", func() {
+	// Start flight recorder (queue depths come from bus introspection)
+	e.flightRecorder.SetQueueDepthSource(e.queueDepths)
+	e.goMonitor("flight-recorder", func() {
 		e.flightRecorder.StartRecording(
 			e.monitorCtx,
-			e.config.FlightRecorderInterval,
+			cfg.FlightRecorderInterval,
 			e.memoryLimit,
 		)
 	})
 
 	// Start memory monitor
-	e.WrapGoroutine("memory-monitor", func() {
+	e.goMonitor("memory-monitor", func() {
 		e.monitorMemory()
 	})
 
 	// Start PSI monitor (will gracefully degrade on non-Linux)
-	e.psiMonitor = NewPSIMonitor(
-		e.config.PSIThreshold,
-		e.config.PSISustainWindow,
-		e.config.PSIPollInterval,
-		e.errorBus,
-	)
-	e.WrapGoroutine("psi-monitor", func() {
-		e.psiMonitor.Start(e.monitorCtx)
-	})
+	if cfg.PSIEnabled {
+		e.psiMonitor = NewPSIMonitor(
+			cfg.PSIThreshold,
+			cfg.PSISustainWindow,
+			cfg.PSIPollInterval,
+			e.errorBus,
+		)
+		e.goMonitor("psi-monitor", func() {
+			e.psiMonitor.Start(e.monitorCtx)
+		})
+	}
+
+	// Start scheduler (publishes due events to the external bus)
+	if e.scheduler != nil {
+		e.goMonitor("scheduler", func() {
+			e.scheduler.Start(e.monitorCtx, cfg.SchedulerTickInterval)
+		})
+	}
+
+	// Publish error summaries when sampling windows close
+	if sampler := e.errorBus.Sampler(); sampler != nil {
+		e.goMonitor("error-sampler", func() {
+			ticker := time.NewTicker(sampler.Window())
+			defer ticker.Stop()
+			for {
+				select {
+				case <-e.monitorCtx.Done():
+					return
+				case <-ticker.C:
+					e.errorBus.FlushSampler()
+				}
+			}
+		})
+	}
+
+	// Shrink retained history while the governor is degraded
+	if len(e.retentions) > 0 && e.controller != nil {
+		e.goMonitor("retention-monitor", func() {
+			e.monitorRetention()
+		})
+	}
+
+	// Tune the GC percent while the governor is degraded
+	if e.gcAssist != nil && e.controller != nil {
+		e.goMonitor("gc-assist", func() {
+			ticker := time.NewTicker(cfg.GovernorPollInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-e.monitorCtx.Done():
+					return
+				case <-ticker.C:
+					e.gcAssist.SetDegraded(e.controller.State() == StateDegraded)
+				}
+			}
+		})
+	}
+
+	// Scale the worker pool to hold the target lag
+	if e.workerPool != nil {
+		e.goMonitor("worker-pool", func() {
+			e.workerPool.Start(e.monitorCtx, cfg.ControlLoopInterval)
+		})
+	}
+
+	// Evaluate alert rules against the error bus
+	if e.alerts != nil {
+		e.goMonitor("alerts", func() {
+			e.alerts.Start(e.monitorCtx, alertTickInterval)
+		})
+	}
 
 	// Start control lab (Phase 2 - analyzes state, emits to error bus)
 	if e.controlLab != nil {
-		e.WrapGoroutine("control-lab", func() {
+		e.goMonitor("control-lab", func() {
 			e.controlLab.Start(e.monitorCtx)
 		})
 	}
@@ -334,7 +924,7 @@ func (e *Engine) startMonitors() {
 
 // monitorMemory polls memory usage and emits warnings.
 func (e *Engine) monitorMemory() {
-	ticker := time.NewTicker(e.config.FlightRecorderInterval)
+	ticker := time.NewTicker(e.Config().FlightRecorderInterval)
 	defer ticker.Stop()
 
 	var lastLevel int // 0=normal, 1=warn, 2=error, 3=crit
@@ -345,9 +935,13 @@ func (e *Engine) monitorMemory() {
 			return
 		case <-ticker.C:
 			stats := ReadMemoryStatsFast(e.memoryLimit)
+			cfg := e.Config() // Thresholds may be reloaded
+
+			// Error level sits 5% below critical (85% with defaults)
+			errorPct := max(cfg.MemoryEnterThreshold, cfg.MemoryCriticalPct-0.05)
 
-			// Update flight recorder with current stats
-			snap := e.flightRecorder.CaptureSnapshot(e.memoryLimit, nil)
+			// Update flight recorder with current stats and queue depths
+			snap := e.flightRecorder.CaptureSnapshot(e.memoryLimit, e.queueDepths())
 			e.flightRecorder.Record(snap)
 
 			// Skip if no memory limit
@@ -357,13 +951,13 @@ func (e *Engine) monitorMemory() {
 
 			// Determine severity level
 			level := 0
-			if stats.UsagePct >= e.config.MemoryEnterThreshold {
+			if stats.UsagePct >= cfg.MemoryEnterThreshold {
 				level = 1 // Warning
 			}
-			if stats.UsagePct >= 0.85 {
+			if stats.UsagePct >= errorPct {
 				level = 2 // Error
 			}
-			if stats.UsagePct >= 0.90 {
+			if stats.UsagePct >= cfg.MemoryCriticalPct {
 				level = 3 // Critical
 			}
 
@@ -373,7 +967,7 @@ func (e *Engine) monitorMemory() {
 			}
 
 			// Emit relief event on drop below exit threshold
-			if lastLevel > 0 && stats.UsagePct < e.config.MemoryExitThreshold {
+			if lastLevel > 0 && stats.UsagePct < cfg.MemoryExitThreshold {
 				e.errorBus.Publish(event.NewErrorEvent(
 					event.InfoSeverity,
 					event.CodeMemRelief,
@@ -391,6 +985,44 @@ func (e *Engine) monitorMemory() {
 	}
 }
 
+// monitorRetention resizes retention stores on every governor poll.
+func (e *Engine) monitorRetention() {
+	ticker := time.NewTicker(e.Config().GovernorPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.monitorCtx.Done():
+			return
+		case <-ticker.C:
+			e.resizeRetention()
+		}
+	}
+}
+
+// resizeRetention scales retention stores down to the governor scale while the
+// governor is degraded, and restores them once it leaves that state.
+func (e *Engine) resizeRetention() {
+	scale := 1.0
+	if e.controller.State() == StateDegraded {
+		scale = e.controller.Scale()
+	}
+	if len(e.retentions) == 0 || e.retentions[0].Scale() == scale {
+		return
+	}
+
+	for _, r := range e.retentions {
+		r.SetScale(scale)
+	}
+	e.errorBus.Publish(event.NewErrorEvent(
+		event.InfoSeverity,
+		event.CodeDegradedMode,
+		"monitor:retention",
+		"Bus retention resized",
+	).WithContext("scale", fmt.Sprintf("%.2f", scale)).
+		WithContext("retained_bytes", FormatBytes(uint64(e.RetainedBytes()))))
+}
+
 // emitMemoryWarning emits a memory warning event.
 func (e *Engine) emitMemoryWarning(stats MemoryStats, level int) {
 	severity := event.WarningSeverity
@@ -398,10 +1030,10 @@ func (e *Engine) emitMemoryWarning(stats MemoryStats, level int) {
 	code := event.CodeMemPressure
 
 	switch level {
-	case 2: // Error (85%+)
+	case 2: // Error (critical - 5%)
 		severity = event.Error
 		signal = event.SignalShed
-	case 3: // Critical (90%+)
+	case 3: // Critical (MemoryCriticalPct)
 		severity = event.CriticalSeverity
 		signal = event.SignalShed
 		code = event.CodeMemCritical
@@ -454,6 +1086,32 @@ func (e *Engine) WrapGoroutine(name string, fn func()) {
 	}()
 }
 
+// goMonitor runs a monitor goroutine under WrapGoroutine and tracks it so
+// Shutdown can wait for monitors to stop before closing the buses they use.
+func (e *Engine) goMonitor(name string, fn func()) {
+	e.monitorWG.Add(1)
+	e.WrapGoroutine(name, func() {
+		defer e.monitorWG.Done()
+		fn()
+	})
+}
+
+// waitMonitors waits for monitor goroutines to exit or ctx to be done.
+func (e *Engine) waitMonitors(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		e.monitorWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // canDumpCrash checks if a crash dump is allowed (rate limiting).
 func (e *Engine) canDumpCrash() bool {
 	e.crashDumpMu.Lock()
@@ -506,21 +1164,69 @@ func (e *Engine) dumpCrashReport(goroutineName string, panicValue any) {
 		}
 	}
 
+	// Dump the error timeline leading up to the panic
+	if history := e.ErrorHistory(); history != nil {
+		fmt.Fprintf(f, "\n")
+		if err := history.Dump(f); err != nil {
+			fmt.Fprintf(f, "Error dumping error history: %v\n", err)
+		}
+	}
+
 	fmt.Fprintf(os.Stderr, "Crash report written to: %s\n", filename)
 }
 
+// attachAdapterManager registers an adapter manager for ordered shutdown.
+func (e *Engine) attachAdapterManager(m *AdapterManager) {
+	if e == nil {
+		return
+	}
+	e.managersMu.Lock()
+	defer e.managersMu.Unlock()
+	e.adapterManagers = append(e.adapterManagers, m)
+}
+
+// attachEmitterManager registers an emitter manager for ordered shutdown.
+func (e *Engine) attachEmitterManager(m *EmitterManager) {
+	if e == nil {
+		return
+	}
+	e.managersMu.Lock()
+	defer e.managersMu.Unlock()
+	e.emitterManagers = append(e.emitterManagers, m)
+}
+
 // Shutdown gracefully shuts down the engine and releases resources.
-// It closes both event buses and waits for the context to complete.
 // Also stops monitors if created with NewWithConfig.
+//
+// Shutdown runs in order so buffered events are not thrown away:
+//  1. Adapters stop (no new input)
+//  2. Buses drain: publishes are rejected while subscribers consume what is
+//     buffered, bounded by ctx and Config.DrainTimeout (the default for
+//     engines built with New())
+//  3. Emitters finish processing, flush (emitter.Flusher) and close
+//  4. The error bus closes and buffered spans are flushed
+//
+// Events still buffered when the drain deadline passes are reported as a
+// DRAIN_INCOMPLETE warning and in the returned error.
 func (e *Engine) Shutdown(ctx context.Context) (err error) {
 	start := time.Now()
 	defer func() {
 		recordEngineOperation(e.metrics, "engine.shutdown", start, err)
 	}()
 
+	var errors []error
+
 	// Stop monitors if they exist
 	if e.monitorCancel != nil {
 		e.monitorCancel()
+		if waitErr := e.waitMonitors(ctx); waitErr != nil {
+			errors = append(errors, fmt.Errorf("monitor shutdown: %w", waitErr))
+		}
+	}
+
+	// GC percent is process-wide; put it back once the tuner has stopped
+	if e.gcAssist != nil {
+		e.gcAssist.Restore()
 	}
 
 	// Emit shutdown event
@@ -533,53 +1239,72 @@ func (e *Engine) Shutdown(ctx context.Context) (err error) {
 		))
 	}
 
-	errCh := make(chan error, 3)
+	e.managersMu.Lock()
+	adapterManagers := append([]*AdapterManager(nil), e.adapterManagers...)
+	emitterManagers := append([]*EmitterManager(nil), e.emitterManagers...)
+	e.managersMu.Unlock()
 
-	// Close internal bus
-	go func() {
-		if err := e.internalBus.Close(); err != nil {
-			errCh <- fmt.Errorf("internal bus shutdown: %w", err)
-		} else {
-			errCh <- nil
+	// 1. Stop adapters
+	for _, m := range adapterManagers {
+		if stopErr := m.Shutdown(); stopErr != nil {
+			errors = append(errors, fmt.Errorf("adapter shutdown: %w", stopErr))
 		}
-	}()
+	}
 
-	// Close external bus
-	go func() {
-		if err := e.externalBus.Close(); err != nil {
-			errCh <- fmt.Errorf("external bus shutdown: %w", err)
-		} else {
-			errCh <- nil
+	// Detach bridges so buses drain without bridged traffic
+	for _, bridge := range e.errorBridges {
+		if closeErr := bridge.Close(); closeErr != nil {
+			errors = append(errors, fmt.Errorf("error bridge close: %w", closeErr))
 		}
-	}()
+	}
 
-	// Close error bus
-	go func() {
-		if e.errorBus != nil {
-			if err := e.errorBus.Close(); err != nil {
-				errCh <- fmt.Errorf("error bus shutdown: %w", err)
-			} else {
-				errCh <- nil
-			}
-		} else {
-			errCh <- nil
+	// 2. Drain buses
+	drainCtx, drainCancel := context.WithTimeout(ctx, e.drainTimeout())
+	defer drainCancel()
+	reports, drainErrs := e.drainBuses(drainCtx)
+	errors = append(errors, drainErrs...)
+	e.reportUndelivered(reports)
+
+	// 3. Flush and close emitters
+	for _, m := range emitterManagers {
+		if flushErr := m.Flush(drainCtx); flushErr != nil {
+			errors = append(errors, fmt.Errorf("emitter flush: %w", flushErr))
 		}
-	}()
+		if stopErr := m.Shutdown(); stopErr != nil {
+			errors = append(errors, fmt.Errorf("emitter shutdown: %w", stopErr))
+		}
+	}
 
-	// Wait for all to complete or context to cancel
-	var errors []error
-	for i := 0; i < 3; i++ {
-		select {
-		case err := <-errCh:
-			if err != nil {
-				errors = append(errors, err)
-			}
-		case <-ctx.Done():
-			err = fmt.Errorf("shutdown cancelled: %w", ctx.Err())
-			return
+	// Emitters have flushed; stop the workers that ran them
+	if e.workerPool != nil {
+		e.workerPool.Close()
+	}
+
+	// 4. Close error bus
+	if e.errorBus != nil {
+		if closeErr := e.errorBus.Close(); closeErr != nil {
+			errors = append(errors, fmt.Errorf("error bus shutdown: %w", closeErr))
+		}
+	}
+
+	// Sinks finish writing what the error bus delivered, then close
+	for _, sink := range e.errorSinks {
+		if closeErr := sink.Close(); closeErr != nil {
+			errors = append(errors, fmt.Errorf("error sink close: %w", closeErr))
 		}
 	}
 
+	// Flush buffered spans
+	if e.tracer != nil {
+		if flushErr := e.tracer.Flush(); flushErr != nil {
+			errors = append(errors, fmt.Errorf("tracer flush: %w", flushErr))
+		}
+	}
+
+	if ctx.Err() != nil {
+		errors = append(errors, fmt.Errorf("shutdown cancelled: %w", ctx.Err()))
+	}
+
 	if len(errors) > 0 {
 		err = fmt.Errorf("shutdown errors: %v", errors)
 		return
@@ -587,3 +1312,78 @@ func (e *Engine) Shutdown(ctx context.Context) (err error) {
 
 	return
 }
+
+// drainTimeout bounds the Shutdown drain. Engines built with New() have no
+// config and drain for the default DrainTimeout.
+func (e *Engine) drainTimeout() time.Duration {
+	cfg := e.Config()
+	if cfg.QueueSizeStart == 0 { // New()
+		return DefaultConfig().DrainTimeout
+	}
+	return cfg.DrainTimeout
+}
+
+// drainBuses drains the external and internal buses in parallel.
+// Buses that do not implement event.Drainer are closed immediately.
+func (e *Engine) drainBuses(ctx context.Context) ([]event.DrainReport, []error) {
+	buses := []struct {
+		name string
+		bus  event.Bus
+	}{
+		{"external", e.externalBus},
+		{"internal", e.internalBus},
+	}
+
+	reports := make([]event.DrainReport, len(buses))
+	errs := make([]error, len(buses))
+	var wg sync.WaitGroup
+	for i, b := range buses {
+		wg.Add(1)
+		go func(i int, name string, bus event.Bus) {
+			defer wg.Done()
+			if d, ok := bus.(event.Drainer); ok {
+				reports[i], _ = d.Drain(ctx) // Leftovers are reported, not errors
+				return
+			}
+			if err := bus.Close(); err != nil {
+				errs[i] = fmt.Errorf("%s bus shutdown: %w", name, err)
+			}
+		}(i, b.name, b.bus)
+	}
+	wg.Wait()
+
+	var errors []error
+	for i, r := range reports {
+		if errs[i] != nil {
+			errors = append(errors, errs[i])
+		}
+		if n := r.Total(); n > 0 {
+			errors = append(errors, fmt.Errorf("%s bus: %d events undelivered", buses[i].name, n))
+		}
+	}
+	return reports, errors
+}
+
+// reportUndelivered publishes a warning for each bus that drained incompletely.
+func (e *Engine) reportUndelivered(reports []event.DrainReport) {
+	if e.errorBus == nil {
+		return
+	}
+	for _, r := range reports {
+		total := r.Total()
+		if total == 0 {
+			continue
+		}
+		evt := event.NewErrorEvent(
+			event.WarningSeverity,
+			event.CodeDrainIncomplete,
+			"engine",
+			fmt.Sprintf("Bus %s closed with %d undelivered events after %s", r.Bus, total, r.Duration.Round(time.Millisecond)),
+		).WithContext("bus", r.Bus).
+			WithContext("undelivered", total)
+		for _, label := range r.Labels() {
+			evt = evt.WithContext("sub:"+label, r.Undelivered[label])
+		}
+		e.errorBus.Publish(evt)
+	}
+}
@@ -1,14 +1,19 @@
 package engine
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/BYTE-6D65/pipeline/pkg/clock"
 	"github.com/BYTE-6D65/pipeline/pkg/event"
 	"github.com/BYTE-6D65/pipeline/pkg/registry"
+	"github.com/BYTE-6D65/pipeline/pkg/trace"
 )
 
 // testClock is a simple fake clock for testing
@@ -369,3 +374,498 @@ func TestEngine_IntegrationTest(t *testing.T) {
 		t.Error("Capability data mismatch")
 	}
 }
+
+func TestEngine_BusStats(t *testing.T) {
+	eng := New()
+	defer eng.Shutdown(context.Background())
+
+	if _, err := eng.ExternalBus().Subscribe(context.Background(), event.Filter{}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	stats := eng.BusStats()
+	if len(stats) != 2 {
+		t.Fatalf("Expected stats for 2 buses, got %d", len(stats))
+	}
+
+	depths := eng.queueDepths()
+	if _, ok := depths["external:sub-0"]; !ok {
+		t.Errorf("Expected external subscription in queue depths, got %v", depths)
+	}
+}
+
+func TestEngine_WithDefaultTTL(t *testing.T) {
+	eng := New(WithDefaultTTL("input.*", time.Millisecond))
+	defer eng.Shutdown(context.Background())
+
+	ctx := context.Background()
+	sub, err := eng.ExternalBus().Subscribe(ctx, event.Filter{})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	stale := &event.Event{ID: "stale", Type: "input.key", Timestamp: time.Now().Add(-time.Second)}
+	fresh := &event.Event{ID: "fresh", Type: "app.event", Timestamp: time.Now().Add(-time.Second)}
+	eng.ExternalBus().Publish(ctx, stale)
+	eng.ExternalBus().Publish(ctx, fresh)
+
+	if got := len(sub.Events()); got != 1 {
+		t.Fatalf("Expected 1 delivered event, got %d", got)
+	}
+	if evt := <-sub.Events(); evt.ID != "fresh" {
+		t.Errorf("Expected untyped-TTL event to pass, got %s", evt.ID)
+	}
+}
+
+func TestEngine_ExpiryFollowsClock(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	clk.Load(0, []time.Duration{time.Second})
+	eng := New(WithClock(clk), WithDefaultTTL("input.*", 500*time.Millisecond))
+	defer eng.Shutdown(context.Background())
+
+	ctx := context.Background()
+	sub, err := eng.ExternalBus().Subscribe(ctx, event.Filter{})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	// Unstamped: stamped on publish, so the policy TTL counts from now
+	eng.ExternalBus().Publish(ctx, &event.Event{ID: "evt-1", Type: "input.key"})
+	if len(sub.Events()) != 1 {
+		t.Fatal("Expected the fresh event delivered")
+	}
+	first := <-sub.Events()
+	if first.Timestamp.IsZero() || first.TTL != 500*time.Millisecond {
+		t.Fatalf("Expected stamped with the policy TTL, got %v/%s", first.Timestamp, first.TTL)
+	}
+
+	// A second on the engine clock later, the same timestamp is stale
+	clk.Advance()
+	eng.ExternalBus().Publish(ctx, &event.Event{ID: "evt-2", Type: "input.key", Timestamp: first.Timestamp})
+	if got := len(sub.Events()); got != 0 {
+		t.Errorf("Expected the stale event skipped on the engine clock, got %d buffered", got)
+	}
+}
+
+// recordingEmitter signals each emitted event on a channel.
+type recordingEmitter struct {
+	emitted chan *event.Event
+}
+
+func (r *recordingEmitter) ID() string   { return "recording" }
+func (r *recordingEmitter) Type() string { return "test" }
+func (r *recordingEmitter) Close() error { return nil }
+
+func (r *recordingEmitter) Emit(ctx context.Context, evt *event.Event) error {
+	r.emitted <- evt
+	return nil
+}
+
+func TestEngine_WithDedup(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	clk.Load(0, []time.Duration{11 * time.Second})
+	eng := New(WithClock(clk), WithDedup(event.WithDedupWindow(10*time.Second)))
+	defer eng.Shutdown(context.Background())
+
+	ctx := context.Background()
+	sub, err := eng.ExternalBus().Subscribe(ctx, event.Filter{})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	defer sub.Close() // Unread events: skip the shutdown drain
+
+	eng.ExternalBus().Publish(ctx, &event.Event{ID: "evt-1", Type: "test"})
+	eng.ExternalBus().Publish(ctx, &event.Event{ID: "evt-1", Type: "test"}) // Retry
+	clk.Advance()                                                           // Past the window on the engine clock
+	eng.ExternalBus().Publish(ctx, &event.Event{ID: "evt-1", Type: "test"})
+
+	if got := len(sub.Events()); got != 2 || eng.Deduplicator().DuplicateCount() != 1 {
+		t.Errorf("Expected 2 delivered and 1 duplicate, got %d and %d", got, eng.Deduplicator().DuplicateCount())
+	}
+}
+
+func TestEngine_WithTracer(t *testing.T) {
+	exporter := trace.NewInMemoryExporter()
+	tracer := trace.NewTracer(exporter)
+	eng := New(WithTracer(tracer))
+	defer eng.Shutdown(context.Background())
+
+	if eng.Tracer() != tracer {
+		t.Fatal("Expected Tracer() to return the configured tracer")
+	}
+
+	rec := &recordingEmitter{emitted: make(chan *event.Event, 1)}
+	em := NewEmitterManager(eng)
+	if err := em.Register("recording", rec, event.Filter{}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := em.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer em.Shutdown()
+
+	evt := &event.Event{ID: "evt-1", Type: "test.trace"}
+	if err := eng.ExternalBus().Publish(context.Background(), evt); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	var emitted *event.Event
+	select {
+	case emitted = <-rec.emitted:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for emit")
+	}
+	em.Stop() // Wait for the emit span to end
+
+	if err := tracer.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	sc, ok := trace.Extract(emitted.Metadata)
+	if !ok {
+		t.Fatal("Expected traceparent on emitted event")
+	}
+
+	stages := make(map[string]bool)
+	for _, s := range exporter.ByTrace(sc.TraceID) {
+		stages[s.Name] = true
+	}
+	for _, want := range []string{"publish test.trace", trace.StageFilter, trace.StageDeliver, trace.StageQueueWait, "emit test.trace"} {
+		if !stages[want] {
+			t.Errorf("Missing %q span (got %v)", want, stages)
+		}
+	}
+}
+
+func TestEngine_WithShardedBuses(t *testing.T) {
+	eng := New(WithShardedBuses(4))
+	defer eng.Shutdown(context.Background())
+
+	for _, bus := range []event.Bus{eng.InternalBus(), eng.ExternalBus()} {
+		sb, ok := bus.(*event.ShardedBus)
+		if !ok {
+			t.Fatalf("Expected *event.ShardedBus, got %T", bus)
+		}
+		if sb.Shards() != 4 {
+			t.Errorf("Expected 4 shards, got %d", sb.Shards())
+		}
+	}
+
+	ctx := context.Background()
+	sub, _ := eng.ExternalBus().Subscribe(ctx, event.Filter{})
+	defer sub.Close() // Unread events: skip the shutdown drain
+	eng.ExternalBus().Publish(ctx, &event.Event{ID: "evt-1", Type: "test"})
+	if len(sub.Events()) != 1 {
+		t.Error("Expected event delivered through sharded bus")
+	}
+
+	stats := eng.BusStats()
+	if len(stats) != 2 || stats[1].Name != "external" {
+		t.Errorf("Expected stats for both sharded buses, got %+v", stats)
+	}
+}
+
+// burstAdapter publishes a fixed number of events on Start.
+type burstAdapter struct {
+	count   int
+	log     *shutdownLog
+	stopped bool
+}
+
+func (a *burstAdapter) ID() string   { return "burst" }
+func (a *burstAdapter) Type() string { return "test" }
+
+func (a *burstAdapter) Start(ctx context.Context, bus event.Bus, clk clock.Clock) error {
+	for i := 0; i < a.count; i++ {
+		bus.Publish(ctx, &event.Event{ID: fmt.Sprintf("evt-%d", i), Type: "test.burst"})
+	}
+	return nil
+}
+
+func (a *burstAdapter) Stop() error {
+	a.stopped = true
+	a.log.add("adapter.stop")
+	return nil
+}
+
+// slowEmitter consumes events slowly and records flush/close order.
+type slowEmitter struct {
+	log     *shutdownLog
+	emitted atomic.Int64
+}
+
+func (e *slowEmitter) ID() string   { return "slow" }
+func (e *slowEmitter) Type() string { return "test" }
+
+func (e *slowEmitter) Emit(ctx context.Context, evt *event.Event) error {
+	time.Sleep(time.Millisecond)
+	e.emitted.Add(1)
+	return nil
+}
+
+func (e *slowEmitter) Flush(ctx context.Context) error {
+	e.log.add(fmt.Sprintf("emitter.flush:%d", e.emitted.Load()))
+	return nil
+}
+
+func (e *slowEmitter) Close() error {
+	e.log.add("emitter.close")
+	return nil
+}
+
+// shutdownLog records lifecycle steps in order.
+type shutdownLog struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (l *shutdownLog) add(step string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.steps = append(l.steps, step)
+}
+
+func TestEngine_ShutdownDrainsInOrder(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DrainTimeout = 2 * time.Second
+	eng, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+
+	log := &shutdownLog{}
+	em := NewEmitterManager(eng)
+	emit := &slowEmitter{log: log}
+	em.Register("slow", emit, event.Filter{Types: []string{"test.*"}})
+	if err := em.Start(); err != nil {
+		t.Fatalf("Emitter start failed: %v", err)
+	}
+
+	am := NewAdapterManager(eng)
+	am.Register(&burstAdapter{count: 20, log: log})
+	if err := am.Start(); err != nil {
+		t.Fatalf("Adapter start failed: %v", err)
+	}
+
+	if err := eng.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	want := []string{"adapter.stop", "emitter.flush:20", "emitter.close"}
+	if fmt.Sprint(log.steps) != fmt.Sprint(want) {
+		t.Errorf("Expected shutdown steps %v, got %v", want, log.steps)
+	}
+
+	// Managers tolerate a second Shutdown from deferred cleanup
+	if err := am.Shutdown(); err != nil {
+		t.Errorf("Second adapter shutdown failed: %v", err)
+	}
+	if err := em.Shutdown(); err != nil {
+		t.Errorf("Second emitter shutdown failed: %v", err)
+	}
+}
+
+func TestEngine_ShutdownDrainsWithoutConfig(t *testing.T) {
+	eng := New() // No config: drains for the default timeout
+
+	log := &shutdownLog{}
+	em := NewEmitterManager(eng)
+	emit := &slowEmitter{log: log}
+	em.Register("slow", emit, event.Filter{})
+	if err := em.Start(); err != nil {
+		t.Fatalf("Emitter start failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 20; i++ {
+		eng.ExternalBus().Publish(ctx, &event.Event{ID: fmt.Sprintf("evt-%d", i), Type: "test"})
+	}
+
+	if err := eng.Shutdown(ctx); err != nil {
+		t.Fatalf("Expected buffered events to drain, got %v", err)
+	}
+	if n := emit.emitted.Load(); n != 20 {
+		t.Errorf("Expected 20 emitted before close, got %d", n)
+	}
+}
+
+func TestEngine_ShutdownReportsUndelivered(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DrainTimeout = 20 * time.Millisecond
+	eng, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+
+	errSub, err := eng.ErrorBus().Subscribe(context.Background())
+	if err != nil {
+		t.Fatalf("ErrorBus subscribe failed: %v", err)
+	}
+	ctx := context.Background()
+	eng.ExternalBus().Subscribe(ctx, event.Filter{}) // Never read
+	eng.ExternalBus().Publish(ctx, &event.Event{ID: "evt-1", Type: "test"})
+
+	err = eng.Shutdown(ctx)
+	if err == nil || !strings.Contains(err.Error(), "1 events undelivered") {
+		t.Errorf("Expected undelivered error, got %v", err)
+	}
+
+	found := false
+	for evt := range errSub.Events() {
+		if evt.Code == event.CodeDrainIncomplete {
+			found = true
+			if evt.Context["undelivered"] != 1 {
+				t.Errorf("Expected undelivered=1, got %v", evt.Context["undelivered"])
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected DRAIN_INCOMPLETE warning on error bus")
+	}
+}
+
+func TestEngine_WithRetention(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.GovernorPollInterval = time.Hour // Resize manually below
+	eng, err := NewWithConfig(cfg, WithRetention(event.WithRetentionPerType(8)))
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer eng.Shutdown(context.Background())
+
+	ctx := context.Background()
+	bus := eng.ExternalBus().(*event.InMemoryBus)
+	for i := 0; i < 8; i++ {
+		bus.Publish(ctx, &event.Event{ID: fmt.Sprintf("evt-%d", i), Type: "test"})
+	}
+
+	sub, _ := bus.SubscribeWithOptions(ctx, event.Filter{}, event.WithRetainedLatest())
+	if evt := <-sub.Events(); evt.ID != "evt-7" {
+		t.Errorf("Expected latest retained evt-7, got %s", evt.ID)
+	}
+	if eng.RetainedBytes() == 0 {
+		t.Error("Expected retained bytes to be reported")
+	}
+	if eng.memoryLimit > 0 && bus.Retention().MaxBytes() == 0 {
+		t.Error("Expected retention capped by the buffer memory budget")
+	}
+
+	// Degraded governor halves retention
+	eng.Governor().Update(0.80)
+	eng.resizeRetention()
+	if n := bus.Retention().Len(); n != 4 {
+		t.Errorf("Expected 4 retained events while degraded, got %d", n)
+	}
+
+	eng.Governor().Update(0.10) // Recovering
+	eng.resizeRetention()
+	if s := bus.Retention().Scale(); s != 1.0 {
+		t.Errorf("Expected full retention after leaving degraded, got %.2f", s)
+	}
+}
+
+func TestEngine_ErrorBusSamplingFromConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ErrorBusSampling = true
+	cfg.ErrorSampleWindow = time.Hour
+	eng, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer eng.Shutdown(context.Background())
+
+	if eng.ErrorBus().Sampler() == nil {
+		t.Fatal("Expected sampler installed from config")
+	}
+
+	sub, _ := eng.ErrorBus().SubscribeFiltered(context.Background(), event.ErrorFilter{Codes: []string{event.CodeEmitterFail}})
+	for i := 0; i < 50; i++ {
+		eng.ErrorBus().Publish(event.NewErrorEvent(event.Error, event.CodeEmitterFail, "emitter:x", "fail"))
+	}
+	if n := len(sub.Events()); n != 1 {
+		t.Errorf("Expected repeats collapsed to 1 event, got %d", n)
+	}
+
+	cfg.ErrorSampleWindow = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected validation error for zero sample window")
+	}
+}
+
+func TestEngine_WithErrorSink(t *testing.T) {
+	var buf bytes.Buffer
+	eng, err := NewWithConfig(DefaultConfig(),
+		WithErrorSink(event.NewConsoleSink(&buf, false),
+			event.WithSinkFilter(event.ErrorFilter{Components: []string{"engine"}})))
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+
+	if err := eng.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	// Startup and shutdown events are written before Shutdown returns
+	out := buf.String()
+	if !strings.Contains(out, "Engine started") || !strings.Contains(out, "Engine shutting down") {
+		t.Errorf("Expected startup and shutdown events in sink output, got %q", out)
+	}
+	if stats := eng.ErrorSinks()[0].Stats(); stats.Written < 2 || stats.Dropped != 0 {
+		t.Errorf("Unexpected sink stats: %+v", stats)
+	}
+}
+
+func TestEngine_WithErrorBridge(t *testing.T) {
+	if _, err := NewWithConfig(DefaultConfig(), WithErrorBridge("sideways")); err == nil {
+		t.Error("Expected unknown bridge bus to be rejected")
+	}
+
+	eng, err := NewWithConfig(DefaultConfig(), WithErrorBridge("external",
+		event.WithBridgeFilter(event.ErrorFilter{Codes: []string{event.CodeEmitterFail}})))
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer eng.Shutdown(context.Background())
+
+	sub, _ := eng.ExternalBus().Subscribe(context.Background(), event.Filter{
+		Types: []string{event.ErrorEventType(event.CodeEmitterFail)},
+	})
+	eng.ErrorBus().Publish(event.NewErrorEvent(event.Error, event.CodeEmitterFail, "emitter:test", "fail"))
+
+	select {
+	case evt := <-sub.Events():
+		if evt.Metadata["component"] != "emitter:test" {
+			t.Errorf("Unexpected bridged event metadata: %v", evt.Metadata)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected bridged error on the external bus")
+	}
+	if len(eng.ErrorBridges()) != 1 {
+		t.Errorf("Expected 1 bridge, got %d", len(eng.ErrorBridges()))
+	}
+}
+
+func TestEngine_ErrorHistory(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ErrorHistorySize = 16
+	eng, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer eng.Shutdown(context.Background())
+
+	eng.ErrorBus().Publish(event.NewErrorEvent(event.Error, event.CodeEmitterFail, "emitter:test", "fail"))
+
+	got := eng.ErrorHistory().Query(event.ErrorQuery{Filter: event.ErrorFilter{Codes: []string{event.CodeEmitterFail}}})
+	if len(got) != 1 || eng.ErrorHistory().Cap() != 16 {
+		t.Errorf("Expected EMITTER_FAIL in a 16-entry history, got %+v", got)
+	}
+
+	cfg.ErrorHistorySize = 0
+	quiet, _ := NewWithConfig(cfg)
+	defer quiet.Shutdown(context.Background())
+	if quiet.ErrorHistory() != nil {
+		t.Error("Expected no history when ErrorHistorySize is 0")
+	}
+}
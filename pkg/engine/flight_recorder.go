@@ -18,6 +18,8 @@ type FlightRecorder struct {
 	index     int
 	size      int
 	mu        sync.Mutex // Single-writer lock
+
+	depthSource func() map[string]int // Optional: queue depths for periodic snapshots
 }
 
 // Snapshot represents a point-in-time state of the system.
@@ -42,6 +44,9 @@ type Snapshot struct {
 	// Governor state
 	GovernorScale float64
 	GovernorState string
+
+	// Note annotates snapshots recorded for an action (e.g., a forced GC)
+	Note string
 }
 
 // LatencySnapshot captures latency percentiles.
@@ -72,6 +77,43 @@ func (fr *FlightRecorder) Record(snap Snapshot) {
 	fr.index = (fr.index + 1) % fr.size
 }
 
+// Resize changes the ring buffer size, keeping the most recent snapshots
+// that fit.
+func (fr *FlightRecorder) Resize(size int) {
+	if size <= 0 {
+		return
+	}
+
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	if size == fr.size {
+		return
+	}
+
+	// Copy newest-last, then keep the tail that fits
+	ordered := make([]Snapshot, 0, fr.size)
+	for i := 0; i < fr.size; i++ {
+		if snap := fr.snapshots[(fr.index+i)%fr.size]; !snap.Timestamp.IsZero() {
+			ordered = append(ordered, snap)
+		}
+	}
+	if len(ordered) > size {
+		ordered = ordered[len(ordered)-size:]
+	}
+
+	fr.snapshots = make([]Snapshot, size)
+	copy(fr.snapshots, ordered)
+	fr.index = len(ordered) % size
+	fr.size = size
+}
+
+// Size returns the ring buffer size.
+func (fr *FlightRecorder) Size() int {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	return fr.size
+}
+
 // Dump writes the flight recorder contents to the given writer.
 // Includes:
 //   - Last N snapshots in chronological order
@@ -80,21 +122,22 @@ func (fr *FlightRecorder) Record(snap Snapshot) {
 func (fr *FlightRecorder) Dump(w io.Writer) error {
 	fr.mu.Lock()
 	// Copy snapshots to avoid holding lock during slow writes
-	snapshots := make([]Snapshot, fr.size)
+	size := fr.size
+	snapshots := make([]Snapshot, size)
 	copy(snapshots, fr.snapshots)
 	currentIndex := fr.index
 	fr.mu.Unlock()
 
 	fmt.Fprintf(w, "=== Flight Recorder Dump ===\n")
 	fmt.Fprintf(w, "Generated: %s\n\n", time.Now().Format(time.RFC3339))
-	fmt.Fprintf(w, "Last %d snapshots:\n\n", fr.size)
+	fmt.Fprintf(w, "Last %d snapshots:\n\n", size)
 
 	// Dump snapshots in chronological order (oldest to newest)
 	count := 0
 	start := currentIndex
 
-	for i := 0; i < fr.size; i++ {
-		idx := (start + i) % fr.size
+	for i := 0; i < size; i++ {
+		idx := (start + i) % size
 		snap := snapshots[idx]
 
 		// Skip uninitialized slots (before buffer fills)
@@ -104,6 +147,9 @@ func (fr *FlightRecorder) Dump(w io.Writer) error {
 
 		count++
 		fmt.Fprintf(w, "[%d] %s\n", count, snap.Timestamp.Format("15:04:05.000"))
+		if snap.Note != "" {
+			fmt.Fprintf(w, "  Note: %s\n", snap.Note)
+		}
 		fmt.Fprintf(w, "  Memory: %s / %s (%.1f%%) | Goroutines: %d | GC: %d\n",
 			FormatBytes(snap.HeapBytes),
 			FormatBytes(snap.MemLimit),
@@ -197,15 +243,33 @@ func (fr *FlightRecorder) StartRecording(ctx context.Context, interval time.Dura
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			// Capture snapshot
-			// Note: Queue depths will be empty here - they should be updated
-			// by the engine via UpdateQueueDepths()
-			snap := fr.CaptureSnapshot(memLimit, nil)
+			// Capture snapshot (queue depths come from the engine-provided source, if any)
+			snap := fr.CaptureSnapshot(memLimit, fr.queueDepths())
 			fr.Record(snap)
 		}
 	}
 }
 
+// SetQueueDepthSource sets the function polled for queue depths on each
+// periodic snapshot. The engine wires this to its bus introspection.
+func (fr *FlightRecorder) SetQueueDepthSource(source func() map[string]int) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	fr.depthSource = source
+}
+
+// queueDepths polls the depth source, if one is set.
+func (fr *FlightRecorder) queueDepths() map[string]int {
+	fr.mu.Lock()
+	source := fr.depthSource
+	fr.mu.Unlock()
+
+	if source == nil {
+		return nil
+	}
+	return source()
+}
+
 // UpdateQueueDepths updates the queue depths in the most recent snapshot.
 // Call this from the engine after capturing depths from bus subscriptions.
 func (fr *FlightRecorder) UpdateQueueDepths(depths map[string]int) {
@@ -0,0 +1,213 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+// GCResult describes one forced collection.
+type GCResult struct {
+	HeapBefore uint64
+	HeapAfter  uint64
+	Duration   time.Duration
+	Reason     string
+}
+
+// Freed returns the heap bytes released (0 if the heap grew).
+func (r GCResult) Freed() uint64 {
+	if r.HeapAfter >= r.HeapBefore {
+		return 0
+	}
+	return r.HeapBefore - r.HeapAfter
+}
+
+// GCAssist executes ForceGCCommands and tunes the GC while degraded.
+//
+// Forced collections (runtime.GC followed by debug.FreeOSMemory) are rate
+// limited by a cooldown measured on the injected clock, since each one
+// stops the world. While the governor is degraded, the GC percent is
+// lowered so the heap is collected more eagerly; the previous value is
+// restored on recovery.
+//
+// Every action is reported on the ErrorBus and recorded as an annotated
+// flight recorder snapshot, with the heap size before and after.
+type GCAssist struct {
+	clock          clock.Clock
+	cooldown       time.Duration
+	degradedPct    int             // GC percent while degraded (0 = don't tune)
+	errorBus       *event.ErrorBus // Optional
+	flightRecorder *FlightRecorder // Optional
+	memoryLimit    uint64
+
+	mu         sync.Mutex
+	lastForced clock.MonoTime
+	forced     uint64
+	skipped    uint64
+	tuned      bool
+	savedPct   int // GC percent to restore on recovery
+}
+
+// NewGCAssist creates a GC assist. degradedPct is the GC percent applied
+// while degraded (0 disables tuning). errorBus and flightRecorder are optional.
+func NewGCAssist(clk clock.Clock, cooldown time.Duration, degradedPct int, errorBus *event.ErrorBus, flightRecorder *FlightRecorder, memoryLimit uint64) *GCAssist {
+	return &GCAssist{
+		clock:          clk,
+		cooldown:       cooldown,
+		degradedPct:    degradedPct,
+		errorBus:       errorBus,
+		flightRecorder: flightRecorder,
+		memoryLimit:    memoryLimit,
+	}
+}
+
+// ForceGC runs a full collection and returns memory to the OS, unless the
+// previous forced collection was less than the cooldown ago. ok is false
+// when rate limited.
+func (g *GCAssist) ForceGC(reason string) (result GCResult, ok bool) {
+	g.mu.Lock()
+	now := g.clock.Now()
+	if g.forced > 0 && clock.ToDuration(now-g.lastForced) < g.cooldown {
+		g.skipped++
+		g.mu.Unlock()
+		return GCResult{}, false
+	}
+	g.lastForced = now
+	g.forced++
+	g.mu.Unlock()
+
+	before := ReadMemoryStatsFast(g.memoryLimit)
+	start := time.Now()
+	runtime.GC()
+	debug.FreeOSMemory()
+	after := ReadMemoryStatsFast(g.memoryLimit)
+
+	result = GCResult{
+		HeapBefore: before.HeapAlloc,
+		HeapAfter:  after.HeapAlloc,
+		Duration:   time.Since(start),
+		Reason:     reason,
+	}
+
+	g.publish(heapEvent(event.WarningSeverity, event.CodeGCForced,
+		fmt.Sprintf("Forced GC freed %s", FormatBytes(result.Freed())), result.HeapBefore, result.HeapAfter).
+		WithContext("duration", result.Duration.String()).
+		WithContext("reason", reason))
+	g.record(fmt.Sprintf("force_gc (%s)", reason), result.HeapBefore, result.HeapAfter)
+	return result, true
+}
+
+// SetDegraded lowers the GC percent when degraded becomes true and restores
+// the saved value when it becomes false. Repeated calls with the same value
+// do nothing.
+func (g *GCAssist) SetDegraded(degraded bool) {
+	if g.degradedPct <= 0 {
+		return
+	}
+
+	g.mu.Lock()
+	if degraded == g.tuned {
+		g.mu.Unlock()
+		return
+	}
+	g.tuned = degraded
+
+	var pct, prev int
+	if degraded {
+		pct = g.degradedPct
+		prev = debug.SetGCPercent(pct)
+		g.savedPct = prev
+	} else {
+		pct = g.savedPct
+		prev = debug.SetGCPercent(pct)
+	}
+	g.mu.Unlock()
+
+	heap := ReadMemoryStatsFast(g.memoryLimit).HeapAlloc
+	message := fmt.Sprintf("GC percent lowered to %d while degraded", pct)
+	if !degraded {
+		message = fmt.Sprintf("GC percent restored to %d", pct)
+	}
+	g.publish(heapEvent(event.InfoSeverity, event.CodeGCTuned, message, heap, heap).
+		WithContext("gc_percent", pct).
+		WithContext("previous_gc_percent", prev))
+	g.record(fmt.Sprintf("gc_percent %d -> %d", prev, pct), heap, heap)
+}
+
+// Restore puts back the GC percent saved when degraded (used at shutdown).
+func (g *GCAssist) Restore() {
+	g.SetDegraded(false)
+}
+
+// Listen executes control.gc.force commands from bus until ctx is
+// cancelled or the bus closes.
+func (g *GCAssist) Listen(ctx context.Context, bus event.Bus) error {
+	filter := event.Filter{
+		Types: []string{event.EventTypeForceGC},
+	}
+
+	sub, err := event.SubscribeNamed(ctx, bus, "gc-assist", filter)
+	if err != nil {
+		return fmt.Errorf("gc assist: failed to subscribe to internal bus: %w", err)
+	}
+
+	go func() {
+		defer sub.Close()
+
+		for {
+			select {
+			case evt, ok := <-sub.Events():
+				if !ok {
+					return // Bus closed
+				}
+				var cmd event.ForceGCCommand
+				if err := evt.DecodePayload(&cmd, event.JSONCodec{}); err != nil {
+					continue
+				}
+				g.ForceGC(cmd.Reason)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stats returns how many collections were forced and how many requests the
+// cooldown skipped.
+func (g *GCAssist) Stats() (forced, skipped uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.forced, g.skipped
+}
+
+// heapEvent creates a GC event with heap before/after context.
+func heapEvent(sev event.ErrorSeverity, code, message string, before, after uint64) event.ErrorEvent {
+	return event.NewErrorEvent(sev, code, "engine:gc", message).
+		WithContext("heap_before", FormatBytes(before)).
+		WithContext("heap_after", FormatBytes(after))
+}
+
+// publish sends evt to the error bus, if any.
+func (g *GCAssist) publish(evt event.ErrorEvent) {
+	if g.errorBus != nil {
+		g.errorBus.Publish(evt)
+	}
+}
+
+// record adds an annotated flight recorder snapshot.
+func (g *GCAssist) record(action string, before, after uint64) {
+	if g.flightRecorder == nil {
+		return
+	}
+	snap := g.flightRecorder.CaptureSnapshot(g.memoryLimit, nil)
+	snap.Note = fmt.Sprintf("%s: heap %s -> %s", action, FormatBytes(before), FormatBytes(after))
+	g.flightRecorder.Record(snap)
+}
@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+func TestGCAssist_ForceGCCooldown(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	clk.Load(0, []time.Duration{10 * time.Second, 30 * time.Second})
+
+	errorBus := event.NewErrorBus(8)
+	defer errorBus.Close()
+	sub, _ := errorBus.SubscribeFiltered(t.Context(), event.ErrorFilter{Codes: []string{event.CodeGCForced}})
+	recorder := NewFlightRecorder(4)
+
+	g := NewGCAssist(clk, 30*time.Second, 0, errorBus, recorder, 0)
+
+	if _, ok := g.ForceGC("test"); !ok {
+		t.Fatal("First forced GC should run")
+	}
+	clk.Advance() // t=10s
+	if _, ok := g.ForceGC("test"); ok {
+		t.Error("Forced GC within cooldown should be skipped")
+	}
+	clk.Advance() // t=40s
+	if _, ok := g.ForceGC("test"); !ok {
+		t.Error("Forced GC after cooldown should run")
+	}
+	if forced, skipped := g.Stats(); forced != 2 || skipped != 1 {
+		t.Errorf("Expected 2 forced and 1 skipped, got %d/%d", forced, skipped)
+	}
+
+	evt := <-sub.Events()
+	if evt.Context["heap_before"] == nil || evt.Context["heap_after"] == nil || evt.Context["reason"] != "test" {
+		t.Errorf("Expected heap before/after context, got %v", evt.Context)
+	}
+
+	var buf bytes.Buffer
+	recorder.Dump(&buf)
+	if !strings.Contains(buf.String(), "Note: force_gc (test): heap ") {
+		t.Error("Expected annotated flight recorder snapshot")
+	}
+}
+
+func TestGCAssist_TunesGCPercentWhileDegraded(t *testing.T) {
+	original := debug.SetGCPercent(100)
+	defer debug.SetGCPercent(original)
+
+	g := NewGCAssist(clock.NewSystemClock(), time.Second, 40, nil, nil, 0)
+
+	g.SetDegraded(true)
+	g.SetDegraded(true) // No-op
+	if pct := debug.SetGCPercent(40); pct != 40 {
+		t.Errorf("Expected GC percent 40 while degraded, got %d", pct)
+	}
+
+	g.SetDegraded(false)
+	if pct := debug.SetGCPercent(100); pct != 100 {
+		t.Errorf("Expected GC percent restored to 100, got %d", pct)
+	}
+}
+
+func TestControlLab_RequestsForceGC(t *testing.T) {
+	bus := event.NewInMemoryBus(event.WithBufferSize(16))
+	defer bus.Close()
+	errorBus := event.NewErrorBus(16)
+	defer errorBus.Close()
+	sub, _ := bus.Subscribe(t.Context(), event.Filter{Types: []string{event.EventTypeForceGC}})
+
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	governor := NewDefaultAIMDGovernor(clk, time.Second)
+
+	// A 1-byte limit makes any heap critical
+	lab := NewControlLab(clk, errorBus, bus, governor.AsController(), NewDefaultREDDropper(), 1, time.Second)
+	lab.SetForceGC(0.9, time.Minute)
+
+	lab.updateGovernor()
+	lab.updateGovernor() // Within the interval: no second request
+
+	select {
+	case evt := <-sub.Events():
+		var cmd event.ForceGCCommand
+		if err := evt.DecodePayload(&cmd, event.JSONCodec{}); err != nil || !strings.Contains(cmd.Reason, "critical") {
+			t.Errorf("Unexpected command: %+v (%v)", cmd, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected ForceGCCommand on the internal bus")
+	}
+	select {
+	case <-sub.Events():
+		t.Error("Expected one request per interval")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestEngine_ExecutesForceGCCommand(t *testing.T) {
+	eng, err := NewWithConfig(DefaultConfig())
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer eng.Shutdown(context.Background())
+
+	sub, _ := eng.ErrorBus().SubscribeFiltered(t.Context(), event.ErrorFilter{Codes: []string{event.CodeGCForced}})
+	eng.InternalBus().Publish(context.Background(), event.NewControlEvent(event.EventTypeForceGC, event.ForceGCCommand{
+		Reason:    "operator",
+		Timestamp: time.Now(),
+	}))
+
+	select {
+	case evt := <-sub.Events():
+		if evt.Context["reason"] != "operator" {
+			t.Errorf("Unexpected GC event context: %v", evt.Context)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected engine to execute the forced GC")
+	}
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+// LagMeter estimates subscription lag (how long a newly published event
+// waits before its subscriber reads it) from bus stats, without touching
+// events.
+//
+// Per subscription, between two Measure calls:
+//   - drain rate = events read / elapsed (delivered minus buffer growth)
+//   - lag = buffered events / drain rate (Little's law)
+//   - a subscriber that read nothing while events were buffered is stalled;
+//     its lag is the time since it last made progress
+//
+// Measure reports the largest lag. Buses that do not implement
+// event.StatsProvider are skipped.
+type LagMeter struct {
+	clock clock.Clock
+	buses []event.Bus
+
+	last     map[string]lagSample // Keyed by bus name + subscription ID
+	lastTime clock.MonoTime
+	measured bool
+}
+
+// lagSample is one subscription's counters at the previous Measure.
+type lagSample struct {
+	delivered uint64
+	buffered  int
+	progress  clock.MonoTime // Last Measure at which the subscriber had read everything or made progress
+}
+
+// NewLagMeter creates a lag meter over buses.
+func NewLagMeter(clk clock.Clock, buses ...event.Bus) *LagMeter {
+	return &LagMeter{
+		clock: clk,
+		buses: buses,
+		last:  make(map[string]lagSample),
+	}
+}
+
+// Measure returns the largest subscription lag since the previous call.
+// The first call only records a baseline and returns 0. Not safe for
+// concurrent use.
+func (m *LagMeter) Measure() time.Duration {
+	now := m.clock.Now()
+	elapsed := clock.ToDuration(now - m.lastTime)
+	first := !m.measured
+	m.lastTime, m.measured = now, true
+
+	var worst time.Duration
+	seen := make(map[string]lagSample, len(m.last))
+	for _, bus := range m.buses {
+		sp, ok := bus.(event.StatsProvider)
+		if !ok {
+			continue
+		}
+		stats := sp.Stats()
+		for _, sub := range stats.Subscriptions {
+			key := stats.Name + "/" + sub.ID
+			cur := lagSample{delivered: sub.Delivered, buffered: sub.BufferLen, progress: now}
+			prev, known := m.last[key]
+
+			if !first && known && elapsed > 0 && sub.BufferLen > 0 {
+				read := int64(sub.Delivered-prev.delivered) - int64(sub.BufferLen-prev.buffered)
+				if read > 0 {
+					rate := float64(read) / elapsed.Seconds()
+					worst = max(worst, time.Duration(float64(sub.BufferLen)/rate*float64(time.Second)))
+				} else {
+					cur.progress = prev.progress
+					worst = max(worst, clock.ToDuration(now-prev.progress))
+				}
+			}
+			seen[key] = cur
+		}
+	}
+	m.last = seen // Forget closed subscriptions
+	return worst
+}
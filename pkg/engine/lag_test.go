@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+func TestLagMeter_Measure(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	clk.Load(0, []time.Duration{time.Second, time.Second, time.Second})
+
+	bus := event.NewInMemoryBus(event.WithBufferSize(64))
+	defer bus.Close()
+	sub, err := bus.Subscribe(t.Context(), event.Filter{})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	n := 0
+	publish := func(count int) {
+		for range count {
+			n++
+			bus.Publish(t.Context(), &event.Event{ID: fmt.Sprintf("evt-%d", n), Type: "test"})
+		}
+	}
+	meter := NewLagMeter(clk, bus)
+
+	// Baseline only
+	publish(10)
+	if lag := meter.Measure(); lag != 0 {
+		t.Errorf("Expected 0 on the first measure, got %s", lag)
+	}
+
+	// Reads 5/s with 10 buffered: 2s to reach a new event
+	clk.Advance()
+	for range 5 {
+		<-sub.Events()
+	}
+	publish(5)
+	if lag := meter.Measure(); lag != 2*time.Second {
+		t.Errorf("Expected 2s drain lag, got %s", lag)
+	}
+
+	// Stalled: lag grows with the time since the last read
+	for _, want := range []time.Duration{time.Second, 2 * time.Second} {
+		clk.Advance()
+		publish(2)
+		if lag := meter.Measure(); lag != want {
+			t.Errorf("Expected stalled lag %s, got %s", want, lag)
+		}
+	}
+}
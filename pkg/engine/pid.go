@@ -0,0 +1,233 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+)
+
+// PIDConfig tunes a PIDController.
+//
+// The error is normalized to the target: (lag - target) / target, so lag at
+// twice the target is an error of +1.0 whatever the target is.
+type PIDConfig struct {
+	Target           time.Duration // Lag setpoint (Config.TargetLagMs)
+	Kp               float64       // Proportional gain (scale per unit error)
+	Ki               float64       // Integral gain (scale per unit error per second)
+	Kd               float64       // Derivative gain (scale per unit error per second of change)
+	DerivativeFilter time.Duration // Low-pass time constant for the derivative (0 = unfiltered)
+	MinScale         float64       // Output floor (Config.GovernorMinScale)
+	MaxScale         float64       // Output ceiling (0 = 1.0)
+}
+
+// PIDController is a Controller that holds measured subscription lag
+// (ControlSignals.Lag) at a target by scaling admitted load:
+//
+//	scale = MaxScale - (Kp·e + Ki·∫e dt + Kd·de/dt), clamped to [MinScale, MaxScale]
+//
+// Unlike AIMDGovernor it reacts continuously and proportionally, without
+// cooldowns, so it settles instead of sawing between steps. It ignores the
+// pressure reading.
+//
+// Protections:
+//   - Output clamping: scale stays within [MinScale, MaxScale]
+//   - Anti-windup: the integral stops where the output saturates and is
+//     frozen while the error pushes further into the clamp (conditional
+//     integration), so recovery after a long overload is not delayed
+//   - Derivative filtering: the derivative acts on the measurement (no kick
+//     when the target changes) through a first-order low-pass filter
+//
+// States: NORMAL at MaxScale, DEGRADED while lag is above target and the
+// scale is reduced, RECOVERING while lag is at or below target and the
+// scale is still reduced.
+//
+// Time between updates is read from the injected clock.
+type PIDController struct {
+	clock clock.Clock
+
+	mu         sync.RWMutex
+	cfg        PIDConfig
+	scale      float64
+	state      GovernorState
+	integral   float64 // Ki·∫e dt, in scale units
+	derivative float64 // Filtered d(lag/target)/dt
+	lastLag    time.Duration
+	lastUpdate clock.MonoTime
+	started    bool
+	explain    string
+}
+
+// NewPIDController creates a PID controller at full scale.
+func NewPIDController(clk clock.Clock, cfg PIDConfig) *PIDController {
+	c := &PIDController{
+		clock:   clk,
+		scale:   1.0,
+		state:   StateNormal,
+		explain: "NORMAL: no lag reading yet",
+	}
+	c.setConfig(cfg)
+	c.scale = c.cfg.MaxScale
+	return c
+}
+
+// NewPIDControllerFromConfig creates a PID controller from the engine
+// config: TargetLagMs, PIDKp, PIDKi, PIDKd, PIDDerivativeFilter and
+// GovernorMinScale.
+func NewPIDControllerFromConfig(clk clock.Clock, cfg Config) *PIDController {
+	return NewPIDController(clk, pidConfig(cfg))
+}
+
+// pidConfig maps the engine config to a PIDConfig.
+func pidConfig(cfg Config) PIDConfig {
+	return PIDConfig{
+		Target:           time.Duration(cfg.TargetLagMs) * time.Millisecond,
+		Kp:               cfg.PIDKp,
+		Ki:               cfg.PIDKi,
+		Kd:               cfg.PIDKd,
+		DerivativeFilter: cfg.PIDDerivativeFilter,
+		MinScale:         cfg.GovernorMinScale,
+		MaxScale:         1.0,
+	}
+}
+
+// ApplyConfig replaces the gains, target and scale floor of a running
+// controller. The integral and scale are kept.
+//
+// Thread-safe: Can be called concurrently with Update().
+func (c *PIDController) ApplyConfig(cfg Config) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setConfig(pidConfig(cfg))
+}
+
+// setConfig stores cfg with defaults filled in. Caller holds c.mu or owns c.
+func (c *PIDController) setConfig(cfg PIDConfig) {
+	if cfg.MaxScale <= 0 {
+		cfg.MaxScale = 1.0
+	}
+	if cfg.Target <= 0 {
+		cfg.Target = time.Millisecond
+	}
+	c.cfg = cfg
+	c.scale = max(cfg.MinScale, min(c.scale, cfg.MaxScale))
+}
+
+// Update processes one lag reading (Controller interface).
+//
+// Thread-safe: Can be called concurrently with Scale(), State() and Explain().
+func (c *PIDController) Update(signals ControlSignals) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cfg := c.cfg
+	now := c.clock.Now()
+	var dt float64
+	if c.started {
+		dt = clock.ToDuration(now - c.lastUpdate).Seconds()
+	}
+	c.started = true
+	c.lastUpdate = now
+
+	target := cfg.Target.Seconds()
+	lag := signals.Lag
+	e := (lag.Seconds() - target) / target
+
+	// Filtered derivative on the measurement
+	if dt > 0 {
+		raw := (lag - c.lastLag).Seconds() / target / dt
+		alpha := 1.0
+		if cfg.DerivativeFilter > 0 {
+			alpha = dt / (cfg.DerivativeFilter.Seconds() + dt)
+		}
+		c.derivative += alpha * (raw - c.derivative)
+	}
+	c.lastLag = lag
+
+	p := cfg.Kp * e
+	d := cfg.Kd * c.derivative
+
+	// Conditional integration: integrate only up to the point where the
+	// output saturates, never further into the clamp
+	integral := c.integral + cfg.Ki*e*dt
+	out := cfg.MaxScale - (p + integral + d)
+	held := false
+	switch {
+	case out < cfg.MinScale && e > 0:
+		integral, held = max(c.integral, cfg.MaxScale-cfg.MinScale-p-d), true
+	case out > cfg.MaxScale && e < 0:
+		integral, held = min(c.integral, -p-d), true
+	}
+	c.integral = integral
+
+	switch {
+	case out <= cfg.MinScale:
+		c.scale = cfg.MinScale
+	case out >= cfg.MaxScale:
+		c.scale = cfg.MaxScale
+	default:
+		c.scale = out
+	}
+
+	from := c.state
+	switch {
+	case c.scale >= cfg.MaxScale:
+		c.state = StateNormal
+	case e > 0:
+		c.state = StateDegraded
+	default:
+		c.state = StateRecovering
+	}
+
+	transition := c.state.String()
+	if c.state != from {
+		transition = from.String() + " -> " + transition
+	}
+	c.explain = fmt.Sprintf("%s: lag %s vs target %s (error %+.2f), P %+.3f I %+.3f D %+.3f, scale %.2f",
+		transition, lag.Round(time.Microsecond), cfg.Target, e, p, c.integral, d, c.scale)
+	switch {
+	case out < cfg.MinScale:
+		c.explain += fmt.Sprintf(" (clamped at min %.2f)", cfg.MinScale)
+	case out > cfg.MaxScale:
+		c.explain += fmt.Sprintf(" (clamped at max %.2f)", cfg.MaxScale)
+	}
+	if held {
+		c.explain += ", integral held"
+	}
+}
+
+// Scale returns the current scale factor (Controller interface).
+func (c *PIDController) Scale() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.scale
+}
+
+// State returns the current state (Controller interface).
+func (c *PIDController) State() GovernorState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+// Explain describes the most recent update (Controller interface).
+func (c *PIDController) Explain() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.explain
+}
+
+// Config returns the controller tuning.
+func (c *PIDController) Config() PIDConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cfg
+}
+
+// Integral returns the integral term (Ki·∫e dt, in scale units).
+func (c *PIDController) Integral() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.integral
+}
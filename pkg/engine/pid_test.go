@@ -0,0 +1,222 @@
+package engine
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+// queuePlant is a single queue: arrivals at arrivalRate × scale, service at
+// serviceRate (events/sec). Lag is how long a new arrival waits.
+type queuePlant struct {
+	arrivalRate float64
+	serviceRate float64
+	backlog     float64
+}
+
+func (q *queuePlant) step(scale float64, dt time.Duration) time.Duration {
+	q.backlog = max(0, q.backlog+(q.arrivalRate*scale-q.serviceRate)*dt.Seconds())
+	return time.Duration(q.backlog / q.serviceRate * float64(time.Second))
+}
+
+const plantTick = 50 * time.Millisecond // Default GovernorPollInterval
+
+// newPlantClock returns a DeltaClock with n plant ticks loaded.
+func newPlantClock(n int) *clock.DeltaClock {
+	deltas := make([]time.Duration, n)
+	for i := range deltas {
+		deltas[i] = plantTick
+	}
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	clk.Load(0, deltas)
+	return clk
+}
+
+// runPlant drives c against plant for n ticks and returns the lag and scale
+// after each tick.
+func runPlant(t *testing.T, clk *clock.DeltaClock, c *PIDController, plant *queuePlant, n int) (lags []time.Duration, scales []float64) {
+	t.Helper()
+	minScale, maxScale := c.Config().MinScale, c.Config().MaxScale
+	for i := 0; i < n; i++ {
+		clk.Advance()
+		lag := plant.step(c.Scale(), plantTick)
+		c.Update(ControlSignals{Lag: lag})
+		if s := c.Scale(); s < minScale || s > maxScale {
+			t.Fatalf("Tick %d: scale %.3f outside [%.2f, %.2f]", i, s, minScale, maxScale)
+		}
+		lags = append(lags, lag)
+		scales = append(scales, c.Scale())
+	}
+	return lags, scales
+}
+
+func TestPIDController_SettlesOnTarget(t *testing.T) {
+	cfg := DefaultConfig() // 10ms target
+	for _, ratio := range []float64{1.2, 2, 4} {
+		clk := newPlantClock(400)
+		c := NewPIDControllerFromConfig(clk, cfg)
+		plant := &queuePlant{arrivalRate: 1000 * ratio, serviceRate: 1000}
+
+		lags, scales := runPlant(t, clk, c, plant, 400) // 20s
+
+		// Last 5s: lag on target, scale at the plant's capacity
+		want := 1 / ratio
+		for i := 300; i < 400; i++ {
+			if math.Abs(lags[i].Seconds()-0.010) > 0.001 || math.Abs(scales[i]-want) > 0.02 {
+				t.Fatalf("Ratio %.1f, tick %d: expected lag 10ms at scale %.2f, got %s at %.3f",
+					ratio, i, want, lags[i], scales[i])
+			}
+		}
+		if c.State() != StateDegraded && c.State() != StateRecovering {
+			t.Errorf("Ratio %.1f: expected reduced-scale state, got %s", ratio, c.State())
+		}
+	}
+}
+
+func TestPIDController_AntiWindup(t *testing.T) {
+	cfg := DefaultConfig()
+	clk := newPlantClock(1200)
+	c := NewPIDControllerFromConfig(clk, cfg)
+
+	// 10s of overload the floor can't absorb: clamped at min scale
+	plant := &queuePlant{arrivalRate: 10000, serviceRate: 1000}
+	_, scales := runPlant(t, clk, c, plant, 200)
+	if scales[len(scales)-1] != cfg.GovernorMinScale {
+		t.Fatalf("Expected scale clamped at %.2f, got %.3f", cfg.GovernorMinScale, scales[len(scales)-1])
+	}
+	if !strings.Contains(c.Explain(), "integral held") {
+		t.Errorf("Expected held integral while clamped, got %q", c.Explain())
+	}
+	if c.Integral() > 1-cfg.GovernorMinScale {
+		t.Errorf("Expected integral bounded by the output range, got %.3f", c.Integral())
+	}
+
+	// Load drops below capacity: drain the backlog, then recover promptly
+	plant.arrivalRate = 500
+	lags, scales := runPlant(t, clk, c, plant, 1000)
+	drained := -1
+	for i, lag := range lags {
+		if lag == 0 {
+			drained = i
+			break
+		}
+	}
+	if drained < 0 {
+		t.Fatal("Expected the backlog to drain")
+	}
+	recovered := -1
+	for i := drained; i < len(scales); i++ {
+		if scales[i] == 1.0 {
+			recovered = i
+			break
+		}
+	}
+	if recovered < 0 || time.Duration(recovered-drained)*plantTick > 5*time.Second {
+		t.Errorf("Expected full scale within 5s of draining, took %d ticks", recovered-drained)
+	}
+	if c.State() != StateNormal {
+		t.Errorf("Expected NORMAL after recovery, got %s", c.State())
+	}
+}
+
+func TestPIDController_DerivativeFilter(t *testing.T) {
+	// Lag jitters ±20% around target; only the derivative term acts
+	swing := func(filter time.Duration) float64 {
+		clk := newPlantClock(100)
+		c := NewPIDController(clk, PIDConfig{
+			Target: 10 * time.Millisecond, Kd: 0.05, DerivativeFilter: filter, MinScale: 0.2,
+		})
+		var lo, hi float64 = 1, 0
+		for i := 0; i < 100; i++ {
+			clk.Advance()
+			lag := 10 * time.Millisecond
+			if i%2 == 0 {
+				lag += 2 * time.Millisecond
+			} else {
+				lag -= 2 * time.Millisecond
+			}
+			c.Update(ControlSignals{Lag: lag})
+			if i >= 10 {
+				lo, hi = min(lo, c.Scale()), max(hi, c.Scale())
+			}
+		}
+		return hi - lo
+	}
+
+	raw, filtered := swing(0), swing(500*time.Millisecond)
+	if filtered > raw/4 {
+		t.Errorf("Expected filtering to damp jitter, swing %.3f unfiltered vs %.3f filtered", raw, filtered)
+	}
+}
+
+func TestEngine_PIDController(t *testing.T) {
+	eng := newEnvEngine(t, map[string]string{"PIPELINE_CONTROLLER": "pid"})
+
+	pid, ok := eng.Controller().(*PIDController)
+	if !ok || eng.Governor() != nil {
+		t.Fatalf("Expected PID controller and no AIMD governor, got %T", eng.Controller())
+	}
+
+	eng.controlLab.SetLagSampler(func() time.Duration { return 30 * time.Millisecond })
+	eng.controlLab.updateGovernor()
+	if pid.State() != StateDegraded || pid.Scale() >= 1 {
+		t.Errorf("Expected lag above target to reduce scale, got %s at %.2f", pid.State(), pid.Scale())
+	}
+	if !strings.Contains(pid.Explain(), "lag 30ms vs target 10ms") {
+		t.Errorf("Unexpected explanation %q", pid.Explain())
+	}
+
+	next := eng.Config()
+	next.PIDKp = 0.1
+	next.Controller = ControllerAIMD // Needs a restart
+	update, err := eng.UpdateConfig(next)
+	if err != nil || len(update.Applied()) != 1 || len(update.RestartRequired()) != 1 {
+		t.Fatalf("Expected PIDKp applied and Controller pending, got %v (%v)", update.Changes, err)
+	}
+	if pid.Config().Kp != 0.1 {
+		t.Errorf("Expected live Kp 0.1, got %.2f", pid.Config().Kp)
+	}
+
+	bad := DefaultConfig()
+	bad.Controller = "mpc"
+	bad.PIDKi = -1
+	if err := bad.Validate(); err == nil {
+		t.Error("Expected unknown controller and negative gain to fail validation")
+	}
+
+	unset := DefaultConfig()
+	unset.Controller = "" // AIMD, as in NewControllerFromConfig
+	if err := unset.Validate(); err != nil {
+		t.Errorf("Expected an unset controller to validate, got %v", err)
+	}
+}
+
+func TestEngine_PIDControllerTimebase(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	clk.Load(0, []time.Duration{time.Second})
+	eng := newEnvEngine(t, map[string]string{"PIPELINE_CONTROLLER": "pid"}, WithClock(clk))
+
+	// The controller integrates on the same clock the lag is measured with
+	if pid := eng.Controller().(*PIDController); pid.clock != clock.Clock(clk) {
+		t.Fatal("Expected the PID controller on the engine clock")
+	}
+
+	// A stalled internal bus subscriber counts as lag
+	sub, err := eng.InternalBus().Subscribe(t.Context(), event.Filter{Types: []string{"test.stalled"}})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	t.Cleanup(func() { sub.Close() }) // Before shutdown, which would wait to drain it
+	eng.InternalBus().Publish(t.Context(), &event.Event{ID: "evt-1", Type: "test.stalled"})
+	eng.controlLab.sampleLag() // Baseline
+	clk.Advance()
+	if lag := eng.controlLab.sampleLag(); lag != time.Second {
+		t.Errorf("Expected 1s internal bus lag, got %s", lag)
+	}
+}
@@ -0,0 +1,340 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+// PressureSource names one input signal of the pressure model.
+type PressureSource string
+
+const (
+	PressureHeap        PressureSource = "heap"         // Heap objects / memory limit
+	PressurePSI         PressureSource = "psi"          // PSI memory avg10
+	PressureQueue       PressureSource = "queue"        // Fullest bus subscription buffer
+	PressureGC          PressureSource = "gc"           // GC share of CPU time since the last sample
+	PressureCPUThrottle PressureSource = "cpu_throttle" // Throttled cgroup CPU periods since the last sample
+)
+
+// PressureMode selects how the model combines signals.
+type PressureMode string
+
+const (
+	// PressureModeMax uses the largest weighted signal.
+	PressureModeMax PressureMode = "max"
+
+	// PressureModeWeighted uses the weighted average of all signals.
+	PressureModeWeighted PressureMode = "weighted"
+)
+
+// ParsePressureMode parses "max" or "weighted".
+func ParsePressureMode(s string) (PressureMode, error) {
+	switch mode := PressureMode(strings.ToLower(s)); mode {
+	case PressureModeMax, PressureModeWeighted:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid pressure mode %q (want \"max\" or \"weighted\")", s)
+	}
+}
+
+// PressureSignals holds one reading of every signal, each normalized to
+// 0.0-1.0. Signals that are unavailable on the platform read 0.
+type PressureSignals struct {
+	Heap        float64
+	PSI         float64
+	Queue       float64
+	GC          float64
+	CPUThrottle float64
+}
+
+// String formats the signals as "heap=72.0% psi=0.0% ...".
+func (s PressureSignals) String() string {
+	return fmt.Sprintf("heap=%.1f%% psi=%.1f%% queue=%.1f%% gc=%.1f%% cpu_throttle=%.1f%%",
+		s.Heap*100, s.PSI*100, s.Queue*100, s.GC*100, s.CPUThrottle*100)
+}
+
+// PressureWeights scales each signal. A zero weight ignores the signal.
+type PressureWeights struct {
+	Heap        float64
+	PSI         float64
+	Queue       float64
+	GC          float64
+	CPUThrottle float64
+}
+
+// weightedSignal is one signal with its weight.
+type weightedSignal struct {
+	source PressureSource
+	value  float64
+	weight float64
+}
+
+// weighted pairs each signal with its weight, in a fixed order.
+func (s PressureSignals) weighted(w PressureWeights) []weightedSignal {
+	return []weightedSignal{
+		{PressureHeap, s.Heap, w.Heap},
+		{PressurePSI, s.PSI, w.PSI},
+		{PressureQueue, s.Queue, w.Queue},
+		{PressureGC, s.GC, w.GC},
+		{PressureCPUThrottle, s.CPUThrottle, w.CPUThrottle},
+	}
+}
+
+// PressureReading is the combined pressure for one set of signals.
+type PressureReading struct {
+	Signals  PressureSignals
+	Pressure float64        // Combined pressure fed to the governor (0.0-1.0)
+	Driver   PressureSource // Signal with the largest weighted contribution
+	Mode     PressureMode
+}
+
+// String formats the reading as "72.0% (driver queue)".
+func (r PressureReading) String() string {
+	return fmt.Sprintf("%.1f%% (driver %s)", r.Pressure*100, r.Driver)
+}
+
+// PressureModel combines pressure signals into the single value the
+// governor reacts to, and reports which signal drove it.
+//
+// Modes:
+//   - max: pressure = max(weight × signal), capped at 1.0. Any one
+//     saturated resource degrades the pipeline.
+//   - weighted: pressure = Σ(weight × signal) / Σweight. Signals must agree
+//     before the pipeline degrades.
+//
+// In both modes the driver is the signal with the largest weight × signal;
+// ties go to the earlier signal (heap, psi, queue, gc, cpu_throttle).
+type PressureModel struct {
+	mu      sync.RWMutex
+	mode    PressureMode
+	weights PressureWeights
+}
+
+// NewPressureModel creates a model with the given mode and weights.
+func NewPressureModel(mode PressureMode, weights PressureWeights) *PressureModel {
+	return &PressureModel{mode: mode, weights: weights}
+}
+
+// NewPressureModelFromConfig creates a model from the Pressure* fields of
+// cfg. An invalid mode falls back to max (Validate reports it).
+func NewPressureModelFromConfig(cfg Config) *PressureModel {
+	m := &PressureModel{}
+	m.ApplyConfig(cfg)
+	return m
+}
+
+// HeapOnlyPressureModel reacts to heap usage alone.
+func HeapOnlyPressureModel() *PressureModel {
+	return NewPressureModel(PressureModeMax, PressureWeights{Heap: 1})
+}
+
+// ApplyConfig replaces the mode and weights. Safe to call while running.
+func (m *PressureModel) ApplyConfig(cfg Config) {
+	mode, err := ParsePressureMode(cfg.PressureMode)
+	if err != nil {
+		mode = PressureModeMax
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mode = mode
+	m.weights = PressureWeights{
+		Heap:        cfg.PressureWeightHeap,
+		PSI:         cfg.PressureWeightPSI,
+		Queue:       cfg.PressureWeightQueue,
+		GC:          cfg.PressureWeightGC,
+		CPUThrottle: cfg.PressureWeightCPUThrottle,
+	}
+}
+
+// Mode returns the combining mode.
+func (m *PressureModel) Mode() PressureMode {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.mode
+}
+
+// Weights returns the signal weights.
+func (m *PressureModel) Weights() PressureWeights {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.weights
+}
+
+// Combine computes the pressure for signals.
+func (m *PressureModel) Combine(signals PressureSignals) PressureReading {
+	m.mu.RLock()
+	mode, weights := m.mode, m.weights
+	m.mu.RUnlock()
+
+	reading := PressureReading{Signals: signals, Mode: mode, Driver: PressureHeap}
+
+	var top, sum, total float64
+	var found bool
+	for _, v := range signals.weighted(weights) {
+		if v.weight <= 0 {
+			continue
+		}
+		contribution := v.weight * v.value
+		if !found || contribution > top {
+			found = true
+			top = contribution
+			reading.Driver = v.source
+		}
+		sum += contribution
+		total += v.weight
+	}
+
+	switch {
+	case total == 0:
+		reading.Pressure = 0
+	case mode == PressureModeWeighted:
+		reading.Pressure = sum / total
+	default:
+		reading.Pressure = top
+	}
+	reading.Pressure = max(0, min(reading.Pressure, 1))
+	return reading
+}
+
+// PressureSampler reads the raw signals for a PressureModel.
+//
+// GC and CPU throttling are rates: GC reports its share of the last full
+// CPU-second and throttling the share of periods since the previous call.
+// The first Sample reads 0 for both.
+type PressureSampler struct {
+	memoryLimit uint64
+	buses       []event.Bus // Queue fill is read from buses implementing event.StatsProvider
+
+	gcSamples    []metrics.Sample
+	lastGCCPU    float64
+	lastTotalCPU float64
+	gcShare      float64 // Last completed window
+
+	lastPeriods   uint64
+	lastThrottled uint64
+}
+
+// NewPressureSampler creates a sampler for heap usage against memoryLimit
+// and the subscription buffers of buses.
+func NewPressureSampler(memoryLimit uint64, buses ...event.Bus) *PressureSampler {
+	return &PressureSampler{
+		memoryLimit: memoryLimit,
+		buses:       buses,
+		gcSamples: []metrics.Sample{
+			{Name: "/cpu/classes/gc/total:cpu-seconds"},
+			{Name: "/cpu/classes/total:cpu-seconds"},
+		},
+	}
+}
+
+// Sample reads every signal. Not safe for concurrent use.
+func (s *PressureSampler) Sample() PressureSignals {
+	signals := PressureSignals{
+		Heap:  ReadMemoryStatsFast(s.memoryLimit).UsagePct,
+		Queue: s.queueFill(),
+		GC:    s.gcFraction(),
+	}
+	if psi, err := ReadPSIMemory(); err == nil {
+		signals.PSI = min(psi.Avg10/100, 1) // avg10 is a percentage
+	}
+	signals.CPUThrottle = s.throttledFraction()
+	return signals
+}
+
+// queueFill returns the fill level of the fullest subscription buffer.
+func (s *PressureSampler) queueFill() float64 {
+	var fill float64
+	for _, bus := range s.buses {
+		sp, ok := bus.(event.StatsProvider)
+		if !ok {
+			continue
+		}
+		for _, sub := range sp.Stats().Subscriptions {
+			fill = max(fill, sub.Fill())
+		}
+	}
+	return fill
+}
+
+// gcWindowCPU is the CPU time (across all Ps, idle included) each GC share
+// is measured over. The runtime accounts GC CPU in bursts at cycle
+// boundaries, so shorter windows swing between 0 and 1.
+const gcWindowCPU = 1.0 // cpu-seconds
+
+// gcFraction returns the share of CPU time spent in GC over the last
+// completed window.
+func (s *PressureSampler) gcFraction() float64 {
+	metrics.Read(s.gcSamples)
+	if s.gcSamples[0].Value.Kind() != metrics.KindFloat64 {
+		return 0
+	}
+	gcCPU, totalCPU := s.gcSamples[0].Value.Float64(), s.gcSamples[1].Value.Float64()
+	if s.lastTotalCPU == 0 {
+		s.lastGCCPU, s.lastTotalCPU = gcCPU, totalCPU
+		return 0
+	}
+
+	dGC, dTotal := gcCPU-s.lastGCCPU, totalCPU-s.lastTotalCPU
+	if dTotal < gcWindowCPU {
+		return s.gcShare
+	}
+	s.lastGCCPU, s.lastTotalCPU = gcCPU, totalCPU
+	s.gcShare = max(0, min(dGC/dTotal, 1))
+	return s.gcShare
+}
+
+// throttledFraction returns the share of cgroup CPU periods throttled since
+// the last call (cgroup v2 only).
+func (s *PressureSampler) throttledFraction() float64 {
+	periods, throttled, ok := readCgroupCPUStat()
+	if !ok {
+		return 0
+	}
+	lastPeriods, lastThrottled := s.lastPeriods, s.lastThrottled
+	s.lastPeriods, s.lastThrottled = periods, throttled
+
+	// First sample, no new periods, or counters reset
+	if lastPeriods == 0 || periods <= lastPeriods || throttled < lastThrottled {
+		return 0
+	}
+	return min(float64(throttled-lastThrottled)/float64(periods-lastPeriods), 1)
+}
+
+// readCgroupCPUStat reads nr_periods and nr_throttled from cgroup v2 cpu.stat.
+func readCgroupCPUStat() (periods, throttled uint64, ok bool) {
+	data, err := os.ReadFile("/sys/fs/cgroup/cpu.stat")
+	if err != nil {
+		return 0, 0, false
+	}
+	return parseCPUStat(string(data))
+}
+
+// parseCPUStat parses cgroup v2 cpu.stat lines such as "nr_periods 120".
+func parseCPUStat(data string) (periods, throttled uint64, ok bool) {
+	var havePeriods bool
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "nr_periods":
+			periods, havePeriods = v, true
+		case "nr_throttled":
+			throttled = v
+		}
+	}
+	// nr_periods stays 0 without a CPU quota
+	return periods, throttled, havePeriods && periods > 0
+}
@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+func TestPressureModel_Combine(t *testing.T) {
+	signals := PressureSignals{Heap: 0.4, PSI: 0.1, Queue: 0.9, GC: 0.2, CPUThrottle: 0}
+	all := PressureWeights{Heap: 1, PSI: 1, Queue: 1, GC: 1, CPUThrottle: 1}
+
+	tests := []struct {
+		name     string
+		mode     PressureMode
+		weights  PressureWeights
+		signals  PressureSignals
+		pressure float64
+		driver   PressureSource
+	}{
+		{"max picks fullest signal", PressureModeMax, all, signals, 0.9, PressureQueue},
+		{"weighted averages", PressureModeWeighted, all, signals, 0.32, PressureQueue},
+		{"zero weight ignores signal", PressureModeMax, PressureWeights{Heap: 1, GC: 1}, signals, 0.4, PressureHeap},
+		{"weights scale contributions", PressureModeMax, PressureWeights{Heap: 2, Queue: 0.5}, signals, 0.8, PressureHeap},
+		{"capped at 1", PressureModeMax, PressureWeights{Queue: 2}, signals, 1, PressureQueue},
+		{"tie goes to earlier signal", PressureModeMax, all, PressureSignals{Heap: 0.5, GC: 0.5}, 0.5, PressureHeap},
+		{"no weights", PressureModeMax, PressureWeights{}, signals, 0, PressureHeap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewPressureModel(tt.mode, tt.weights).Combine(tt.signals)
+			if !approx(r.Pressure, tt.pressure) || r.Driver != tt.driver {
+				t.Errorf("Expected %.2f driven by %s, got %s", tt.pressure, tt.driver, r)
+			}
+		})
+	}
+}
+
+func TestParseCPUStat(t *testing.T) {
+	periods, throttled, ok := parseCPUStat("usage_usec 1000\nnr_periods 120\nnr_throttled 30\nthrottled_usec 500\n")
+	if !ok || periods != 120 || throttled != 30 {
+		t.Errorf("Expected 120/30, got %d/%d ok=%t", periods, throttled, ok)
+	}
+
+	// No quota: cgroup reports usage only
+	if _, _, ok := parseCPUStat("usage_usec 1000\nuser_usec 600\n"); ok {
+		t.Error("Expected no throttling data without nr_periods")
+	}
+}
+
+func TestControlLab_ReportsPressureDriver(t *testing.T) {
+	bus := event.NewInMemoryBus(event.WithBufferSize(16))
+	defer bus.Close()
+	errorBus := event.NewErrorBus(16)
+	defer errorBus.Close()
+	sub, _ := errorBus.SubscribeFiltered(t.Context(), event.ErrorFilter{Codes: []string{event.CodeDegradedMode}})
+
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	lab := NewControlLab(clk, errorBus, bus, NewDefaultAIMDGovernor(clk, time.Second).AsController(), NewDefaultREDDropper(), 0, time.Second)
+	lab.SetPressureModel(NewPressureModelFromConfig(DefaultConfig()))
+
+	signals := PressureSignals{Heap: 0.1, Queue: 0.85}
+	lab.SetPressureSampler(func() PressureSignals { return signals })
+
+	if _, ok := lab.LastPressure(); ok {
+		t.Error("Expected no reading before the first poll")
+	}
+
+	lab.updateGovernor()
+	if lab.Governor().State() != StateDegraded {
+		t.Fatalf("Expected queue fill to degrade the governor, got %s", lab.Governor().State())
+	}
+	if r, _ := lab.LastPressure(); r.Driver != PressureQueue || !approx(r.Pressure, 0.85) {
+		t.Errorf("Expected queue-driven 85%% reading, got %s", r)
+	}
+
+	select {
+	case evt := <-sub.Events():
+		if evt.Context["driver"] != "queue" {
+			t.Errorf("Expected driver in state change context, got %v", evt.Context)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected degraded state change event")
+	}
+
+	// Heap-only model ignores the queue
+	lab.PressureModel().ApplyConfig(Config{PressureMode: "max", PressureWeightHeap: 1})
+	lab.updateGovernor()
+	if r, _ := lab.LastPressure(); r.Driver != PressureHeap || !approx(r.Pressure, 0.1) {
+		t.Errorf("Expected heap-driven 10%% reading, got %s", r)
+	}
+}
+
+func TestEngine_PressureConfig(t *testing.T) {
+	eng := newEnvEngine(t, map[string]string{
+		"PIPELINE_PRESSURE_MODE":  "weighted",
+		"PIPELINE_PRESSURE_W_PSI": "0",
+	})
+
+	model := eng.controlLab.PressureModel()
+	if model.Mode() != PressureModeWeighted || model.Weights().PSI != 0 || model.Weights().Queue != 1 {
+		t.Errorf("Expected weighted model without PSI, got %s %+v", model.Mode(), model.Weights())
+	}
+
+	next := eng.Config()
+	next.PressureMode = "max"
+	next.PressureWeightGC = 0.5
+	update, err := eng.UpdateConfig(next)
+	if err != nil || len(update.Applied()) != 2 {
+		t.Fatalf("Expected 2 live pressure changes, got %v (%v)", update.Changes, err)
+	}
+	if model.Mode() != PressureModeMax || model.Weights().GC != 0.5 {
+		t.Errorf("Expected reloaded model, got %s %+v", model.Mode(), model.Weights())
+	}
+
+	bad := DefaultConfig()
+	bad.PressureMode = "average"
+	bad.PressureWeightHeap = -1
+	if err := bad.Validate(); err == nil {
+		t.Error("Expected invalid pressure mode and weight to fail validation")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/BYTE-6D65/pipeline/pkg/event"
@@ -90,6 +91,7 @@ func parsePSILine(line string) (PSIMemory, error) {
 // PSIMonitor polls PSI and emits pre-OOM warnings to the error bus.
 type PSIMonitor struct {
 	// Config
+	mu            sync.Mutex    // Guards threshold and sustainWindow (see SetThreshold)
 	threshold     float64       // avg10 threshold (e.g., 0.2 = 20%)
 	sustainWindow time.Duration // How long above threshold before alert
 	pollInterval  time.Duration // Polling interval (e.g., 1s)
@@ -110,6 +112,22 @@ func NewPSIMonitor(threshold float64, sustainWindow time.Duration, pollInterval
 	}
 }
 
+// SetThreshold replaces the avg10 threshold and sustain window of a running
+// monitor. Takes effect on the next poll.
+func (pm *PSIMonitor) SetThreshold(threshold float64, sustainWindow time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.threshold = threshold
+	pm.sustainWindow = sustainWindow
+}
+
+// Threshold returns the avg10 threshold and sustain window.
+func (pm *PSIMonitor) Threshold() (float64, time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	return pm.threshold, pm.sustainWindow
+}
+
 // Start begins monitoring PSI in a background goroutine.
 // Stops when context is cancelled.
 func (pm *PSIMonitor) Start(ctx context.Context) {
@@ -126,13 +144,14 @@ func (pm *PSIMonitor) Start(ctx context.Context) {
 		return
 	}
 
+	threshold, sustainWindow := pm.Threshold()
 	pm.errorBus.Publish(event.NewErrorEvent(
 		event.InfoSeverity,
 		event.CodeHealthCheck,
 		"monitor:psi",
 		"PSI monitoring started",
-	).WithContext("threshold", pm.threshold).
-		WithContext("sustain_window", pm.sustainWindow.String()))
+	).WithContext("threshold", threshold).
+		WithContext("sustain_window", sustainWindow.String()))
 
 	ticker := time.NewTicker(pm.pollInterval)
 	defer ticker.Stop()
@@ -156,9 +175,10 @@ func (pm *PSIMonitor) checkPSI() {
 	}
 
 	now := time.Now()
+	threshold, sustainWindow := pm.Threshold()
 
 	// Check if avg10 exceeds threshold
-	if psi.Avg10 > pm.threshold {
+	if psi.Avg10 > threshold {
 		// Above threshold
 		if pm.aboveThresholdSince.IsZero() {
 			// First time above - record start time
@@ -167,7 +187,7 @@ func (pm *PSIMonitor) checkPSI() {
 
 		// Check if sustained for long enough
 		sustainedDuration := now.Sub(pm.aboveThresholdSince)
-		if sustainedDuration >= pm.sustainWindow {
+		if sustainedDuration >= sustainWindow {
 			// Sustained pressure - emit alert
 			// But don't spam - only alert once per minute
 			if now.Sub(pm.lastAlert) >= time.Minute {
@@ -0,0 +1,287 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+	"github.com/BYTE-6D65/pipeline/pkg/telemetry"
+)
+
+// ErrThrottled is returned by a rate-limited Publish in ThrottleReject mode.
+var ErrThrottled = errors.New("engine: publish throttled by rate limiter")
+
+// ThrottleMode selects what happens when a source exceeds its rate.
+type ThrottleMode int
+
+const (
+	// ThrottleBlock delays Publish until a token is available (back pressure).
+	ThrottleBlock ThrottleMode = iota
+
+	// ThrottleReject fails Publish immediately with ErrThrottled.
+	ThrottleReject
+)
+
+func (m ThrottleMode) String() string {
+	switch m {
+	case ThrottleBlock:
+		return "block"
+	case ThrottleReject:
+		return "reject"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", m)
+	}
+}
+
+// ParseThrottleMode parses "block" or "reject".
+func ParseThrottleMode(s string) (ThrottleMode, error) {
+	switch s {
+	case "block":
+		return ThrottleBlock, nil
+	case "reject":
+		return ThrottleReject, nil
+	default:
+		return ThrottleBlock, fmt.Errorf("unknown throttle mode %q (want block or reject)", s)
+	}
+}
+
+// RateLimit is the baseline limit for one source.
+type RateLimit struct {
+	Rate  float64      // Baseline events/sec at governor scale 1.0 (0 = unlimited)
+	Burst int          // Bucket capacity (max events admitted back-to-back)
+	Mode  ThrottleMode // Block or reject when empty
+}
+
+// minRateScale keeps the effective rate positive if a scale source reports 0.
+const minRateScale = 0.01
+
+// RateLimiter applies per-source token buckets whose refill rate is the
+// baseline rate multiplied by the current governor scale.
+//
+// This closes the degradation loop: when the AIMD governor halves its scale
+// under memory pressure, every adapter's admitted rate halves with it.
+//
+//	effective rate = baseline rate × governor.Scale()
+//
+// Sources without an explicit limit use the default limit. A zero rate means
+// unlimited. Time is read from the injected clock; only the blocking wait
+// itself uses real timers.
+type RateLimiter struct {
+	clock   clock.Clock
+	scale   func() float64     // Governor scale source (nil = always 1.0)
+	metrics *telemetry.Metrics // Optional
+
+	mu           sync.Mutex
+	defaultLimit RateLimit
+	limits       map[string]RateLimit
+	buckets      map[string]*tokenBucket
+}
+
+// tokenBucket holds the state for one source (protected by RateLimiter.mu).
+type tokenBucket struct {
+	tokens float64
+	last   clock.MonoTime
+}
+
+// NewRateLimiter creates a rate limiter.
+//
+// Parameters:
+//   - clk: Clock for refill timing (use engine's clock for consistency)
+//   - defaultLimit: Limit applied to sources without an explicit SetLimit
+//   - scale: Scale source, typically governor.Scale (nil = fixed 1.0)
+//   - metrics: Optional throttle metrics
+func NewRateLimiter(clk clock.Clock, defaultLimit RateLimit, scale func() float64, metrics *telemetry.Metrics) *RateLimiter {
+	return &RateLimiter{
+		clock:        clk,
+		scale:        scale,
+		metrics:      metrics,
+		defaultLimit: normalizeLimit(defaultLimit),
+		limits:       make(map[string]RateLimit),
+		buckets:      make(map[string]*tokenBucket),
+	}
+}
+
+// normalizeLimit ensures a usable burst for limited sources.
+func normalizeLimit(l RateLimit) RateLimit {
+	if l.Rate > 0 && l.Burst <= 0 {
+		l.Burst = 1
+	}
+	return l
+}
+
+// SetLimit sets the baseline limit for a source.
+func (rl *RateLimiter) SetLimit(source string, limit RateLimit) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.limits[source] = normalizeLimit(limit)
+	delete(rl.buckets, source) // Start fresh with a full bucket
+}
+
+// Limit returns the baseline limit for a source.
+func (rl *RateLimiter) Limit(source string) RateLimit {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.limitFor(source)
+}
+
+// limitFor returns the limit for a source (assumes lock is held).
+func (rl *RateLimiter) limitFor(source string) RateLimit {
+	if l, ok := rl.limits[source]; ok {
+		return l
+	}
+	return rl.defaultLimit
+}
+
+// EffectiveRate returns the current admitted rate for a source (events/sec).
+// Returns 0 for unlimited sources.
+func (rl *RateLimiter) EffectiveRate(source string) float64 {
+	rl.mu.Lock()
+	limit := rl.limitFor(source)
+	rl.mu.Unlock()
+	return limit.Rate * rl.currentScale()
+}
+
+// currentScale reads the scale source, clamped to a positive floor.
+func (rl *RateLimiter) currentScale() float64 {
+	if rl.scale == nil {
+		return 1.0
+	}
+	s := rl.scale()
+	if s < minRateScale {
+		s = minRateScale
+	}
+	return s
+}
+
+// reserve takes one token and returns how long the caller must wait for it.
+// With reject=true, no token is taken if one is not immediately available.
+func (rl *RateLimiter) reserve(source string, reject bool) (wait time.Duration, ok bool) {
+	scale := rl.currentScale()
+	now := rl.clock.Now()
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	limit := rl.limitFor(source)
+	if limit.Rate <= 0 {
+		return 0, true // Unlimited
+	}
+	rate := limit.Rate * scale
+
+	b, exists := rl.buckets[source]
+	if !exists {
+		b = &tokenBucket{tokens: float64(limit.Burst), last: now}
+		rl.buckets[source] = b
+	}
+
+	// Refill since last reservation
+	elapsed := clock.ToDuration(now - b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens += elapsed * rate
+		if b.tokens > float64(limit.Burst) {
+			b.tokens = float64(limit.Burst)
+		}
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	if reject {
+		return 0, false
+	}
+
+	// Go into debt; the caller sleeps until the debt is repaid
+	deficit := 1 - b.tokens
+	b.tokens--
+	return time.Duration(deficit / rate * float64(time.Second)), true
+}
+
+// refund returns a token taken by an abandoned blocking reservation.
+func (rl *RateLimiter) refund(source string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if b, ok := rl.buckets[source]; ok {
+		b.tokens++
+	}
+}
+
+// Allow reports whether one event from source may proceed right now,
+// consuming a token if so. Never blocks.
+func (rl *RateLimiter) Allow(source string) bool {
+	_, ok := rl.reserve(source, true)
+	if !ok {
+		rl.recordThrottle(source, "rejected", 0)
+	}
+	return ok
+}
+
+// Wait blocks until one event from source may proceed or ctx is done.
+func (rl *RateLimiter) Wait(ctx context.Context, source string) error {
+	wait, _ := rl.reserve(source, false)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		rl.recordThrottle(source, "delayed", wait)
+		return nil
+	case <-ctx.Done():
+		rl.refund(source)
+		return ctx.Err()
+	}
+}
+
+// Admit applies the source's throttle mode: Wait for ThrottleBlock, Allow
+// (returning ErrThrottled) for ThrottleReject.
+func (rl *RateLimiter) Admit(ctx context.Context, source string) error {
+	if rl.Limit(source).Mode == ThrottleReject {
+		if !rl.Allow(source) {
+			return ErrThrottled
+		}
+		return nil
+	}
+	return rl.Wait(ctx, source)
+}
+
+// recordThrottle updates throttle metrics.
+func (rl *RateLimiter) recordThrottle(source, result string, wait time.Duration) {
+	if rl.metrics == nil {
+		return
+	}
+	rl.metrics.PublishThrottled.WithLabelValues(source, result).Inc()
+	if wait > 0 {
+		rl.metrics.ThrottleWait.WithLabelValues(source).Observe(wait.Seconds())
+	}
+	rl.metrics.ThrottleRate.WithLabelValues(source).Set(rl.EffectiveRate(source))
+}
+
+// Wrap returns a bus whose Publish is rate limited under the given source key.
+// Subscribe and Close pass through to the underlying bus.
+func (rl *RateLimiter) Wrap(bus event.Bus, source string) event.Bus {
+	return &throttledBus{Bus: bus, limiter: rl, source: source}
+}
+
+// throttledBus enforces a rate limit on Publish.
+type throttledBus struct {
+	event.Bus
+	limiter *RateLimiter
+	source  string
+}
+
+// Publish waits for (or is refused) a token, then publishes.
+func (b *throttledBus) Publish(ctx context.Context, evt *event.Event) error {
+	if err := b.limiter.Admit(ctx, b.source); err != nil {
+		return err
+	}
+	return b.Bus.Publish(ctx, evt)
+}
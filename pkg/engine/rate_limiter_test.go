@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+func TestRateLimiter_BurstThenRefill(t *testing.T) {
+	clk := newTestClock()
+	rl := NewRateLimiter(clk, RateLimit{Rate: 10, Burst: 2, Mode: ThrottleReject}, nil, nil)
+
+	if !rl.Allow("src") || !rl.Allow("src") {
+		t.Fatal("Burst of 2 should be admitted")
+	}
+	if rl.Allow("src") {
+		t.Error("Third event should be rejected with empty bucket")
+	}
+
+	clk.Advance(100 * time.Millisecond) // 10/s → one token
+	if !rl.Allow("src") {
+		t.Error("Expected one token after 100ms refill")
+	}
+}
+
+func TestRateLimiter_ScaledByGovernor(t *testing.T) {
+	clk := newTestClock()
+	scale := 1.0
+	rl := NewRateLimiter(clk, RateLimit{Rate: 10, Burst: 1, Mode: ThrottleReject}, func() float64 { return scale }, nil)
+
+	rl.Allow("src") // Drain the bucket
+	scale = 0.5     // Governor halves throughput
+
+	if got := rl.EffectiveRate("src"); got != 5 {
+		t.Errorf("Expected effective rate 5/s, got %.2f", got)
+	}
+
+	clk.Advance(100 * time.Millisecond) // Only half a token at 5/s
+	if rl.Allow("src") {
+		t.Error("Half a token should not admit an event")
+	}
+
+	clk.Advance(100 * time.Millisecond)
+	if !rl.Allow("src") {
+		t.Error("Expected a full token after 200ms at 5/s")
+	}
+}
+
+func TestRateLimiter_PerSourceLimits(t *testing.T) {
+	clk := newTestClock()
+	rl := NewRateLimiter(clk, RateLimit{}, nil, nil) // Default unlimited
+	rl.SetLimit("slow", RateLimit{Rate: 1, Burst: 1, Mode: ThrottleReject})
+
+	for i := 0; i < 100; i++ {
+		if !rl.Allow("fast") {
+			t.Fatal("Unlimited source should never be throttled")
+		}
+	}
+
+	rl.Allow("slow")
+	if rl.Allow("slow") {
+		t.Error("Limited source should be throttled")
+	}
+}
+
+func TestRateLimiter_WrapRejectsAndBlocks(t *testing.T) {
+	clk := newTestClock()
+	bus := event.NewInMemoryBus(event.WithBufferSize(16))
+	defer bus.Close()
+
+	rl := NewRateLimiter(clk, RateLimit{Rate: 1, Burst: 1, Mode: ThrottleReject}, nil, nil)
+	throttled := rl.Wrap(bus, "adapter-1")
+
+	ctx := context.Background()
+	if err := throttled.Publish(ctx, &event.Event{ID: "1"}); err != nil {
+		t.Fatalf("First publish should pass: %v", err)
+	}
+	if err := throttled.Publish(ctx, &event.Event{ID: "2"}); !errors.Is(err, ErrThrottled) {
+		t.Errorf("Expected ErrThrottled, got %v", err)
+	}
+
+	// Block mode waits for the token (real time, 1000/s → ~1ms)
+	rl.SetLimit("adapter-1", RateLimit{Rate: 1000, Burst: 1, Mode: ThrottleBlock})
+	throttled.Publish(ctx, &event.Event{ID: "3"})
+
+	start := time.Now()
+	if err := throttled.Publish(ctx, &event.Event{ID: "4"}); err != nil {
+		t.Fatalf("Blocking publish should succeed: %v", err)
+	}
+	if time.Since(start) < 500*time.Microsecond {
+		t.Error("Blocking publish should have waited for a token")
+	}
+
+	// A cancelled context aborts the wait
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	rl.SetLimit("adapter-1", RateLimit{Rate: 0.001, Burst: 1, Mode: ThrottleBlock})
+	throttled.Publish(ctx, &event.Event{ID: "5"})
+	if err := throttled.Publish(cancelled, &event.Event{ID: "6"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
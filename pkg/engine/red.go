@@ -2,6 +2,7 @@ package engine
 
 import (
 	"math/rand"
+	"sync"
 )
 
 // REDDropper implements Random Early Detection for graceful degradation.
@@ -21,6 +22,8 @@ type REDDropper struct {
 	maxThreshold float64 // Max fill level for drop probability curve (e.g., 1.0 = 100%)
 	maxDropProb  float64 // Maximum drop probability (e.g., 0.3 = 30%)
 
+	mu sync.RWMutex // Guards thresholds (replaced by ApplyConfig)
+
 	rng *rand.Rand // Random number generator
 }
 
@@ -62,6 +65,21 @@ func NewDefaultREDDropper() *REDDropper {
 	return NewREDDropper(0.6, 1.0, 0.3)
 }
 
+// NewREDDropperFromConfig creates a RED dropper that starts dropping at
+// cfg.REDMinFill and reaches cfg.REDMaxDropProb at 100% full.
+func NewREDDropperFromConfig(cfg Config) *REDDropper {
+	return NewREDDropper(cfg.REDMinFill, 1.0, cfg.REDMaxDropProb)
+}
+
+// ApplyConfig replaces the min fill and max drop probability of a running
+// dropper with cfg.REDMinFill and cfg.REDMaxDropProb.
+func (rd *REDDropper) ApplyConfig(cfg Config) {
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+	rd.minThreshold = cfg.REDMinFill
+	rd.maxDropProb = cfg.REDMaxDropProb
+}
+
 // ShouldDrop returns true if an event should be dropped based on current fill level.
 //
 // This is a probabilistic decision:
@@ -110,6 +128,9 @@ func (rd *REDDropper) ShouldDrop(fill float64) bool {
 //	DropProbability(1.0)  = 0.3    (at max)
 //	DropProbability(1.1)  = 0.3    (clamped to max)
 func (rd *REDDropper) DropProbability(fill float64) float64 {
+	rd.mu.RLock()
+	defer rd.mu.RUnlock()
+
 	// Below minimum threshold - never drop
 	if fill <= rd.minThreshold {
 		return 0.0
@@ -129,15 +150,21 @@ func (rd *REDDropper) DropProbability(fill float64) float64 {
 
 // MinThreshold returns the configured minimum threshold.
 func (rd *REDDropper) MinThreshold() float64 {
+	rd.mu.RLock()
+	defer rd.mu.RUnlock()
 	return rd.minThreshold
 }
 
 // MaxThreshold returns the configured maximum threshold.
 func (rd *REDDropper) MaxThreshold() float64 {
+	rd.mu.RLock()
+	defer rd.mu.RUnlock()
 	return rd.maxThreshold
 }
 
 // MaxDropProb returns the configured maximum drop probability.
 func (rd *REDDropper) MaxDropProb() float64 {
+	rd.mu.RLock()
+	defer rd.mu.RUnlock()
 	return rd.maxDropProb
 }
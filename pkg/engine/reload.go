@@ -0,0 +1,222 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+// liveConfigFields are the Config fields UpdateConfig applies to a running
+// engine. Changes to any other field are recorded as needing a restart.
+var liveConfigFields = map[string]bool{
+	// Governor (also read by the memory monitor and control lab each tick)
+	"MemoryEnterThreshold": true,
+	"MemoryExitThreshold":  true,
+	"MemoryCriticalPct":    true,
+	"GovernorMinScale":     true,
+	"ControlCooldown":      true,
+	"AIMDIncrStep":         true,
+	"AIMDDecrFactor":       true,
+	"AIMDMaxPerTick":       true,
+
+	// PID controller gains
+	"PIDKp":               true,
+	"PIDKi":               true,
+	"PIDKd":               true,
+	"PIDDerivativeFilter": true,
+
+	// Control lab action budget and pressure model
+	"MaxActionsPerLoop":         true,
+	"PressureMode":              true,
+	"PressureWeightHeap":        true,
+	"PressureWeightPSI":         true,
+	"PressureWeightQueue":       true,
+	"PressureWeightGC":          true,
+	"PressureWeightCPUThrottle": true,
+
+	// RED dropper
+	"REDMinFill":     true,
+	"REDMaxDropProb": true,
+
+	// PSI monitor
+	"PSIThreshold":     true,
+	"PSISustainWindow": true,
+
+	// Flight recorder
+	"FlightRecorderSize": true,
+
+	// Bus buffers (new subscriptions)
+	"QueueSizeStart": true,
+}
+
+// ConfigChange describes one Config field changed by UpdateConfig.
+type ConfigChange struct {
+	Field string
+	Old   any
+	New   any
+	Live  bool // Applied to the running engine; false = needs a restart
+}
+
+// String formats the change as "Field: old -> new".
+func (c ConfigChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Field, c.Old, c.New)
+}
+
+// ConfigUpdate is the result of UpdateConfig.
+type ConfigUpdate struct {
+	Changes []ConfigChange // In Config field order
+}
+
+// Applied returns the changes applied to the running engine.
+func (u ConfigUpdate) Applied() []ConfigChange {
+	return u.filter(true)
+}
+
+// RestartRequired returns the changes that take effect only after a restart.
+func (u ConfigUpdate) RestartRequired() []ConfigChange {
+	return u.filter(false)
+}
+
+func (u ConfigUpdate) filter(live bool) []ConfigChange {
+	var out []ConfigChange
+	for _, c := range u.Changes {
+		if c.Live == live {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// diffConfig lists the fields that differ between old and cfg.
+func diffConfig(old, cfg Config) []ConfigChange {
+	var changes []ConfigChange
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(cfg)
+	for i := 0; i < ov.NumField(); i++ {
+		a, b := ov.Field(i).Interface(), nv.Field(i).Interface()
+		if a == b {
+			continue
+		}
+		name := ov.Type().Field(i).Name
+		changes = append(changes, ConfigChange{Field: name, Old: a, New: b, Live: liveConfigFields[name]})
+	}
+	return changes
+}
+
+// UpdateConfig validates cfg, and the running config with cfg's live fields
+// merged in, then applies it to the running engine without dropping
+// in-flight events:
+//   - Governor thresholds, AIMD tuning, PID gains, scale floor and cooldown
+//     (controllers with an ApplyConfig(Config) method)
+//   - RED min fill and max drop probability
+//   - PSI threshold and sustain window
+//   - Flight recorder size (most recent snapshots are kept)
+//   - Bus buffer size (QueueSizeStart, for subscriptions created afterwards)
+//   - Control lab action budget and pressure model
+//
+// Other changed fields are not applied and are listed by
+// ConfigUpdate.RestartRequired; Config() keeps returning their running
+// values. Changes are diffed against the previously requested config, so a
+// pending restart-only change is reported once, not on every update. The
+// change is reported on the error bus as a CodeHealthCheck event.
+//
+// Only engines created with NewWithConfig can be updated.
+func (e *Engine) UpdateConfig(cfg Config) (ConfigUpdate, error) {
+	if e.errorBus == nil {
+		return ConfigUpdate{}, fmt.Errorf("engine: UpdateConfig requires an engine created with NewWithConfig")
+	}
+	if err := cfg.Validate(); err != nil {
+		return ConfigUpdate{}, fmt.Errorf("invalid config: %w", err)
+	}
+
+	e.configMu.Lock()
+	// Live fields match in both; restart-only ones differ only by what is pending
+	update := ConfigUpdate{Changes: diffConfig(e.requested, cfg)}
+
+	// Running config takes the live fields only
+	merged := e.config
+	mv, nv := reflect.ValueOf(&merged).Elem(), reflect.ValueOf(cfg)
+	for _, c := range update.Applied() {
+		mv.FieldByName(c.Field).Set(nv.FieldByName(c.Field))
+	}
+
+	// The live fields must also be valid against the running restart-only ones
+	if err := merged.Validate(); err != nil {
+		e.configMu.Unlock()
+		return ConfigUpdate{}, fmt.Errorf("invalid config with running restart-only values: %w", err)
+	}
+	if len(update.Applied()) > 0 {
+		e.applyConfig(merged)
+	}
+	e.config = merged
+	e.requested = cfg
+	e.configMu.Unlock()
+
+	if len(update.Changes) > 0 {
+		e.publishConfigUpdate(update)
+	}
+	return update, nil
+}
+
+// applyConfig pushes the live fields of cfg to the running components.
+// Caller holds e.configMu.
+func (e *Engine) applyConfig(cfg Config) {
+	if c, ok := e.controller.(interface{ ApplyConfig(Config) }); ok {
+		c.ApplyConfig(cfg)
+	}
+	if e.redDropper != nil {
+		e.redDropper.ApplyConfig(cfg)
+	}
+	if e.psiMonitor != nil {
+		e.psiMonitor.SetThreshold(cfg.PSIThreshold, cfg.PSISustainWindow)
+	}
+	if e.flightRecorder != nil {
+		e.flightRecorder.Resize(cfg.FlightRecorderSize)
+	}
+	if e.controlLab != nil {
+		e.controlLab.SetForceGC(cfg.MemoryCriticalPct, cfg.GCCooldown)
+		e.controlLab.PressureModel().ApplyConfig(cfg)
+		if cfg.MaxActionsPerLoop != e.config.MaxActionsPerLoop {
+			e.controlLab.SetActionBudget(cfg.MaxActionsPerLoop, cfg.ControlLoopInterval)
+		}
+	}
+	for _, bus := range []event.Bus{e.internalBus, e.externalBus} {
+		if b, ok := bus.(interface{ SetBufferSize(int) }); ok {
+			b.SetBufferSize(cfg.QueueSizeStart)
+		}
+	}
+}
+
+// publishConfigUpdate reports a config update on the error bus.
+func (e *Engine) publishConfigUpdate(update ConfigUpdate) {
+	applied, restart := update.Applied(), update.RestartRequired()
+
+	severity := event.InfoSeverity
+	if len(restart) > 0 {
+		severity = event.WarningSeverity
+	}
+
+	evt := event.NewErrorEvent(
+		severity,
+		event.CodeHealthCheck,
+		"engine:config",
+		fmt.Sprintf("Configuration updated: %d applied, %d require restart", len(applied), len(restart)),
+	)
+	if len(applied) > 0 {
+		evt = evt.WithContext("applied", joinChanges(applied))
+	}
+	if len(restart) > 0 {
+		evt = evt.WithContext("restart_required", joinChanges(restart))
+	}
+	e.errorBus.Publish(evt)
+}
+
+// joinChanges formats changes as "A: 1 -> 2, B: x -> y".
+func joinChanges(changes []ConfigChange) string {
+	parts := make([]string, len(changes))
+	for i, c := range changes {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, ", ")
+}
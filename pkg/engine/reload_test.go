@@ -0,0 +1,178 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+func TestEngine_UpdateConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.GovernorPollInterval = time.Hour // Only the test drives the governor
+	eng, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer eng.Shutdown(context.Background())
+
+	sub, _ := eng.ErrorBus().SubscribeFiltered(t.Context(), event.ErrorFilter{Components: []string{"engine:config"}})
+
+	next := cfg
+	next.MemoryEnterThreshold = 0.60
+	next.REDMinFill = 0.8
+	next.PSIThreshold = 0.4
+	next.FlightRecorderSize = 10
+	next.QueueSizeStart = 64
+	next.SchedulerTickInterval = time.Second // Needs a restart
+
+	update, err := eng.UpdateConfig(next)
+	if err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+	if n := len(update.Applied()); n != 5 {
+		t.Errorf("Expected 5 applied changes, got %v", update.Applied())
+	}
+	if restart := update.RestartRequired(); len(restart) != 1 || restart[0].Field != "SchedulerTickInterval" {
+		t.Errorf("Expected SchedulerTickInterval to need a restart, got %v", restart)
+	}
+
+	// Applied to running components
+	eng.Governor().Update(0.65)
+	if eng.Governor().State() != StateDegraded {
+		t.Errorf("Expected governor to use the new enter threshold, got %s", eng.Governor().State())
+	}
+	if eng.RED().MinThreshold() != 0.8 {
+		t.Errorf("Expected RED min fill 0.8, got %.2f", eng.RED().MinThreshold())
+	}
+	if threshold, _ := eng.PSIMonitor().Threshold(); threshold != 0.4 {
+		t.Errorf("Expected PSI threshold 0.4, got %.2f", threshold)
+	}
+	if eng.flightRecorder.Size() != 10 {
+		t.Errorf("Expected flight recorder size 10, got %d", eng.flightRecorder.Size())
+	}
+	sub2, _ := eng.ExternalBus().Subscribe(t.Context(), event.Filter{})
+	if c := cap(sub2.Events()); c != 64 {
+		t.Errorf("Expected new subscriptions to get 64 buffers, got %d", c)
+	}
+
+	// Config reports running values
+	if got := eng.Config(); got.MemoryEnterThreshold != 0.60 || got.SchedulerTickInterval != cfg.SchedulerTickInterval {
+		t.Errorf("Expected live fields only in running config, got enter=%.2f tick=%s",
+			got.MemoryEnterThreshold, got.SchedulerTickInterval)
+	}
+
+	select {
+	case evt := <-sub.Events():
+		if evt.Code != event.CodeHealthCheck || evt.Severity != event.WarningSeverity {
+			t.Errorf("Unexpected update event: %s %s", evt.Severity, evt.Code)
+		}
+		if applied, _ := evt.Context["applied"].(string); !strings.Contains(applied, "MemoryEnterThreshold: 0.7 -> 0.6") {
+			t.Errorf("Expected applied changes in context, got %q", applied)
+		}
+		if restart, _ := evt.Context["restart_required"].(string); !strings.Contains(restart, "SchedulerTickInterval") {
+			t.Errorf("Expected restart fields in context, got %q", restart)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected config update event")
+	}
+
+	// Same config again: the pending restart field was already reported
+	update, _ = eng.UpdateConfig(next)
+	if len(update.Changes) != 0 {
+		t.Errorf("Expected no changes for a repeated config, got %v", update.Changes)
+	}
+	select {
+	case evt := <-sub.Events():
+		t.Errorf("Expected no update event for a repeated config, got %q", evt.Message)
+	default:
+	}
+
+	// Reverting the pending field is a new change
+	update, _ = eng.UpdateConfig(cfg)
+	if restart := update.RestartRequired(); len(restart) != 1 || restart[0].Old != time.Second {
+		t.Errorf("Expected the reverted restart field reported against the request, got %v", restart)
+	}
+}
+
+func TestEngine_UpdateConfigRejectsInvalid(t *testing.T) {
+	eng, err := NewWithConfig(DefaultConfig())
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer eng.Shutdown(context.Background())
+
+	bad := DefaultConfig()
+	bad.MemoryEnterThreshold = 0.50 // Below exit threshold
+	if _, err := eng.UpdateConfig(bad); err == nil {
+		t.Error("Expected invalid config to be rejected")
+	}
+	if eng.Governor().EnterThreshold() != 0.70 {
+		t.Error("Expected rejected config to leave the governor unchanged")
+	}
+
+	// Valid on its own, but the live start exceeds the running (restart-only) max
+	bad = DefaultConfig()
+	bad.QueueSizeStart = 2000
+	bad.QueueSizeMax = 4096
+	if _, err := eng.UpdateConfig(bad); err == nil {
+		t.Error("Expected live fields invalid against the running config to be rejected")
+	}
+	if eng.Config().QueueSizeStart != 128 {
+		t.Errorf("Expected running queue start 128, got %d", eng.Config().QueueSizeStart)
+	}
+
+	if _, err := New().UpdateConfig(DefaultConfig()); err == nil {
+		t.Error("Expected UpdateConfig to fail on an engine from New()")
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipeline.json")
+	data := `{"PIPELINE_MEM_ENTER_PCT": 0.65, "PIPELINE_CONTROL_COOLDOWN": "10s", "PIPELINE_MAX_ACTIONS": 3}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PIPELINE_MAX_ACTIONS", "5") // Env wins over the file
+
+	cfg, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile failed: %v", err)
+	}
+	if cfg.MemoryEnterThreshold != 0.65 || cfg.ControlCooldown != 10*time.Second || cfg.MaxActionsPerLoop != 5 {
+		t.Errorf("Unexpected config: enter=%.2f cooldown=%s actions=%d",
+			cfg.MemoryEnterThreshold, cfg.ControlCooldown, cfg.MaxActionsPerLoop)
+	}
+
+	if _, err := LoadConfigFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
+
+func TestFlightRecorder_Resize(t *testing.T) {
+	fr := NewFlightRecorder(4)
+	base := time.Now()
+	for i := 0; i < 6; i++ {
+		fr.Record(Snapshot{Timestamp: base.Add(time.Duration(i) * time.Second), NumGoroutine: i})
+	}
+
+	fr.Resize(2)
+	fr.Record(Snapshot{Timestamp: base.Add(time.Minute), NumGoroutine: 6})
+	if fr.Size() != 2 {
+		t.Fatalf("Expected size 2, got %d", fr.Size())
+	}
+
+	// Newest two survive, oldest overwritten first
+	if got := fr.snapshots[fr.index].NumGoroutine; got != 5 {
+		t.Errorf("Expected oldest kept snapshot 5, got %d", got)
+	}
+
+	fr.Resize(8)
+	if fr.Size() != 8 || fr.index != 2 {
+		t.Errorf("Expected growth to keep 2 snapshots, size=%d index=%d", fr.Size(), fr.index)
+	}
+}
@@ -0,0 +1,273 @@
+package engine
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+	"github.com/BYTE-6D65/pipeline/pkg/telemetry"
+)
+
+// Scheduler publishes events at a future point in engine time.
+//
+// Components call PublishAfter / PublishAt / PublishEvery instead of managing
+// their own timers. All due times are expressed in the engine clock, and the
+// scheduler only fires events when polled, so tests can drive it
+// deterministically:
+//
+//	clk := clock.NewDeltaClock()
+//	sched := NewScheduler(clk, bus, nil, nil)
+//	sched.PublishAfter(evt, 30*time.Second)
+//	clk.Advance()            // move engine time forward
+//	sched.RunDue(ctx)        // fire everything that is now due
+//
+// In production, Start polls RunDue on a ticker.
+type Scheduler struct {
+	clock    clock.Clock
+	bus      event.Bus
+	errorBus *event.ErrorBus    // Optional: report publish failures
+	metrics  *telemetry.Metrics // Optional: pending gauge
+
+	mu     sync.Mutex
+	queue  scheduleQueue
+	items  map[uint64]*scheduledItem // Live items by ID (for Cancel)
+	nextID uint64
+}
+
+// ScheduleHandle identifies a scheduled publish and allows cancelling it.
+type ScheduleHandle struct {
+	id        uint64
+	scheduler *Scheduler
+}
+
+// scheduledItem is a single pending publish.
+type scheduledItem struct {
+	id       uint64
+	due      clock.MonoTime
+	interval time.Duration       // > 0 for recurring schedules
+	evt      *event.Event        // One-shot payload
+	factory  func() *event.Event // Recurring payload (fresh event each firing)
+	index    int                 // Heap index
+}
+
+// NewScheduler creates a scheduler that publishes to bus using clk for due times.
+// errorBus and metrics are optional.
+func NewScheduler(clk clock.Clock, bus event.Bus, errorBus *event.ErrorBus, metrics *telemetry.Metrics) *Scheduler {
+	return &Scheduler{
+		clock:    clk,
+		bus:      bus,
+		errorBus: errorBus,
+		metrics:  metrics,
+		items:    make(map[uint64]*scheduledItem),
+	}
+}
+
+// PublishAfter schedules evt to be published once delay has elapsed.
+func (s *Scheduler) PublishAfter(evt *event.Event, delay time.Duration) *ScheduleHandle {
+	return s.PublishAt(evt, s.clock.Now()+clock.FromDuration(delay))
+}
+
+// PublishAt schedules evt to be published at engine time at.
+// A time in the past fires on the next poll.
+func (s *Scheduler) PublishAt(evt *event.Event, at clock.MonoTime) *ScheduleHandle {
+	return s.schedule(&scheduledItem{due: at, evt: evt})
+}
+
+// PublishEvery publishes the event returned by fn every interval, starting one
+// interval from now. fn is called at each firing so every occurrence gets its
+// own ID and timestamp. Returns nil if interval is not positive.
+func (s *Scheduler) PublishEvery(interval time.Duration, fn func() *event.Event) *ScheduleHandle {
+	if interval <= 0 || fn == nil {
+		return nil
+	}
+	return s.schedule(&scheduledItem{
+		due:      s.clock.Now() + clock.FromDuration(interval),
+		interval: interval,
+		factory:  fn,
+	})
+}
+
+// schedule adds an item to the queue and returns its handle.
+func (s *Scheduler) schedule(item *scheduledItem) *ScheduleHandle {
+	s.mu.Lock()
+	s.nextID++
+	item.id = s.nextID
+	heap.Push(&s.queue, item)
+	s.items[item.id] = item
+	pending := len(s.items)
+	s.mu.Unlock()
+
+	s.reportPending(pending)
+	return &ScheduleHandle{id: item.id, scheduler: s}
+}
+
+// Cancel removes the scheduled publish. Returns false if it already fired
+// (one-shot) or was already cancelled.
+func (h *ScheduleHandle) Cancel() bool {
+	if h == nil {
+		return false
+	}
+	return h.scheduler.cancel(h.id)
+}
+
+// ID returns the schedule identifier.
+func (h *ScheduleHandle) ID() uint64 {
+	return h.id
+}
+
+// cancel removes an item by ID.
+func (s *Scheduler) cancel(id uint64) bool {
+	s.mu.Lock()
+	item, ok := s.items[id]
+	if ok {
+		heap.Remove(&s.queue, item.index)
+		delete(s.items, id)
+	}
+	pending := len(s.items)
+	s.mu.Unlock()
+
+	if ok {
+		s.reportPending(pending)
+	}
+	return ok
+}
+
+// RunDue publishes every item whose due time has been reached and returns the
+// number of events published. Recurring items are rescheduled; if the clock
+// jumped several intervals ahead they fire once and resume from now.
+func (s *Scheduler) RunDue(ctx context.Context) int {
+	now := s.clock.Now()
+
+	// Collect due items under the lock; build and publish their events
+	// outside it, since a factory may call back into the scheduler
+	var due []*scheduledItem
+	s.mu.Lock()
+	for s.queue.Len() > 0 && s.queue[0].due <= now {
+		item := s.queue[0]
+		due = append(due, item)
+		if item.interval > 0 {
+			next := item.due + clock.FromDuration(item.interval)
+			if next <= now {
+				next = now + clock.FromDuration(item.interval)
+			}
+			item.due = next
+			heap.Fix(&s.queue, item.index)
+			continue
+		}
+
+		heap.Pop(&s.queue)
+		delete(s.items, item.id)
+	}
+	pending := len(s.items)
+	s.mu.Unlock()
+
+	published := 0
+	for _, item := range due {
+		evt := item.evt
+		if item.interval > 0 {
+			evt = item.factory()
+		}
+		if evt == nil {
+			continue
+		}
+		if err := s.bus.Publish(ctx, evt); err != nil {
+			s.reportFailure(evt, err)
+			continue
+		}
+		published++
+	}
+
+	s.reportPending(pending)
+	return published
+}
+
+// Start polls RunDue every interval until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunDue(ctx)
+		}
+	}
+}
+
+// Pending returns the number of scheduled items (recurring items count once).
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// NextDue returns the due time of the earliest pending item.
+func (s *Scheduler) NextDue() (clock.MonoTime, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.queue.Len() == 0 {
+		return 0, false
+	}
+	return s.queue[0].due, true
+}
+
+// reportPending updates the pending gauge.
+func (s *Scheduler) reportPending(pending int) {
+	if s.metrics != nil {
+		s.metrics.ScheduledPending.WithLabelValues("scheduler").Set(float64(pending))
+	}
+}
+
+// reportFailure emits a warning when a scheduled publish fails.
+func (s *Scheduler) reportFailure(evt *event.Event, err error) {
+	if s.errorBus == nil {
+		return
+	}
+	s.errorBus.Publish(event.NewErrorEvent(
+		event.WarningSeverity,
+		event.CodeScheduleFail,
+		"scheduler",
+		fmt.Sprintf("Scheduled publish failed: %v", err),
+	).WithContext("event_type", evt.Type).
+		WithContext("event_id", evt.ID))
+}
+
+// scheduleQueue is a min-heap of scheduled items ordered by due time.
+type scheduleQueue []*scheduledItem
+
+func (q scheduleQueue) Len() int { return len(q) }
+
+func (q scheduleQueue) Less(i, j int) bool {
+	if q[i].due == q[j].due {
+		return q[i].id < q[j].id // FIFO for equal due times
+	}
+	return q[i].due < q[j].due
+}
+
+func (q scheduleQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *scheduleQueue) Push(x any) {
+	item := x.(*scheduledItem)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *scheduleQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*q = old[:n-1]
+	return item
+}
@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+// newSchedulerTest creates a scheduler driven by a no-sleep DeltaClock.
+func newSchedulerTest(t *testing.T, deltas ...time.Duration) (*Scheduler, *clock.DeltaClock, event.Subscription) {
+	t.Helper()
+
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	clk.Load(0, deltas)
+
+	bus := event.NewInMemoryBus(event.WithBufferSize(16))
+	t.Cleanup(func() { bus.Close() })
+
+	sub, err := bus.Subscribe(context.Background(), event.Filter{})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	return NewScheduler(clk, bus, nil, nil), clk, sub
+}
+
+func TestScheduler_PublishAfter(t *testing.T) {
+	sched, clk, sub := newSchedulerTest(t, 10*time.Second, 20*time.Second)
+	ctx := context.Background()
+
+	sched.PublishAfter(&event.Event{ID: "delayed", Type: "test.delayed"}, 30*time.Second)
+
+	if n := sched.RunDue(ctx); n != 0 {
+		t.Errorf("Expected nothing due at t=0, published %d", n)
+	}
+
+	clk.Advance() // t=10s
+	if n := sched.RunDue(ctx); n != 0 {
+		t.Errorf("Expected nothing due at t=10s, published %d", n)
+	}
+	if sched.Pending() != 1 {
+		t.Errorf("Expected 1 pending, got %d", sched.Pending())
+	}
+
+	clk.Advance() // t=30s
+	if n := sched.RunDue(ctx); n != 1 {
+		t.Errorf("Expected 1 published at t=30s, got %d", n)
+	}
+	if sched.Pending() != 0 {
+		t.Errorf("Expected 0 pending, got %d", sched.Pending())
+	}
+
+	evt := <-sub.Events()
+	if evt.ID != "delayed" {
+		t.Errorf("Expected delayed event, got %s", evt.ID)
+	}
+}
+
+func TestScheduler_PublishAtOrdering(t *testing.T) {
+	sched, clk, sub := newSchedulerTest(t, time.Minute)
+	ctx := context.Background()
+
+	sched.PublishAt(&event.Event{ID: "second"}, clock.FromDuration(20*time.Second))
+	sched.PublishAt(&event.Event{ID: "first"}, clock.FromDuration(10*time.Second))
+
+	clk.Advance()
+	if n := sched.RunDue(ctx); n != 2 {
+		t.Fatalf("Expected 2 published, got %d", n)
+	}
+
+	if evt := <-sub.Events(); evt.ID != "first" {
+		t.Errorf("Expected 'first' to fire first, got %s", evt.ID)
+	}
+	if evt := <-sub.Events(); evt.ID != "second" {
+		t.Errorf("Expected 'second' to fire second, got %s", evt.ID)
+	}
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	sched, clk, _ := newSchedulerTest(t, time.Minute)
+
+	h := sched.PublishAfter(&event.Event{ID: "cancelled"}, time.Second)
+	if !h.Cancel() {
+		t.Error("Cancel should succeed for pending item")
+	}
+	if h.Cancel() {
+		t.Error("Second Cancel should report false")
+	}
+
+	clk.Advance()
+	if n := sched.RunDue(context.Background()); n != 0 {
+		t.Errorf("Cancelled item should not publish, got %d", n)
+	}
+}
+
+func TestScheduler_PublishEvery(t *testing.T) {
+	sched, clk, sub := newSchedulerTest(t, 5*time.Second, 5*time.Second, 5*time.Second)
+	ctx := context.Background()
+
+	count := 0
+	h := sched.PublishEvery(5*time.Second, func() *event.Event {
+		count++
+		return &event.Event{Type: "test.tick"}
+	})
+
+	for i := 0; i < 2; i++ {
+		clk.Advance()
+		sched.RunDue(ctx)
+	}
+
+	if count != 2 || len(sub.Events()) != 2 {
+		t.Errorf("Expected 2 recurring firings, got count=%d delivered=%d", count, len(sub.Events()))
+	}
+	if sched.Pending() != 1 {
+		t.Errorf("Recurring schedule should stay pending, got %d", sched.Pending())
+	}
+
+	h.Cancel()
+	clk.Advance()
+	sched.RunDue(ctx)
+	if count != 2 {
+		t.Errorf("Cancelled recurring schedule fired again (count=%d)", count)
+	}
+}
+
+func TestScheduler_FactoryReentry(t *testing.T) {
+	sched, clk, sub := newSchedulerTest(t, 5*time.Second)
+
+	// The factory schedules a follow-up and cancels its own schedule
+	var h *ScheduleHandle
+	h = sched.PublishEvery(5*time.Second, func() *event.Event {
+		sched.PublishAfter(&event.Event{Type: "test.followup"}, time.Minute)
+		h.Cancel()
+		return &event.Event{Type: "test.tick"}
+	})
+
+	clk.Advance()
+	done := make(chan int)
+	go func() { done <- sched.RunDue(context.Background()) }()
+	select {
+	case n := <-done:
+		if n != 1 || len(sub.Events()) != 1 {
+			t.Errorf("Expected 1 published event, got %d (delivered %d)", n, len(sub.Events()))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("RunDue deadlocked on a factory calling back into the scheduler")
+	}
+	if sched.Pending() != 1 {
+		t.Errorf("Expected only the follow-up pending, got %d", sched.Pending())
+	}
+}
@@ -0,0 +1,527 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+// ErrPoolClosed is returned by Submit after the worker pool has been closed.
+var ErrPoolClosed = errors.New("engine: worker pool closed")
+
+// WorkerPoolConfig sets the bounds and target of a WorkerPool.
+type WorkerPoolConfig struct {
+	MinWorkers int           // Workers kept running even when idle
+	MaxWorkers int           // Upper bound at governor scale 1.0
+	TargetLag  time.Duration // Queue wait the pool scales to hold
+	QueueSize  int           // Tasks waiting before Submit blocks (running tasks not counted)
+}
+
+// WorkerPoolStats is a point-in-time view of a WorkerPool.
+type WorkerPoolStats struct {
+	Workers   int           // Current worker count
+	Limit     int           // Max workers allowed at the current governor scale
+	Queued    int           // Tasks waiting for a worker or for their key
+	Processed uint64        // Tasks completed
+	Lag       time.Duration // Average queue wait over the last scaling tick
+	TargetLag time.Duration
+	Saturated bool // Lag above target at the worker limit
+	Idle      bool // No work over the last tick at MinWorkers
+}
+
+// WorkerPool runs tasks on a pool of goroutines sized to hold queue lag
+// (time from Submit until a worker picks the task up) near TargetLag.
+//
+// Tasks submitted with SubmitKeyed run one at a time per key, in submission
+// order; tasks with different keys run in parallel. A task whose key is
+// busy is parked behind it at submission and run by the worker that frees
+// the key, so workers never wait on each other.
+//
+// Scaling happens on Tick (Start calls it on a ticker):
+//   - Lag above target: add a worker, up to the limit
+//   - Lag below half the target: remove a worker, down to MinWorkers
+//
+// The limit is MaxWorkers × governor scale (never below MinWorkers), so
+// the pool shrinks with the rest of the pipeline under memory pressure.
+// control.worker.scale commands (see Listen) resize it directly within the
+// same bounds. Every change is reported on the ErrorBus.
+//
+// Lag is measured with the injected clock.
+type WorkerPool struct {
+	clock    clock.Clock
+	cfg      WorkerPoolConfig
+	scale    func() float64  // Governor scale source (nil = always 1.0)
+	errorBus *event.ErrorBus // Optional: scaling reports
+
+	queue  chan poolTask
+	slots  chan struct{} // Held from Submit until the task finishes (back pressure)
+	shrink chan struct{} // Each token stops one worker
+	wg     sync.WaitGroup
+
+	// Keys with a task running, mapped to the tasks parked behind it
+	keyMu  sync.Mutex
+	keys   map[string][]poolTask
+	parked int
+
+	// Submitted but not finished tasks, for Wait. drained is closed while
+	// inflight is zero.
+	taskMu   sync.Mutex
+	inflight int
+	drained  chan struct{}
+
+	// closeMu is held for reading while Submit sends, so Close cannot close
+	// the queue under it. closed is written holding both mu and closeMu.
+	closeMu sync.RWMutex
+	closed  bool
+
+	mu        sync.Mutex
+	size      int // Workers running or about to
+	saturated bool
+	idle      bool
+	lag       time.Duration
+	lastTick  clock.MonoTime
+
+	lagSum    atomic.Int64 // Queue wait since the last tick (ns)
+	lagCount  atomic.Int64
+	processed atomic.Uint64
+}
+
+// poolTask is a queued unit of work.
+type poolTask struct {
+	fn       func()
+	key      string
+	keyed    bool
+	enqueued clock.MonoTime
+}
+
+// NewWorkerPool creates a pool and starts MinWorkers workers. scale and
+// errorBus are optional.
+func NewWorkerPool(clk clock.Clock, cfg WorkerPoolConfig, scale func() float64, errorBus *event.ErrorBus) *WorkerPool {
+	if cfg.MinWorkers < 1 {
+		cfg.MinWorkers = 1
+	}
+	if cfg.MaxWorkers < cfg.MinWorkers {
+		cfg.MaxWorkers = cfg.MinWorkers
+	}
+	if cfg.QueueSize < 1 {
+		cfg.QueueSize = 1
+	}
+
+	p := &WorkerPool{
+		clock:    clk,
+		cfg:      cfg,
+		scale:    scale,
+		errorBus: errorBus,
+		queue:    make(chan poolTask, cfg.QueueSize+cfg.MaxWorkers),
+		slots:    make(chan struct{}, cfg.QueueSize+cfg.MaxWorkers),
+		keys:     make(map[string][]poolTask),
+		shrink:   make(chan struct{}, cfg.MaxWorkers),
+		drained:  make(chan struct{}),
+		lastTick: clk.Now(),
+	}
+	close(p.drained)
+
+	p.mu.Lock()
+	p.resizeLocked(cfg.MinWorkers)
+	p.mu.Unlock()
+	return p
+}
+
+// Submit queues fn for a worker, blocking while the queue is full
+// (back pressure) until ctx is done.
+func (p *WorkerPool) Submit(ctx context.Context, fn func()) error {
+	return p.submit(ctx, poolTask{fn: fn})
+}
+
+// SubmitKeyed queues fn like Submit. Tasks sharing key run one at a time in
+// submission order.
+func (p *WorkerPool) SubmitKeyed(ctx context.Context, key string, fn func()) error {
+	return p.submit(ctx, poolTask{fn: fn, key: key, keyed: true})
+}
+
+// submit takes a slot for task and queues it.
+func (p *WorkerPool) submit(ctx context.Context, task poolTask) error {
+	p.closeMu.RLock()
+	defer p.closeMu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+	p.addTask()
+
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		p.doneTask()
+		return ctx.Err()
+	}
+
+	// Parked tasks skip the queue. It holds as many tasks as there are
+	// slots, so sending never blocks.
+	task.enqueued = p.clock.Now()
+	if task.keyed && !p.claim(task) {
+		return nil
+	}
+	p.queue <- task
+	return nil
+}
+
+// addTask counts a submitted task.
+func (p *WorkerPool) addTask() {
+	p.taskMu.Lock()
+	defer p.taskMu.Unlock()
+	if p.inflight == 0 {
+		p.drained = make(chan struct{})
+	}
+	p.inflight++
+}
+
+// doneTask counts a finished (or abandoned) task, releasing Wait at zero.
+func (p *WorkerPool) doneTask() {
+	p.taskMu.Lock()
+	defer p.taskMu.Unlock()
+	p.inflight--
+	if p.inflight == 0 {
+		close(p.drained)
+	}
+}
+
+// work runs tasks until the queue closes or a shrink token arrives.
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+	for {
+		// Prefer exiting when asked, so a busy queue cannot starve a shrink
+		select {
+		case <-p.shrink:
+			return
+		default:
+		}
+
+		select {
+		case <-p.shrink:
+			return
+		case task, ok := <-p.queue:
+			if !ok {
+				return
+			}
+			p.run(task)
+		}
+	}
+}
+
+// run executes task, then any tasks parked behind its key.
+func (p *WorkerPool) run(task poolTask) {
+	for {
+		p.lagSum.Add(int64(p.clock.Since(task.enqueued)))
+		p.lagCount.Add(1)
+		task.fn()
+		p.processed.Add(1)
+		<-p.slots
+		p.doneTask()
+
+		if !task.keyed {
+			return
+		}
+		next, ok := p.release(task.key)
+		if !ok {
+			return
+		}
+		task = next
+	}
+}
+
+// claim marks task's key busy, or parks task if it already is.
+// Reports whether task should be queued.
+func (p *WorkerPool) claim(task poolTask) bool {
+	p.keyMu.Lock()
+	defer p.keyMu.Unlock()
+	if parked, busy := p.keys[task.key]; busy {
+		p.keys[task.key] = append(parked, task)
+		p.parked++
+		return false
+	}
+	p.keys[task.key] = nil
+	return true
+}
+
+// release returns the next task parked on key, or frees the key.
+func (p *WorkerPool) release(key string) (poolTask, bool) {
+	p.keyMu.Lock()
+	defer p.keyMu.Unlock()
+	parked := p.keys[key]
+	if len(parked) == 0 {
+		delete(p.keys, key)
+		return poolTask{}, false
+	}
+	p.keys[key] = parked[1:]
+	p.parked--
+	return parked[0], true
+}
+
+// queued counts tasks waiting for a worker or for their key.
+func (p *WorkerPool) queued() int {
+	p.keyMu.Lock()
+	defer p.keyMu.Unlock()
+	return len(p.queue) + p.parked
+}
+
+// limit returns the max workers allowed at the current governor scale.
+func (p *WorkerPool) limit() int {
+	scale := 1.0
+	if p.scale != nil {
+		scale = p.scale()
+	}
+	n := int(math.Ceil(float64(p.cfg.MaxWorkers) * scale))
+	return max(p.cfg.MinWorkers, min(n, p.cfg.MaxWorkers))
+}
+
+// Tick measures lag since the previous tick and scales by one worker
+// toward the target. Returns the worker count.
+func (p *WorkerPool) Tick() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return p.size
+	}
+
+	now := p.clock.Now()
+	elapsed := clock.ToDuration(now - p.lastTick)
+	p.lastTick = now
+
+	sum, count := p.lagSum.Swap(0), p.lagCount.Swap(0)
+	queued := p.queued()
+	switch {
+	case count > 0:
+		p.lag = time.Duration(sum / count)
+	case queued > 0:
+		p.lag = elapsed // Nothing dequeued all tick: waiting at least this long
+	default:
+		p.lag = 0
+	}
+
+	limit := p.limit()
+	old := p.size
+	target := old
+	switch {
+	case old > limit:
+		target = limit // Governor scaled down
+	case p.lag > p.cfg.TargetLag && old < limit:
+		target = old + 1
+	case p.lag < p.cfg.TargetLag/2 && old > p.cfg.MinWorkers:
+		target = old - 1
+	}
+
+	if target != old {
+		p.resizeLocked(target)
+		p.reportResize(old, target, limit, "lag")
+	}
+
+	// Report saturation and idleness on transitions only
+	saturated := p.lag > p.cfg.TargetLag && p.size >= limit
+	if saturated && !p.saturated {
+		p.report(event.WarningSeverity, event.CodeWorkerSaturated,
+			fmt.Sprintf("Worker pool saturated at %d workers", p.size), limit)
+	}
+	p.saturated = saturated
+
+	idle := count == 0 && queued == 0 && p.size == p.cfg.MinWorkers
+	if idle && !p.idle {
+		p.report(event.DebugSeverity, event.CodeWorkerIdle,
+			fmt.Sprintf("Worker pool idle at %d workers", p.size), limit)
+	}
+	p.idle = idle
+
+	return p.size
+}
+
+// Resize sets the worker count, clamped to [MinWorkers, limit].
+// Returns the resulting count.
+func (p *WorkerPool) Resize(n int, reason string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return p.size
+	}
+
+	limit := p.limit()
+	n = max(p.cfg.MinWorkers, min(n, limit))
+	if old := p.size; n != old {
+		p.resizeLocked(n)
+		p.reportResize(old, n, limit, reason)
+	}
+	return p.size
+}
+
+// resizeLocked starts or stops workers to reach n. Caller holds p.mu.
+//
+// Stop tokens are picked up by workers between tasks, so busy workers may
+// leave some pending. Growing takes those back first (the worker keeps
+// running) before starting new goroutines, so running workers always equal
+// size plus pending tokens. That never exceeds MaxWorkers, the token
+// buffer, so sending a token cannot block.
+func (p *WorkerPool) resizeLocked(n int) {
+	for ; p.size < n; p.size++ {
+		select {
+		case <-p.shrink:
+		default:
+			p.wg.Add(1)
+			go p.work()
+		}
+	}
+	for p.size > n {
+		select {
+		case p.shrink <- struct{}{}:
+			p.size--
+		default:
+			return // Unreachable per the invariant; size stays truthful
+		}
+	}
+}
+
+// reportResize publishes a scale up/down event. Caller holds p.mu.
+func (p *WorkerPool) reportResize(old, n, limit int, reason string) {
+	code, verb := event.CodeWorkerScaleUp, "up"
+	if n < old {
+		code, verb = event.CodeWorkerScaleDown, "down"
+	}
+	p.report(event.InfoSeverity, code,
+		fmt.Sprintf("Worker pool scaled %s from %d to %d (%s)", verb, old, n, reason), limit)
+}
+
+// report publishes a worker pool event with the current measurements.
+func (p *WorkerPool) report(sev event.ErrorSeverity, code, message string, limit int) {
+	if p.errorBus == nil {
+		return
+	}
+	p.errorBus.Publish(event.NewErrorEvent(sev, code, "engine:workers", message).
+		WithContext("workers", p.size).
+		WithContext("limit", limit).
+		WithContext("lag", p.lag.String()).
+		WithContext("target_lag", p.cfg.TargetLag.String()).
+		WithContext("queued", p.queued()))
+}
+
+// Start calls Tick every interval until ctx is cancelled (blocks).
+func (p *WorkerPool) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.Tick()
+		}
+	}
+}
+
+// Listen applies control.worker.scale commands from bus until ctx is
+// cancelled or the bus closes.
+func (p *WorkerPool) Listen(ctx context.Context, bus event.Bus) error {
+	filter := event.Filter{
+		Types: []string{event.EventTypeWorkerScale},
+	}
+
+	sub, err := event.SubscribeNamed(ctx, bus, "worker-pool", filter)
+	if err != nil {
+		return fmt.Errorf("worker pool: failed to subscribe to internal bus: %w", err)
+	}
+
+	go func() {
+		defer sub.Close()
+
+		for {
+			select {
+			case evt, ok := <-sub.Events():
+				if !ok {
+					return // Bus closed
+				}
+				p.applyScaleCommand(evt)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// applyScaleCommand applies a WorkerScaleCommand. Invalid commands are ignored.
+func (p *WorkerPool) applyScaleCommand(evt *event.Event) {
+	var cmd event.WorkerScaleCommand
+	if err := evt.DecodePayload(&cmd, event.JSONCodec{}); err != nil {
+		return
+	}
+
+	reason := "command"
+	if cmd.Reason != "" {
+		reason = cmd.Reason
+	}
+
+	current := p.Stats().Workers
+	switch cmd.Action {
+	case "scale_up":
+		p.Resize(current+1, reason)
+	case "scale_down":
+		p.Resize(current-1, reason)
+	case "set_count":
+		if cmd.Count > 0 {
+			p.Resize(cmd.Count, reason)
+		}
+	}
+}
+
+// Stats returns current pool measurements.
+func (p *WorkerPool) Stats() WorkerPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return WorkerPoolStats{
+		Workers:   p.size,
+		Limit:     p.limit(),
+		Queued:    p.queued(),
+		Processed: p.processed.Load(),
+		Lag:       p.lag,
+		TargetLag: p.cfg.TargetLag,
+		Saturated: p.saturated,
+		Idle:      p.idle,
+	}
+}
+
+// Wait blocks until every submitted task has finished or ctx is done.
+func (p *WorkerPool) Wait(ctx context.Context) error {
+	p.taskMu.Lock()
+	drained := p.drained
+	p.taskMu.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting tasks, lets workers finish the queue and waits for
+// them to exit. Safe to call multiple times.
+func (p *WorkerPool) Close() {
+	p.mu.Lock()
+	p.closeMu.Lock()
+	if p.closed {
+		p.closeMu.Unlock()
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.queue)
+	p.closeMu.Unlock()
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
@@ -0,0 +1,316 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/event"
+)
+
+// observeLag records one dequeue with the given queue wait, as a worker would.
+func observeLag(p *WorkerPool, d time.Duration) {
+	p.lagSum.Add(int64(d))
+	p.lagCount.Add(1)
+}
+
+func TestWorkerPool_ScalesToTargetLag(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	errorBus := event.NewErrorBus(32)
+	defer errorBus.Close()
+	sub, _ := errorBus.SubscribeFiltered(t.Context(), event.ErrorFilter{Codes: []string{"WORKER_*"}})
+
+	var scale atomic.Value
+	scale.Store(1.0)
+	p := NewWorkerPool(clk, WorkerPoolConfig{
+		MinWorkers: 1,
+		MaxWorkers: 4,
+		TargetLag:  10 * time.Millisecond,
+		QueueSize:  8,
+	}, func() float64 { return scale.Load().(float64) }, errorBus)
+	defer p.Close()
+
+	// Lag above target: one worker per tick up to the max, then saturated
+	for want := 2; want <= 4; want++ {
+		observeLag(p, 50*time.Millisecond)
+		if got := p.Tick(); got != want {
+			t.Fatalf("Expected %d workers, got %d", want, got)
+		}
+	}
+	observeLag(p, 50*time.Millisecond)
+	p.Tick()
+	if st := p.Stats(); st.Workers != 4 || !st.Saturated || st.Lag != 50*time.Millisecond {
+		t.Errorf("Expected saturated at 4 workers, got %+v", st)
+	}
+
+	// Governor at 0.5 caps the pool at 2 workers
+	scale.Store(0.5)
+	if got := p.Tick(); got != 2 {
+		t.Errorf("Expected governor cap of 2 workers, got %d", got)
+	}
+
+	// No lag: shrink to the minimum, then report idle
+	p.Tick()
+	p.Tick()
+	if st := p.Stats(); st.Workers != 1 || !st.Idle {
+		t.Errorf("Expected idle at 1 worker, got %+v", st)
+	}
+
+	counts := make(map[string]int)
+	for len(sub.Events()) > 0 {
+		counts[(<-sub.Events()).Code]++
+	}
+	if counts[event.CodeWorkerScaleUp] != 3 || counts[event.CodeWorkerScaleDown] != 2 ||
+		counts[event.CodeWorkerSaturated] != 1 || counts[event.CodeWorkerIdle] != 1 {
+		t.Errorf("Unexpected scaling reports: %v", counts)
+	}
+}
+
+func TestWorkerPool_SubmitWaitClose(t *testing.T) {
+	p := NewWorkerPool(clock.NewSystemClock(), WorkerPoolConfig{MinWorkers: 2, MaxWorkers: 2, QueueSize: 4}, nil, nil)
+
+	var ran atomic.Int64
+	for i := 0; i < 20; i++ {
+		if err := p.Submit(t.Context(), func() { ran.Add(1) }); err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+	}
+	if err := p.Wait(t.Context()); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if ran.Load() != 20 || p.Stats().Processed != 20 {
+		t.Errorf("Expected 20 tasks run, got %d", ran.Load())
+	}
+
+	p.Close()
+	p.Close() // Idempotent
+	if err := p.Submit(t.Context(), func() {}); err != ErrPoolClosed {
+		t.Errorf("Expected ErrPoolClosed, got %v", err)
+	}
+}
+
+func TestWorkerPool_ShrinkWhileBusy(t *testing.T) {
+	p := NewWorkerPool(clock.NewSystemClock(), WorkerPoolConfig{MinWorkers: 1, MaxWorkers: 4, QueueSize: 4}, nil, nil)
+	defer p.Close()
+	p.Resize(4, "test")
+
+	// Busy workers leave the stop tokens pending
+	release := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		p.Submit(t.Context(), func() { <-release })
+	}
+	p.Resize(1, "test")
+
+	// Growing again takes the pending tokens back instead of starting
+	// goroutines that would exit on them
+	if got := p.Resize(4, "test"); got != 4 || len(p.shrink) != 0 {
+		t.Fatalf("Expected 4 workers and no pending stops, got %d with %d pending", got, len(p.shrink))
+	}
+	close(release)
+
+	var ran atomic.Int64
+	for i := 0; i < 20; i++ {
+		p.Submit(t.Context(), func() { ran.Add(1) })
+	}
+	if err := p.Wait(t.Context()); err != nil || ran.Load() != 20 {
+		t.Errorf("Expected 20 tasks run, got %d (%v)", ran.Load(), err)
+	}
+}
+
+func TestWorkerPool_WaitDuringSubmit(t *testing.T) {
+	p := NewWorkerPool(clock.NewSystemClock(), WorkerPoolConfig{MinWorkers: 2, MaxWorkers: 2, QueueSize: 4}, nil, nil)
+	defer p.Close()
+
+	// An abandoned Wait must not race later Submits
+	block := make(chan struct{})
+	p.Submit(t.Context(), func() { <-block })
+	ctx, cancel := context.WithTimeout(t.Context(), time.Millisecond)
+	defer cancel()
+	if err := p.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		p.Submit(t.Context(), func() {})
+	}
+	close(block)
+	if err := p.Wait(t.Context()); err != nil {
+		t.Errorf("Wait failed: %v", err)
+	}
+}
+
+func TestWorkerPool_SubmitKeyed(t *testing.T) {
+	p := NewWorkerPool(clock.NewSystemClock(), WorkerPoolConfig{MinWorkers: 4, MaxWorkers: 4, QueueSize: 16}, nil, nil)
+	defer p.Close()
+
+	// Key a is held until key b runs: different keys run in parallel
+	bRan := make(chan struct{})
+	var mu sync.Mutex
+	var order []string
+	for i := 0; i < 10; i++ {
+		p.SubmitKeyed(t.Context(), "a", func() {
+			if i == 0 {
+				<-bRan
+			}
+			mu.Lock()
+			order = append(order, fmt.Sprintf("a%d", i))
+			mu.Unlock()
+		})
+	}
+	p.SubmitKeyed(t.Context(), "b", func() { close(bRan) })
+
+	if err := p.Wait(t.Context()); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	for i, got := range order {
+		if want := fmt.Sprintf("a%d", i); got != want {
+			t.Fatalf("Expected %s in order, got %v", want, order)
+		}
+	}
+	if st := p.Stats(); st.Processed != 11 || st.Queued != 0 {
+		t.Errorf("Expected 11 tasks run and none parked, got %+v", st)
+	}
+}
+
+func TestWorkerPool_ScaleCommands(t *testing.T) {
+	bus := event.NewInMemoryBus()
+	defer bus.Close()
+	p := NewWorkerPool(clock.NewSystemClock(), WorkerPoolConfig{MinWorkers: 1, MaxWorkers: 6}, nil, nil)
+	defer p.Close()
+
+	if err := p.Listen(t.Context(), bus); err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+
+	publish := func(cmd event.WorkerScaleCommand) {
+		bus.Publish(context.Background(), event.NewControlEvent(event.EventTypeWorkerScale, cmd))
+	}
+	waitFor := func(want int) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for p.Stats().Workers != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected %d workers, got %d", want, p.Stats().Workers)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	publish(event.WorkerScaleCommand{Action: "set_count", Count: 4})
+	waitFor(4)
+	publish(event.WorkerScaleCommand{Action: "scale_down"})
+	waitFor(3)
+	publish(event.WorkerScaleCommand{Action: "set_count", Count: 100}) // Clamped
+	waitFor(6)
+}
+
+func TestEngine_WorkerPoolRunsEmitters(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.WorkerPool = true
+	cfg.MinWorkers = 4 // Enough workers to reorder events if dispatch allowed it
+	eng, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer eng.Shutdown(context.Background())
+
+	if eng.WorkerPool() == nil || eng.WorkerPool().Stats().Workers != cfg.MinWorkers {
+		t.Fatal("Expected worker pool started at MinWorkers")
+	}
+
+	rec := &recordingEmitter{emitted: make(chan *event.Event, 10)}
+	em := NewEmitterManager(eng)
+	em.Register("recording", rec, event.Filter{})
+	if err := em.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		eng.ExternalBus().Publish(context.Background(), &event.Event{ID: fmt.Sprintf("evt-%d", i), Type: "test.pool"})
+	}
+	for i := 0; i < 10; i++ {
+		select {
+		case evt := <-rec.emitted:
+			if want := fmt.Sprintf("evt-%d", i); evt.ID != want {
+				t.Fatalf("Expected %s in order, got %s", want, evt.ID)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out after %d emits", i)
+		}
+	}
+	em.Stop()
+
+	if processed := eng.WorkerPool().Stats().Processed; processed != 10 {
+		t.Errorf("Expected 10 emits on the pool, got %d", processed)
+	}
+}
+
+// partitionedEmitter orders events per source and blocks source a until
+// source b has been emitted.
+type partitionedEmitter struct {
+	mu      sync.Mutex
+	emitted map[string][]string
+	bRan    chan struct{}
+	done    chan struct{}
+}
+
+func (e *partitionedEmitter) ID() string   { return "partitioned" }
+func (e *partitionedEmitter) Type() string { return "test" }
+func (e *partitionedEmitter) Close() error { return nil }
+
+func (e *partitionedEmitter) PartitionKey(evt *event.Event) string { return evt.Source }
+
+func (e *partitionedEmitter) Emit(ctx context.Context, evt *event.Event) error {
+	if evt.Source == "b" {
+		close(e.bRan)
+	} else {
+		<-e.bRan
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.emitted[evt.Source] = append(e.emitted[evt.Source], evt.ID)
+	if len(e.emitted["a"]) == 5 && len(e.emitted["b"]) == 1 {
+		close(e.done)
+	}
+	return nil
+}
+
+func TestEngine_WorkerPoolPartitionsEmitter(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.WorkerPool = true
+	cfg.MinWorkers = 2
+	eng, err := NewWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewWithConfig failed: %v", err)
+	}
+	defer eng.Shutdown(context.Background())
+
+	emit := &partitionedEmitter{emitted: make(map[string][]string), bRan: make(chan struct{}), done: make(chan struct{})}
+	em := NewEmitterManager(eng)
+	em.Register("partitioned", emit, event.Filter{})
+	if err := em.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// Source a queues behind its first, blocked event; b still gets through
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		eng.ExternalBus().Publish(ctx, &event.Event{ID: fmt.Sprintf("a-%d", i), Type: "test", Source: "a"})
+	}
+	eng.ExternalBus().Publish(ctx, &event.Event{ID: "b-0", Type: "test", Source: "b"})
+
+	select {
+	case <-emit.done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected partition b to be emitted while a was blocked")
+	}
+	em.Stop()
+
+	if got := fmt.Sprint(emit.emitted["a"]); got != "[a-0 a-1 a-2 a-3 a-4]" {
+		t.Errorf("Expected partition a in order, got %s", got)
+	}
+}
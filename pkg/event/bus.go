@@ -3,11 +3,16 @@ package event
 import (
 	"context"
 	"fmt"
+	"iter"
+	"maps"
 	"path/filepath"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/BYTE-6D65/pipeline/pkg/telemetry"
+	"github.com/BYTE-6D65/pipeline/pkg/trace"
 )
 
 // Bus defines the interface for an event bus that supports publish/subscribe patterns.
@@ -49,10 +54,19 @@ type InMemoryBus struct {
 	mu            sync.RWMutex
 	subscriptions map[string]*inMemorySubscription
 	closed        bool
+	draining      atomic.Bool // Set by Drain: reject new publishes
 	bufferSize    int
 	dropSlow      bool // If true, drop events for slow subscribers; if false, block
 	name          string
 	metrics       *telemetry.Metrics
+	dedup         *Deduplicator                    // Optional: suppress duplicate events
+	nextSubID     atomic.Uint64                    // Monotonic counter for unique subscription IDs
+	published     atomic.Uint64                    // Total events accepted by Publish
+	ttlPolicy     atomic.Pointer[TTLPolicy]        // Optional: default TTLs by event type
+	errorBus      atomic.Pointer[ErrorBus]         // Optional: report expired events
+	expiryClock   atomic.Pointer[func() time.Time] // Optional: wall time for expiry (default time.Now)
+	tracer        atomic.Pointer[trace.Tracer]     // Optional: record publish/filter/deliver spans
+	retention     *Retention                       // Optional: history replayed to late subscribers
 }
 
 // BusOption configures an InMemoryBus.
@@ -87,6 +101,109 @@ func WithMetrics(metrics *telemetry.Metrics) BusOption {
 	}
 }
 
+// WithDeduplicator suppresses events the deduplicator has already seen.
+// Duplicates are dropped before filtering and never reach subscribers.
+func WithDeduplicator(dedup *Deduplicator) BusOption {
+	return func(b *InMemoryBus) {
+		b.dedup = dedup
+	}
+}
+
+// WithTTLPolicy applies type-level default TTLs to published events.
+func WithTTLPolicy(policy *TTLPolicy) BusOption {
+	return func(b *InMemoryBus) {
+		b.ttlPolicy.Store(policy)
+	}
+}
+
+// WithExpiryClock sets the wall time source used to expire events (default
+// time.Now), so deadlines and TTLs can follow an injected clock.
+func WithExpiryClock(now func() time.Time) BusOption {
+	return func(b *InMemoryBus) {
+		b.SetExpiryClock(now)
+	}
+}
+
+// WithErrorBus sets the error bus used to report expired events.
+func WithErrorBus(errorBus *ErrorBus) BusOption {
+	return func(b *InMemoryBus) {
+		b.errorBus.Store(errorBus)
+	}
+}
+
+// WithTracer records publish, filter and deliver spans for every event and
+// propagates W3C trace context through the delivered event's Metadata.
+func WithTracer(tracer *trace.Tracer) BusOption {
+	return func(b *InMemoryBus) {
+		b.tracer.Store(tracer)
+	}
+}
+
+// WithRetention keeps published events in r so late subscribers can replay
+// them (see WithRetainedLatest). Replay skips events expired on the bus's
+// expiry clock.
+func WithRetention(r *Retention) BusOption {
+	return func(b *InMemoryBus) {
+		b.retention = r
+		if r != nil {
+			r.setClock(b.now)
+		}
+	}
+}
+
+// Retention returns the bus history store, or nil if retention is disabled.
+func (b *InMemoryBus) Retention() *Retention {
+	return b.retention
+}
+
+// SetTracer replaces the tracer on a running bus (nil disables tracing).
+func (b *InMemoryBus) SetTracer(tracer *trace.Tracer) {
+	b.tracer.Store(tracer)
+}
+
+// SetTTLPolicy replaces the TTL policy on a running bus.
+func (b *InMemoryBus) SetTTLPolicy(policy *TTLPolicy) {
+	b.ttlPolicy.Store(policy)
+}
+
+// SetErrorBus replaces the error bus on a running bus.
+func (b *InMemoryBus) SetErrorBus(errorBus *ErrorBus) {
+	b.errorBus.Store(errorBus)
+}
+
+// SetExpiryClock replaces the expiry time source on a running bus (nil
+// restores time.Now).
+func (b *InMemoryBus) SetExpiryClock(now func() time.Time) {
+	if now == nil {
+		b.expiryClock.Store(nil)
+		return
+	}
+	b.expiryClock.Store(&now)
+}
+
+// now returns the current time for expiry decisions.
+func (b *InMemoryBus) now() time.Time {
+	if now := b.expiryClock.Load(); now != nil {
+		return (*now)()
+	}
+	return time.Now()
+}
+
+// SetBufferSize changes the channel buffer size of subscriptions created
+// from now on. Existing subscriptions keep their buffers.
+func (b *InMemoryBus) SetBufferSize(size int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bufferSize = size
+}
+
+// BufferSize returns the buffer size given to new subscriptions.
+func (b *InMemoryBus) BufferSize() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.bufferSize
+}
+
 // NewInMemoryBus creates a new in-memory event bus with the given options.
 func NewInMemoryBus(opts ...BusOption) *InMemoryBus {
 	bus := &InMemoryBus{
@@ -101,6 +218,12 @@ func NewInMemoryBus(opts ...BusOption) *InMemoryBus {
 		opt(bus)
 	}
 
+	// Count duplicates under this bus's name unless the deduplicator has its own metrics
+	if bus.dedup != nil && bus.dedup.metrics == nil {
+		bus.dedup.metrics = bus.metrics
+		bus.dedup.name = bus.name
+	}
+
 	// Update subscriber gauge
 	if bus.metrics != nil {
 		bus.metrics.SubscribersTotal.WithLabelValues(bus.name).Set(0)
@@ -110,7 +233,34 @@ func NewInMemoryBus(opts ...BusOption) *InMemoryBus {
 }
 
 // Publish sends an event to all matching subscribers.
+// If ctx carries a handled event (see ContextWithEvent), empty causation and
+// correlation IDs are filled from it.
+//
+// Subscribers receive a copy of evt carrying that enrichment (causation,
+// timestamp, TTL and trace context); evt itself is not modified.
+//
+// ctx's error is returned only if cancellation kept the event from some
+// matching subscriber; a publish that delivered everywhere returns nil.
 func (b *InMemoryBus) Publish(ctx context.Context, evt *Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return fmt.Errorf("bus is closed")
+	}
+
+	return b.publish(ctx, evt, maps.Values(b.subscriptions))
+}
+
+// publish runs the delivery pipeline (causation, dedup, TTL, tracing,
+// filtering and parallel fan-out) against a set of subscriptions.
+// The caller guarantees subs stays valid for the duration of the call.
+func (b *InMemoryBus) publish(ctx context.Context, evt *Event, subs iter.Seq[*inMemorySubscription]) (err error) {
+	// Rejected before counting: a draining bus publishes nothing
+	if b.draining.Load() {
+		return ErrBusDraining
+	}
+
 	// Start timing the entire publish operation
 	publishTimer := telemetry.NewTimer()
 	defer func() {
@@ -120,26 +270,82 @@ func (b *InMemoryBus) Publish(ctx context.Context, evt *Event) error {
 		}
 	}()
 
-	b.mu.RLock()
-	defer b.mu.RUnlock()
-
-	if b.closed {
-		return fmt.Errorf("bus is closed")
-	}
-
 	// Check context before processing
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
 
+	// Enrich and deliver a copy: the caller's event may be reused or
+	// published to other buses concurrently
+	enriched := *evt
+	evt = &enriched
+
+	// Link follow-up events to the event being handled
+	ApplyCausation(ctx, evt)
+
+	// Drop duplicates before they reach any subscriber; the key is only
+	// remembered once the event has been delivered
+	if b.dedup != nil {
+		key, dup := b.dedup.reserve(evt)
+		if dup {
+			return nil
+		}
+		defer func() { b.dedup.commit(key, err == nil && !evt.Expired(b.now())) }()
+	}
+
+	// Apply type default TTL, then skip events that are already stale. An
+	// unstamped event is stamped first so a TTL has something to count from.
+	policy := b.ttlPolicy.Load()
+	if evt.Timestamp.IsZero() && (policy != nil || evt.TTL > 0) {
+		evt.Timestamp = b.now()
+	}
+	if policy != nil {
+		policy.Apply(evt)
+	}
+	if evt.Expired(b.now()) {
+		b.recordExpired(evt, "publish")
+		return nil
+	}
+
+	b.published.Add(1)
+
+	// Start the publish span and propagate its context to consumers
+	tracer := b.tracer.Load()
+	var publishSpan, filterSpan *trace.ActiveSpan
+	if tracer != nil {
+		parent, _ := trace.Extract(evt.Metadata)
+		publishSpan = tracer.Start(parent, trace.StagePublish+" "+evt.Type, trace.SpanKindProducer).
+			SetAttribute("bus", b.name).
+			SetAttribute("event.id", evt.ID).
+			SetAttribute("event.type", evt.Type)
+		defer publishSpan.End()
+
+		// The copy still shares the caller's Metadata map
+		metadata := make(map[string]string, len(evt.Metadata)+2)
+		maps.Copy(metadata, evt.Metadata)
+		evt.Metadata = metadata
+		trace.Inject(evt.Metadata, publishSpan.Context())
+		filterSpan = tracer.Start(publishSpan.Context(), trace.StageFilter, trace.SpanKindInternal)
+		// Stamped before retention: retained events are shared read-only
+		evt.Metadata[trace.EnqueuedAtKey] = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+
+	// Retain before fan-out: a subscriber that replays retention after
+	// inserting itself then either replays this event or receives it live
+	if b.retention != nil {
+		b.retention.Add(evt)
+	}
+
 	// Collect matching subscriptions
 	var matching []*inMemorySubscription
-	for _, sub := range b.subscriptions {
+	total := 0
+	for sub := range subs {
+		total++
 		// Time the filter matching
 		filterTimer := telemetry.NewTimer()
 		matches := sub.matches(evt)
 		if b.metrics != nil {
-			b.metrics.FilterDuration.WithLabelValues(b.name, sub.id).Observe(filterTimer.Elapsed().Seconds())
+			b.metrics.FilterDuration.WithLabelValues(b.name, sub.name).Observe(filterTimer.Elapsed().Seconds())
 		}
 
 		if matches {
@@ -147,18 +353,32 @@ func (b *InMemoryBus) Publish(ctx context.Context, evt *Event) error {
 		}
 	}
 
+	if filterSpan != nil {
+		filterSpan.SetAttribute("subscriptions", strconv.Itoa(total)).
+			SetAttribute("matched", strconv.Itoa(len(matching))).
+			End()
+	}
+
 	// Send to all matching subscriptions in parallel
 	// Each subscriber gets its own goroutine to avoid head-of-line blocking
 	var wg sync.WaitGroup
+	var skipped atomic.Bool
 	for _, sub := range matching {
 		wg.Add(1)
 		go func(s *inMemorySubscription) {
 			defer wg.Done()
 			select {
 			case <-ctx.Done():
+				skipped.Store(true)
 				return
 			default:
-				s.send(evt, b.dropSlow, b.name, b.metrics)
+				if publishSpan == nil {
+					s.send(evt, b.dropSlow, b.name, b.metrics)
+					return
+				}
+				span := tracer.Start(publishSpan.Context(), trace.StageDeliver, trace.SpanKindConsumer).
+					SetAttribute("subscription.id", s.id)
+				span.SetAttribute("result", s.send(evt, b.dropSlow, b.name, b.metrics)).End()
 			}
 		}(sub)
 	}
@@ -166,11 +386,26 @@ func (b *InMemoryBus) Publish(ctx context.Context, evt *Event) error {
 	// Wait for all sends to complete
 	wg.Wait()
 
+	// Report cancellation only if it cut delivery short
+	if skipped.Load() {
+		return ctx.Err()
+	}
+
 	return nil
 }
 
 // Subscribe creates a new subscription with the given filter.
 func (b *InMemoryBus) Subscribe(ctx context.Context, filter Filter) (Subscription, error) {
+	return b.SubscribeWithOptions(ctx, filter)
+}
+
+// SubscribeWithOptions creates a new subscription with the given filter and options.
+func (b *InMemoryBus) SubscribeWithOptions(ctx context.Context, filter Filter, opts ...SubscribeOption) (Subscription, error) {
+	cfg := subscribeConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -179,7 +414,8 @@ func (b *InMemoryBus) Subscribe(ctx context.Context, filter Filter) (Subscriptio
 	}
 
 	sub := &inMemorySubscription{
-		id:         fmt.Sprintf("sub-%d", len(b.subscriptions)),
+		id:         fmt.Sprintf("sub-%d", b.nextSubID.Add(1)-1),
+		name:       cfg.name,
 		bus:        b,
 		filter:     filter,
 		ch:         make(chan *Event, b.bufferSize),
@@ -187,13 +423,15 @@ func (b *InMemoryBus) Subscribe(ctx context.Context, filter Filter) (Subscriptio
 		bufferSize: b.bufferSize,
 	}
 
+	// Publishers are excluded by the write lock, so the replay is gap-free
+	sub.replayRetained(b.retention, cfg)
 	b.subscriptions[sub.id] = sub
 
 	// Update metrics
 	if b.metrics != nil {
 		b.metrics.SubscribersTotal.WithLabelValues(b.name).Set(float64(len(b.subscriptions)))
-		b.metrics.BufferSize.WithLabelValues(b.name, sub.id).Set(float64(b.bufferSize))
-		b.metrics.BufferUsage.WithLabelValues(b.name, sub.id).Set(0)
+		b.metrics.BufferSize.WithLabelValues(b.name, sub.name).Set(float64(b.bufferSize))
+		b.metrics.BufferUsage.WithLabelValues(b.name, sub.name).Set(0)
 	}
 
 	return sub, nil
@@ -213,21 +451,47 @@ func (b *InMemoryBus) Close() error {
 	// Close all subscriptions
 	for _, sub := range b.subscriptions {
 		sub.closeChannel()
+		if b.metrics != nil {
+			b.metrics.DeleteSubscription(b.name, sub.name)
+		}
 	}
 
 	b.subscriptions = nil
 	return nil
 }
 
+// recordExpired counts and reports an event skipped because it expired.
+func (b *InMemoryBus) recordExpired(evt *Event, stage string) {
+	if b.metrics != nil {
+		b.metrics.EventsExpired.WithLabelValues(b.name, evt.Type, stage).Inc()
+	}
+	if errBus := b.errorBus.Load(); errBus != nil {
+		errBus.Publish(NewExpiredEvent("bus:"+b.name, stage, evt, b.now()))
+	}
+}
+
 // inMemorySubscription represents a single subscription.
 type inMemorySubscription struct {
 	id         string
+	name       string
 	bus        *InMemoryBus
+	sharded    *ShardedBus // Set when owned by a ShardedBus (bus then only supplies config)
+	shard      int         // Shard index within sharded
 	filter     Filter
 	ch         chan *Event
 	mu         sync.Mutex
 	closed     bool
 	bufferSize int
+
+	// Events already replayed on subscribe that a concurrent publish may
+	// still deliver live (ShardedBus only; guarded by mu)
+	replayed map[*Event]struct{}
+
+	// Delivery counters (read by Stats without taking mu)
+	delivered    atomic.Uint64
+	dropped      atomic.Uint64
+	blocked      atomic.Uint64
+	lastDelivery atomic.Int64 // Unix nanoseconds of last successful send
 }
 
 // Events returns the channel that receives events.
@@ -235,8 +499,23 @@ func (s *inMemorySubscription) Events() <-chan *Event {
 	return s.ch
 }
 
+// ID returns the bus-unique subscription identifier.
+func (s *inMemorySubscription) ID() string {
+	return s.id
+}
+
+// Name returns the optional human-readable subscription name.
+func (s *inMemorySubscription) Name() string {
+	return s.name
+}
+
 // Close unsubscribes and closes the event channel.
 func (s *inMemorySubscription) Close() error {
+	if s.sharded != nil {
+		s.sharded.unsubscribe(s)
+		return nil
+	}
+
 	s.bus.mu.Lock()
 	defer s.bus.mu.Unlock()
 
@@ -246,11 +525,25 @@ func (s *inMemorySubscription) Close() error {
 	// Update metrics
 	if s.bus.metrics != nil {
 		s.bus.metrics.SubscribersTotal.WithLabelValues(s.bus.name).Set(float64(len(s.bus.subscriptions)))
+		if !nameInUse(maps.Values(s.bus.subscriptions), s.name) {
+			s.bus.metrics.DeleteSubscription(s.bus.name, s.name)
+		}
 	}
 
 	return nil
 }
 
+// nameInUse reports whether any of subs is named name. Per-subscription
+// metrics are labelled by name, so they are deleted only with the last one.
+func nameInUse(subs iter.Seq[*inMemorySubscription], name string) bool {
+	for sub := range subs {
+		if sub.name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // closeChannel closes the event channel (internal use only, assumes lock is held).
 func (s *inMemorySubscription) closeChannel() {
 	s.mu.Lock()
@@ -263,12 +556,23 @@ func (s *inMemorySubscription) closeChannel() {
 }
 
 // send attempts to send an event to the subscription channel.
-func (s *inMemorySubscription) send(evt *Event, dropSlow bool, busName string, metrics *telemetry.Metrics) {
+// Returns the outcome: "success", "dropped", "expired" or "closed".
+func (s *inMemorySubscription) send(evt *Event, dropSlow bool, busName string, metrics *telemetry.Metrics) string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if s.closed {
-		return
+		return "closed"
+	}
+	if _, ok := s.replayed[evt]; ok {
+		delete(s.replayed, evt)
+		return "replayed"
+	}
+
+	// Skip events that went stale while waiting behind earlier blocked sends
+	if evt.Expired(s.bus.now()) {
+		s.bus.recordExpired(evt, "deliver")
+		return "expired"
 	}
 
 	// Start timing the send operation (includes blocking time)
@@ -279,37 +583,50 @@ func (s *inMemorySubscription) send(evt *Event, dropSlow bool, busName string, m
 		select {
 		case s.ch <- evt:
 			// Successful send
+			s.recordDelivery()
 			if metrics != nil {
 				elapsed := time.Since(sendTimer).Seconds()
-				metrics.SendDuration.WithLabelValues(busName, s.id, "success").Observe(elapsed)
-				metrics.BufferUsage.WithLabelValues(busName, s.id).Set(float64(len(s.ch)))
+				metrics.SendDuration.WithLabelValues(busName, s.name, "success").Observe(elapsed)
+				metrics.BufferUsage.WithLabelValues(busName, s.name).Set(float64(len(s.ch)))
 			}
 		default:
 			// Event dropped due to slow subscriber
+			s.dropped.Add(1)
 			if metrics != nil {
 				elapsed := time.Since(sendTimer).Seconds()
-				metrics.SendDuration.WithLabelValues(busName, s.id, "dropped").Observe(elapsed)
-				metrics.EventsDropped.WithLabelValues(busName, evt.Type, s.id).Inc()
+				metrics.SendDuration.WithLabelValues(busName, s.name, "dropped").Observe(elapsed)
+				metrics.EventsDropped.WithLabelValues(busName, evt.Type, s.name).Inc()
 			}
+			return "dropped"
 		}
 	} else {
 		// Blocking send, wait for space in channel
 		// Check if we'll block
 		if len(s.ch) >= s.bufferSize {
+			s.blocked.Add(1)
 			if metrics != nil {
-				metrics.SendBlocked.WithLabelValues(busName, s.id).Inc()
+				metrics.SendBlocked.WithLabelValues(busName, s.name).Inc()
 			}
 		}
 
 		s.ch <- evt
+		s.recordDelivery()
 
 		// Record send duration (includes any blocking time!)
 		if metrics != nil {
 			elapsed := time.Since(sendTimer).Seconds()
-			metrics.SendDuration.WithLabelValues(busName, s.id, "success").Observe(elapsed)
-			metrics.BufferUsage.WithLabelValues(busName, s.id).Set(float64(len(s.ch)))
+			metrics.SendDuration.WithLabelValues(busName, s.name, "success").Observe(elapsed)
+			metrics.BufferUsage.WithLabelValues(busName, s.name).Set(float64(len(s.ch)))
 		}
 	}
+
+	return "success"
+}
+
+// recordDelivery updates delivery counters after a successful send.
+func (s *inMemorySubscription) recordDelivery() {
+	s.delivered.Add(1)
+	s.lastDelivery.Store(time.Now().UnixNano())
 }
 
 // matches checks if an event matches the subscription filter.
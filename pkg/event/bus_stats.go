@@ -0,0 +1,169 @@
+package event
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// SubscribeOption configures a single subscription.
+type SubscribeOption func(*subscribeConfig)
+
+// subscribeConfig collects per-subscription options.
+type subscribeConfig struct {
+	name     string
+	retained retainedSelector // Optional: replay retained events on subscribe
+}
+
+// WithSubscriptionName attaches a human-readable name to a subscription.
+// Names need not be unique; IDs are always unique. Per-subscription metrics
+// are labelled by name, so subscriptions sharing a name share their series.
+func WithSubscriptionName(name string) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.name = name
+	}
+}
+
+// OptionSubscriber is implemented by buses that accept subscription options.
+type OptionSubscriber interface {
+	SubscribeWithOptions(ctx context.Context, filter Filter, opts ...SubscribeOption) (Subscription, error)
+}
+
+// SubscribeNamed subscribes with a human-readable name if the bus supports
+// subscription options, and falls back to a plain Subscribe otherwise.
+func SubscribeNamed(ctx context.Context, bus Bus, name string, filter Filter) (Subscription, error) {
+	if ob, ok := bus.(OptionSubscriber); ok {
+		return ob.SubscribeWithOptions(ctx, filter, WithSubscriptionName(name))
+	}
+	return bus.Subscribe(ctx, filter)
+}
+
+// SubscriptionStats is a point-in-time view of one subscription.
+type SubscriptionStats struct {
+	ID             string    // Unique for the lifetime of the bus (never reused)
+	Name           string    // Optional human-readable name
+	Filter         Filter    // Copy of the subscription filter
+	BufferCapacity int       // Channel capacity
+	BufferLen      int       // Events currently buffered
+	Delivered      uint64    // Events successfully sent to the channel
+	Dropped        uint64    // Events dropped because the buffer was full
+	Blocked        uint64    // Sends that had to wait for buffer space
+	LastDelivery   time.Time // Zero if nothing has been delivered yet
+}
+
+// Fill returns the buffer fill level (0.0-1.0).
+func (s SubscriptionStats) Fill() float64 {
+	if s.BufferCapacity == 0 {
+		return 0
+	}
+	return float64(s.BufferLen) / float64(s.BufferCapacity)
+}
+
+// Label returns "id" or "id/name" for display and map keys.
+func (s SubscriptionStats) Label() string {
+	if s.Name == "" {
+		return s.ID
+	}
+	return s.ID + "/" + s.Name
+}
+
+// BusStats is a point-in-time view of a bus and its subscriptions.
+type BusStats struct {
+	Name          string
+	Closed        bool
+	Published     uint64
+	Retained      int                 // Events held for late subscribers (0 without retention)
+	RetainedBytes int64               // Approximate memory held by retained events
+	Subscriptions []SubscriptionStats // Sorted by ID
+}
+
+// QueueDepths returns buffered event counts keyed by "bus:label".
+func (s BusStats) QueueDepths() map[string]int {
+	depths := make(map[string]int, len(s.Subscriptions))
+	for _, sub := range s.Subscriptions {
+		depths[s.Name+":"+sub.Label()] = sub.BufferLen
+	}
+	return depths
+}
+
+// StatsProvider is implemented by buses that expose introspection.
+type StatsProvider interface {
+	Stats() BusStats
+}
+
+// Stats returns a snapshot of the bus and all active subscriptions.
+func (b *InMemoryBus) Stats() BusStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	stats := BusStats{
+		Name:      b.name,
+		Closed:    b.closed,
+		Published: b.published.Load(),
+	}
+	if b.retention != nil {
+		stats.Retained = b.retention.Len()
+		stats.RetainedBytes = b.retention.Bytes()
+	}
+
+	stats.Subscriptions = make([]SubscriptionStats, 0, len(b.subscriptions))
+	for _, sub := range b.subscriptions {
+		stats.Subscriptions = append(stats.Subscriptions, sub.stats())
+	}
+	sortSubscriptionStats(stats.Subscriptions)
+
+	return stats
+}
+
+// Subscriptions returns a snapshot of all active subscriptions, sorted by ID.
+func (b *InMemoryBus) Subscriptions() []SubscriptionStats {
+	return b.Stats().Subscriptions
+}
+
+// Name returns the bus name used in metrics labels.
+func (b *InMemoryBus) Name() string {
+	return b.name
+}
+
+// stats captures the subscription's counters.
+func (s *inMemorySubscription) stats() SubscriptionStats {
+	st := SubscriptionStats{
+		ID:             s.id,
+		Name:           s.name,
+		Filter:         copyFilter(s.filter),
+		BufferCapacity: cap(s.ch),
+		BufferLen:      len(s.ch),
+		Delivered:      s.delivered.Load(),
+		Dropped:        s.dropped.Load(),
+		Blocked:        s.blocked.Load(),
+	}
+	if ns := s.lastDelivery.Load(); ns != 0 {
+		st.LastDelivery = time.Unix(0, ns)
+	}
+	return st
+}
+
+// copyFilter deep-copies a filter so callers cannot mutate live subscriptions.
+func copyFilter(f Filter) Filter {
+	out := Filter{
+		Types:   append([]string(nil), f.Types...),
+		Sources: append([]string(nil), f.Sources...),
+	}
+	if f.Metadata != nil {
+		out.Metadata = make(map[string]string, len(f.Metadata))
+		for k, v := range f.Metadata {
+			out.Metadata[k] = v
+		}
+	}
+	return out
+}
+
+// sortSubscriptionStats orders stats by numeric ID suffix, then lexically.
+func sortSubscriptionStats(stats []SubscriptionStats) {
+	sort.Slice(stats, func(i, j int) bool {
+		if len(stats[i].ID) != len(stats[j].ID) {
+			return len(stats[i].ID) < len(stats[j].ID)
+		}
+		return stats[i].ID < stats[j].ID
+	})
+}
@@ -6,6 +6,10 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/telemetry"
+	"github.com/BYTE-6D65/pipeline/pkg/trace"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func TestNewInMemoryBus(t *testing.T) {
@@ -42,6 +46,36 @@ func TestNewInMemoryBus_WithOptions(t *testing.T) {
 	}
 }
 
+func TestBus_SetBufferSize(t *testing.T) {
+	buses := map[string]interface {
+		Bus
+		SetBufferSize(int)
+		BufferSize() int
+	}{
+		"memory":  NewInMemoryBus(WithBufferSize(4)),
+		"sharded": NewShardedBus(2, WithBufferSize(4)),
+	}
+
+	for name, bus := range buses {
+		t.Run(name, func(t *testing.T) {
+			defer bus.Close()
+			before, _ := bus.Subscribe(context.Background(), Filter{})
+			bus.SetBufferSize(16)
+			after, _ := bus.Subscribe(context.Background(), Filter{})
+
+			if bus.BufferSize() != 16 {
+				t.Errorf("Expected buffer size 16, got %d", bus.BufferSize())
+			}
+			if c := cap(before.Events()); c != 4 {
+				t.Errorf("Expected existing subscription to keep 4, got %d", c)
+			}
+			if c := cap(after.Events()); c != 16 {
+				t.Errorf("Expected new subscription buffer 16, got %d", c)
+			}
+		})
+	}
+}
+
 func TestBus_Subscribe(t *testing.T) {
 	bus := NewInMemoryBus()
 	defer bus.Close()
@@ -428,6 +462,33 @@ func TestBus_PublishWithContextCancel(t *testing.T) {
 	}
 }
 
+func TestBus_PublishCancelledAfterDelivery(t *testing.T) {
+	bus := NewInMemoryBus(WithDropSlow(false), WithBufferSize(1))
+	defer bus.Close()
+
+	sub, _ := bus.Subscribe(context.Background(), Filter{})
+	defer sub.Close()
+	bus.Publish(context.Background(), &Event{ID: "fill", Type: "test"})
+
+	// Block the send on the full buffer, then cancel while it waits
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- bus.Publish(ctx, &Event{ID: "blocked", Type: "test"}) }()
+	for sub.(*inMemorySubscription).blocked.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-sub.Events()
+
+	// The send completed, so the event was delivered despite the cancel
+	if err := <-errc; err != nil {
+		t.Errorf("Expected nil once every subscriber got the event, got %v", err)
+	}
+	if got := fmt.Sprint(drainIDs(sub)); got != "[blocked]" {
+		t.Errorf("Expected the blocked event delivered, got %s", got)
+	}
+}
+
 func TestBus_ConcurrentPublish(t *testing.T) {
 	bus := NewInMemoryBus()
 	defer bus.Close()
@@ -612,3 +673,479 @@ func TestMatchesAny_Wildcard(t *testing.T) {
 		}
 	}
 }
+
+func TestBus_SubscriptionIDsAreUnique(t *testing.T) {
+	bus := NewInMemoryBus()
+	defer bus.Close()
+
+	ctx := context.Background()
+	first, _ := bus.Subscribe(ctx, Filter{})
+	second, _ := bus.Subscribe(ctx, Filter{})
+
+	// Closing a subscription must not let the next one reuse an ID
+	first.Close()
+	third, _ := bus.Subscribe(ctx, Filter{})
+
+	ids := map[string]bool{}
+	for _, st := range bus.Subscriptions() {
+		if ids[st.ID] {
+			t.Errorf("Duplicate subscription ID %s", st.ID)
+		}
+		ids[st.ID] = true
+	}
+
+	if len(ids) != 2 {
+		t.Errorf("Expected 2 active subscriptions, got %d", len(ids))
+	}
+	if second.(*inMemorySubscription).ID() == third.(*inMemorySubscription).ID() {
+		t.Error("New subscription reused an existing ID")
+	}
+}
+
+func TestBus_Stats(t *testing.T) {
+	bus := NewInMemoryBus(WithBufferSize(2), WithDropSlow(true), WithBusName("stats"))
+	defer bus.Close()
+
+	ctx := context.Background()
+	_, err := bus.SubscribeWithOptions(ctx, Filter{Types: []string{"test.*"}}, WithSubscriptionName("dashboard"))
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		bus.Publish(ctx, &Event{ID: fmt.Sprintf("evt-%d", i), Type: "test.event"})
+	}
+
+	stats := bus.Stats()
+	if stats.Name != "stats" || stats.Published != 3 {
+		t.Errorf("Unexpected bus stats: name=%s published=%d", stats.Name, stats.Published)
+	}
+	if len(stats.Subscriptions) != 1 {
+		t.Fatalf("Expected 1 subscription, got %d", len(stats.Subscriptions))
+	}
+
+	sub := stats.Subscriptions[0]
+	if sub.Name != "dashboard" {
+		t.Errorf("Expected name 'dashboard', got %q", sub.Name)
+	}
+	if sub.BufferCapacity != 2 || sub.BufferLen != 2 {
+		t.Errorf("Expected buffer 2/2, got %d/%d", sub.BufferLen, sub.BufferCapacity)
+	}
+	if sub.Delivered != 2 || sub.Dropped != 1 {
+		t.Errorf("Expected delivered=2 dropped=1, got %d/%d", sub.Delivered, sub.Dropped)
+	}
+	if sub.LastDelivery.IsZero() {
+		t.Error("LastDelivery should be set")
+	}
+	if len(sub.Filter.Types) != 1 || sub.Filter.Types[0] != "test.*" {
+		t.Errorf("Unexpected filter in stats: %+v", sub.Filter)
+	}
+
+	depths := stats.QueueDepths()
+	if depths["stats:"+sub.Label()] != 2 {
+		t.Errorf("Expected queue depth 2, got %v", depths)
+	}
+}
+
+func TestBus_SkipsExpiredEvents(t *testing.T) {
+	errBus := NewErrorBus(8)
+	defer errBus.Close()
+	errSub, _ := errBus.Subscribe(context.Background())
+
+	bus := NewInMemoryBus(
+		WithTTLPolicy(NewTTLPolicy().Set("stale.*", time.Nanosecond)),
+		WithErrorBus(errBus),
+	)
+	defer bus.Close()
+
+	ctx := context.Background()
+	sub, _ := bus.Subscribe(ctx, Filter{})
+
+	past := time.Now().Add(-time.Second)
+	bus.Publish(ctx, &Event{ID: "expired", Type: "fresh.event", Timestamp: past, TTL: time.Millisecond})
+	bus.Publish(ctx, &Event{ID: "policy", Type: "stale.event", Timestamp: past})
+	bus.Publish(ctx, &Event{ID: "fresh", Type: "fresh.event", Timestamp: time.Now(), TTL: time.Hour})
+
+	if got := len(sub.Events()); got != 1 {
+		t.Fatalf("Expected only the fresh event to be delivered, got %d", got)
+	}
+	if evt := <-sub.Events(); evt.ID != "fresh" {
+		t.Errorf("Expected fresh event, got %s", evt.ID)
+	}
+
+	if got := len(errSub.Events()); got != 2 {
+		t.Errorf("Expected 2 expiry reports, got %d", got)
+	}
+	if errEvt := <-errSub.Events(); errEvt.Code != CodeEventExpired {
+		t.Errorf("Expected %s, got %s", CodeEventExpired, errEvt.Code)
+	}
+}
+
+func TestBus_ExpiryClock(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bus := NewInMemoryBus(
+		WithTTLPolicy(NewTTLPolicy().Set("input.*", time.Second)),
+		WithExpiryClock(func() time.Time { return now }),
+	)
+	defer bus.Close()
+
+	ctx := context.Background()
+	sub, _ := bus.Subscribe(ctx, Filter{})
+
+	// Unstamped events get the bus time before the policy TTL applies
+	bus.Publish(ctx, &Event{ID: "evt-1", Type: "input.key"})
+	evt := <-sub.Events()
+	if !evt.Timestamp.Equal(now) || evt.TTL != time.Second {
+		t.Fatalf("Expected stamp %v with 1s TTL, got %v/%s", now, evt.Timestamp, evt.TTL)
+	}
+
+	// Expiry follows the injected clock, not wall time
+	now = now.Add(2 * time.Second)
+	bus.Publish(ctx, &Event{ID: "evt-2", Type: "input.key", Timestamp: evt.Timestamp})
+	if got := len(sub.Events()); got != 0 {
+		t.Errorf("Expected the event stale on the bus clock skipped, got %d delivered", got)
+	}
+}
+
+func TestBus_PublishLeavesCallerEvent(t *testing.T) {
+	ctx := ContextWithEvent(context.Background(), &Event{ID: "parent"})
+
+	// The same event published to buses with different TTL policies at once
+	evt := &Event{ID: "evt-1", Type: "input.key"}
+	var wg sync.WaitGroup
+	for _, ttl := range []time.Duration{time.Second, time.Minute} {
+		bus := NewInMemoryBus(WithTTLPolicy(NewTTLPolicy().Set("input.*", ttl)))
+		defer bus.Close()
+		sub, err := bus.Subscribe(ctx, Filter{})
+		if err != nil {
+			t.Fatalf("Subscribe failed: %v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bus.Publish(ctx, evt)
+			received := <-sub.Events()
+			if received.TTL != ttl || received.Timestamp.IsZero() || received.CausationID != "parent" {
+				t.Errorf("Expected the delivered copy enriched with a %s TTL, got %+v", ttl, received)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if evt.TTL != 0 || !evt.Timestamp.IsZero() || evt.CausationID != "" {
+		t.Errorf("Expected the caller's event untouched, got %+v", evt)
+	}
+}
+
+func TestBus_CausationFromContext(t *testing.T) {
+	bus := NewInMemoryBus()
+	defer bus.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handler publishes a follow-up for every "order.created"
+	_, err := SubscribeWithHandler(ctx, bus, Filter{Types: []string{"order.created"}}, func(hctx context.Context, evt *Event) {
+		bus.Publish(hctx, &Event{ID: "follow-up", Type: "order.billed"})
+	})
+	if err != nil {
+		t.Fatalf("SubscribeWithHandler failed: %v", err)
+	}
+
+	billed, _ := bus.Subscribe(ctx, Filter{Types: []string{"order.billed"}})
+
+	bus.Publish(ctx, &Event{ID: "root", Type: "order.created"})
+
+	select {
+	case evt := <-billed.Events():
+		if evt.CausationID != "root" {
+			t.Errorf("Expected CausationID 'root', got %q", evt.CausationID)
+		}
+		if evt.CorrelationID != "root" {
+			t.Errorf("Expected CorrelationID 'root', got %q", evt.CorrelationID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Follow-up event not received")
+	}
+}
+
+func TestApplyCausation_KeepsExplicitIDs(t *testing.T) {
+	parent := &Event{ID: "parent", CorrelationID: "workflow-1"}
+	ctx := ContextWithEvent(context.Background(), parent)
+
+	inherited := &Event{ID: "child"}
+	ApplyCausation(ctx, inherited)
+	if inherited.CausationID != "parent" || inherited.CorrelationID != "workflow-1" {
+		t.Errorf("Unexpected inherited IDs: cause=%q corr=%q", inherited.CausationID, inherited.CorrelationID)
+	}
+
+	explicit := &Event{ID: "other", CausationID: "manual", CorrelationID: "manual-corr"}
+	ApplyCausation(ctx, explicit)
+	if explicit.CausationID != "manual" || explicit.CorrelationID != "manual-corr" {
+		t.Error("Explicit IDs should not be overwritten")
+	}
+}
+
+func TestBus_TracingSpans(t *testing.T) {
+	exporter := trace.NewInMemoryExporter()
+	tracer := trace.NewTracer(exporter)
+	bus := NewInMemoryBus(WithTracer(tracer))
+	defer bus.Close()
+
+	ctx := context.Background()
+	sub, err := bus.Subscribe(ctx, Filter{})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	// Event arrives with an upstream trace context
+	upstream := trace.SpanContext{TraceID: trace.NewTraceID(), SpanID: trace.NewSpanID(), Flags: trace.FlagSampled}
+	evt := &Event{ID: "evt-1", Type: "test.trace", Metadata: map[string]string{
+		trace.TraceparentKey: upstream.Traceparent(),
+	}}
+	if err := bus.Publish(ctx, evt); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	received := <-sub.Events()
+	sc, ok := trace.Extract(received.Metadata)
+	if !ok {
+		t.Fatal("Expected traceparent on delivered event")
+	}
+	if sc.TraceID != upstream.TraceID {
+		t.Errorf("Expected trace ID %s, got %s", upstream.TraceID, sc.TraceID)
+	}
+	if received.Metadata[trace.EnqueuedAtKey] == "" {
+		t.Error("Expected enqueued_at on delivered event")
+	}
+
+	if err := tracer.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	spans := exporter.ByTrace(upstream.TraceID)
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans (publish, filter, deliver), got %d", len(spans))
+	}
+
+	var publish trace.Span
+	for _, s := range spans {
+		if s.Kind == trace.SpanKindProducer {
+			publish = s
+		}
+	}
+	if publish.Parent != upstream.SpanID {
+		t.Errorf("Publish span should be a child of the upstream span")
+	}
+	if publish.Context.SpanID != sc.SpanID {
+		t.Errorf("Delivered traceparent should reference the publish span")
+	}
+	for _, s := range spans {
+		if s.Kind == trace.SpanKindProducer {
+			continue
+		}
+		if s.Parent != publish.Context.SpanID {
+			t.Errorf("Span %q should be a child of the publish span", s.Name)
+		}
+		if s.Name == trace.StageDeliver && s.Attributes["result"] != "success" {
+			t.Errorf("Expected deliver result success, got %q", s.Attributes["result"])
+		}
+	}
+}
+
+func TestBus_TracingLeavesCallerMetadata(t *testing.T) {
+	tracer := trace.NewTracer(trace.NewInMemoryExporter())
+	ctx := context.Background()
+
+	// The same event published to two traced buses at once
+	evt := &Event{ID: "evt-1", Type: "test.trace", Metadata: map[string]string{"tenant": "a"}}
+	var wg sync.WaitGroup
+	for range 2 {
+		bus := NewInMemoryBus(WithTracer(tracer))
+		defer bus.Close()
+		sub, err := bus.Subscribe(ctx, Filter{})
+		if err != nil {
+			t.Fatalf("Subscribe failed: %v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := bus.Publish(ctx, evt); err != nil {
+				t.Errorf("Publish failed: %v", err)
+			}
+			received := <-sub.Events()
+			if _, ok := trace.Extract(received.Metadata); !ok || received.Metadata["tenant"] != "a" {
+				t.Errorf("Expected traceparent and caller metadata on delivery, got %v", received.Metadata)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(evt.Metadata) != 1 {
+		t.Errorf("Expected caller metadata untouched, got %v", evt.Metadata)
+	}
+}
+
+func TestBus_DrainWaitsForSubscribers(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		bus  interface {
+			Bus
+			Drainer
+		}
+	}{
+		{"in-memory", NewInMemoryBus(WithMetrics(nil))},
+		{"sharded", NewShardedBus(2, WithMetrics(nil))},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			sub, _ := tc.bus.Subscribe(ctx, Filter{})
+			for i := 0; i < 5; i++ {
+				tc.bus.Publish(ctx, &Event{ID: fmt.Sprintf("evt-%d", i), Type: "test"})
+			}
+
+			// Slow consumer keeps reading while the bus drains
+			received := make(chan int)
+			go func() {
+				n := 0
+				for range sub.Events() {
+					n++
+					time.Sleep(time.Millisecond)
+				}
+				received <- n
+			}()
+
+			drainCtx, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+			report, err := tc.bus.Drain(drainCtx)
+			if err != nil {
+				t.Fatalf("Drain failed: %v", err)
+			}
+			if report.Total() != 0 {
+				t.Errorf("Expected nothing undelivered, got %v", report.Undelivered)
+			}
+			if n := <-received; n != 5 {
+				t.Errorf("Expected 5 events consumed, got %d", n)
+			}
+			if err := tc.bus.Publish(ctx, &Event{ID: "late"}); err == nil {
+				t.Error("Publish after drain should fail")
+			}
+		})
+	}
+}
+
+func TestBus_DrainReportsUndelivered(t *testing.T) {
+	bus := NewInMemoryBus(WithMetrics(nil))
+	ctx := context.Background()
+	sub, _ := bus.SubscribeWithOptions(ctx, Filter{}, WithSubscriptionName("stuck"))
+	for i := 0; i < 3; i++ {
+		bus.Publish(ctx, &Event{ID: fmt.Sprintf("evt-%d", i), Type: "test"})
+	}
+
+	drainCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	report, err := bus.Drain(drainCtx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+	if report.Total() != 3 {
+		t.Errorf("Expected 3 undelivered, got %d", report.Total())
+	}
+	label := sub.(*inMemorySubscription).id + "/stuck"
+	if report.Undelivered[label] != 3 {
+		t.Errorf("Expected undelivered keyed by %q, got %v", label, report.Undelivered)
+	}
+	if !bus.Stats().Closed {
+		t.Error("Bus should be closed after drain")
+	}
+}
+
+func TestBus_PublishWhileDraining(t *testing.T) {
+	metrics := telemetry.Default()
+	bus := NewInMemoryBus(WithMetrics(metrics), WithBusName("draining-test"))
+	bus.draining.Store(true)
+	if err := bus.Publish(context.Background(), &Event{ID: "evt-1", Type: "test"}); err != ErrBusDraining {
+		t.Errorf("Expected ErrBusDraining, got %v", err)
+	}
+
+	// Rejected events are not counted as published
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "pipeline_events_published_total" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetValue() == "draining-test" {
+					t.Errorf("Expected no published count for a rejected event, got %v", m.GetCounter().GetValue())
+				}
+			}
+		}
+	}
+}
+
+func TestBus_SubscriptionMetricsDeletedOnClose(t *testing.T) {
+	// seriesFor counts buffer-size series labelled with a bus and subscription
+	seriesFor := func(bus, name string) int {
+		families, err := prometheus.DefaultGatherer.Gather()
+		if err != nil {
+			t.Fatalf("Gather failed: %v", err)
+		}
+		n := 0
+		for _, family := range families {
+			if family.GetName() != "pipeline_subscription_buffer_size" {
+				continue
+			}
+			for _, m := range family.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range m.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				if labels["bus"] == bus && labels["subscription"] == name {
+					n++
+				}
+			}
+		}
+		return n
+	}
+
+	metrics := telemetry.Default()
+	for _, tc := range []struct {
+		name string
+		bus  interface {
+			Bus
+			OptionSubscriber
+		}
+	}{
+		{"in-memory", NewInMemoryBus(WithMetrics(metrics), WithBusName("sub-metrics-in-memory"))},
+		{"sharded", NewShardedBus(2, WithMetrics(metrics), WithBusName("sub-metrics-sharded"))},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			busName := "sub-metrics-" + tc.name
+			ctx := context.Background()
+			first, _ := tc.bus.SubscribeWithOptions(ctx, Filter{}, WithSubscriptionName("orders"))
+			second, _ := tc.bus.SubscribeWithOptions(ctx, Filter{}, WithSubscriptionName("orders"))
+			other, _ := tc.bus.SubscribeWithOptions(ctx, Filter{}, WithSubscriptionName("audit"))
+			if got := seriesFor(busName, "orders"); got != 1 {
+				t.Fatalf("Expected one series shared by name, got %d", got)
+			}
+
+			first.Close()
+			if got := seriesFor(busName, "orders"); got != 1 {
+				t.Errorf("Expected the series kept while a subscription uses the name, got %d", got)
+			}
+			second.Close()
+			if got := seriesFor(busName, "orders"); got != 0 {
+				t.Errorf("Expected the series deleted with the last subscription, got %d", got)
+			}
+
+			tc.bus.Close()
+			if got := seriesFor(busName, "audit"); got != 0 {
+				t.Errorf("Expected the series deleted when the bus closes, got %d", got)
+			}
+			other.Close()
+		})
+	}
+}
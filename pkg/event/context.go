@@ -0,0 +1,79 @@
+package event
+
+import "context"
+
+// eventContextKey is the context key for the event currently being handled.
+type eventContextKey struct{}
+
+// ContextWithEvent returns a context carrying evt as the event being handled.
+// Publishes made with this context inherit causation and correlation from evt.
+func ContextWithEvent(ctx context.Context, evt *Event) context.Context {
+	return context.WithValue(ctx, eventContextKey{}, evt)
+}
+
+// EventFromContext returns the event being handled, if any.
+func EventFromContext(ctx context.Context) (*Event, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	evt, ok := ctx.Value(eventContextKey{}).(*Event)
+	return evt, ok && evt != nil
+}
+
+// ApplyCausation fills empty CausationID and CorrelationID on evt from the
+// event carried by ctx:
+//   - CausationID = parent.ID
+//   - CorrelationID = parent.CorrelationID, or parent.ID if the parent
+//     started the workflow
+//
+// Explicitly set IDs are never overwritten, and an event is never made its
+// own cause.
+func ApplyCausation(ctx context.Context, evt *Event) {
+	parent, ok := EventFromContext(ctx)
+	if !ok || parent == evt || parent.ID == evt.ID {
+		return
+	}
+
+	if evt.CausationID == "" {
+		evt.CausationID = parent.ID
+	}
+	if evt.CorrelationID == "" {
+		if parent.CorrelationID != "" {
+			evt.CorrelationID = parent.CorrelationID
+		} else {
+			evt.CorrelationID = parent.ID
+		}
+	}
+}
+
+// EventHandler processes a delivered event. ctx carries the event, so any
+// follow-up Publish made with it is linked to the event automatically.
+type EventHandler func(ctx context.Context, evt *Event)
+
+// SubscribeWithHandler subscribes to bus and runs handler for each matching
+// event in a background goroutine. The goroutine stops and the subscription is
+// closed when ctx is cancelled or the bus closes the subscription.
+func SubscribeWithHandler(ctx context.Context, bus Bus, filter Filter, handler EventHandler) (Subscription, error) {
+	sub, err := bus.Subscribe(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer sub.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt, ok := <-sub.Events():
+				if !ok {
+					return
+				}
+				handler(ContextWithEvent(ctx, evt), evt)
+			}
+		}
+	}()
+
+	return sub, nil
+}
@@ -0,0 +1,297 @@
+package event
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/BYTE-6D65/pipeline/pkg/telemetry"
+)
+
+// DedupKeyFunc extracts the deduplication key from an event.
+// Returning an empty string exempts the event from deduplication.
+type DedupKeyFunc func(evt *Event) string
+
+// DedupByID uses the event ID as the deduplication key (default).
+func DedupByID(evt *Event) string {
+	return evt.ID
+}
+
+// DedupByMetadata uses the given metadata key (e.g., "idempotency_key") as the
+// deduplication key. Events without the key are never treated as duplicates.
+func DedupByMetadata(key string) DedupKeyFunc {
+	return func(evt *Event) string {
+		return evt.Metadata[key]
+	}
+}
+
+// Deduplicator remembers recently seen event keys in a bounded window and
+// reports repeats. Adapters that reconnect or retry can publish the same event
+// twice; the deduplicator catches these before they reach subscribers.
+//
+// The window is bounded both by time (entries older than window are forgotten)
+// and by size (the oldest entries are evicted once maxSize is reached).
+// Time is read from the injected clock, so tests can drive it with DeltaClock.
+//
+// On a bus, a key is only remembered once the publish succeeds, so a retry
+// of an event whose delivery failed is not suppressed. While a publish is in
+// flight, the same key published concurrently counts as a duplicate.
+//
+// Usage as a bus option:
+//
+//	dedup := event.NewDeduplicator(event.WithDedupWindow(time.Minute))
+//	bus := event.NewInMemoryBus(event.WithDeduplicator(dedup))
+//
+// Usage as a standalone filter:
+//
+//	if dedup.IsDuplicate(evt) {
+//	    continue
+//	}
+type Deduplicator struct {
+	window  time.Duration
+	maxSize int
+	keyFunc DedupKeyFunc
+	clock   clock.Clock
+
+	errorBus *ErrorBus          // Optional: report duplicates as debug events
+	metrics  *telemetry.Metrics // Optional: count duplicates
+	name     string             // Metrics label (usually the bus name)
+
+	mu      sync.Mutex
+	seen    map[string]clock.MonoTime
+	pending map[string]struct{} // Keys of publishes in flight (bus only)
+	order   []dedupEntry        // FIFO of keys in insertion order
+	head    int                 // Index of the oldest live entry in order
+
+	duplicates uint64
+}
+
+// dedupEntry records when a key was first seen, for window eviction.
+type dedupEntry struct {
+	key  string
+	seen clock.MonoTime
+}
+
+// DedupOption configures a Deduplicator.
+type DedupOption func(*Deduplicator)
+
+// WithDedupWindow sets how long a key is remembered.
+func WithDedupWindow(window time.Duration) DedupOption {
+	return func(d *Deduplicator) {
+		d.window = window
+	}
+}
+
+// WithDedupMaxSize caps the number of remembered keys.
+func WithDedupMaxSize(size int) DedupOption {
+	return func(d *Deduplicator) {
+		d.maxSize = size
+	}
+}
+
+// WithDedupKey sets the function used to derive the deduplication key.
+func WithDedupKey(fn DedupKeyFunc) DedupOption {
+	return func(d *Deduplicator) {
+		d.keyFunc = fn
+	}
+}
+
+// WithDedupClock sets the clock used for window expiry.
+func WithDedupClock(clk clock.Clock) DedupOption {
+	return func(d *Deduplicator) {
+		d.clock = clk
+	}
+}
+
+// WithDedupErrorBus reports each duplicate as a DebugSeverity error event.
+func WithDedupErrorBus(bus *ErrorBus) DedupOption {
+	return func(d *Deduplicator) {
+		d.errorBus = bus
+	}
+}
+
+// WithDedupMetrics sets the metrics instance and label used for duplicate counts.
+func WithDedupMetrics(metrics *telemetry.Metrics, name string) DedupOption {
+	return func(d *Deduplicator) {
+		d.metrics = metrics
+		d.name = name
+	}
+}
+
+// NewDeduplicator creates a deduplicator with the given options.
+//
+// Defaults:
+//   - window: 1 minute
+//   - maxSize: 10000 keys
+//   - key: event ID
+//   - clock: SystemClock
+func NewDeduplicator(opts ...DedupOption) *Deduplicator {
+	d := &Deduplicator{
+		window:  time.Minute,
+		maxSize: 10000,
+		keyFunc: DedupByID,
+		name:    "dedup",
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	if d.clock == nil {
+		d.clock = clock.NewSystemClock()
+	}
+	if d.keyFunc == nil {
+		d.keyFunc = DedupByID
+	}
+	if d.maxSize <= 0 {
+		d.maxSize = 10000
+	}
+
+	d.seen = make(map[string]clock.MonoTime)
+	d.pending = make(map[string]struct{})
+	return d
+}
+
+// IsDuplicate records the event's key and reports whether it was already seen
+// within the window. Events with an empty key are never duplicates.
+//
+// Thread-safe: Can be called concurrently from multiple publishers.
+func (d *Deduplicator) IsDuplicate(evt *Event) bool {
+	key, dup := d.reserve(evt)
+	if !dup {
+		d.commit(key, true)
+	}
+	return dup
+}
+
+// reserve reports whether the event is a duplicate of a remembered or in-flight
+// key. Otherwise its key is held as in flight until commit. Returns an empty
+// key for events exempt from deduplication.
+func (d *Deduplicator) reserve(evt *Event) (string, bool) {
+	key := d.keyFunc(evt)
+	if key == "" {
+		return "", false
+	}
+
+	now := d.clock.Now()
+
+	d.mu.Lock()
+	d.evictExpired(now)
+
+	first, dup := d.seen[key]
+	_, inFlight := d.pending[key]
+	if !dup && !inFlight {
+		d.pending[key] = struct{}{}
+		d.mu.Unlock()
+		return key, false
+	}
+
+	d.duplicates++
+	d.mu.Unlock()
+
+	if d.metrics != nil {
+		d.metrics.EventsDeduplicated.WithLabelValues(d.name, evt.Type).Inc()
+	}
+
+	if d.errorBus != nil {
+		report := NewErrorEvent(
+			DebugSeverity,
+			CodeDuplicateEvent,
+			"dedup:"+d.name,
+			fmt.Sprintf("Duplicate event suppressed (type=%s)", evt.Type),
+		).WithContext("key", key).
+			WithContext("event_id", evt.ID).
+			WithContext("source", evt.Source)
+		if dup {
+			report = report.WithContext("first_seen_ago", d.clock.Since(first).String())
+		} else {
+			report = report.WithContext("in_flight", true)
+		}
+		d.errorBus.Publish(report)
+	}
+
+	return key, true
+}
+
+// commit releases a key held by reserve, remembering it if the event was
+// delivered. No-op for an empty key.
+func (d *Deduplicator) commit(key string, delivered bool) {
+	if key == "" {
+		return
+	}
+	now := d.clock.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.pending, key)
+	if !delivered {
+		return
+	}
+	d.seen[key] = now
+	d.order = append(d.order, dedupEntry{key: key, seen: now})
+	d.evictOverflow()
+}
+
+// evictExpired drops entries older than the window (assumes lock is held).
+func (d *Deduplicator) evictExpired(now clock.MonoTime) {
+	cutoff := now - clock.FromDuration(d.window)
+	for d.head < len(d.order) && d.order[d.head].seen <= cutoff {
+		d.dropHead()
+	}
+	d.compact()
+}
+
+// evictOverflow drops the oldest entries beyond maxSize (assumes lock is held).
+func (d *Deduplicator) evictOverflow() {
+	for len(d.seen) > d.maxSize && d.head < len(d.order) {
+		d.dropHead()
+	}
+	d.compact()
+}
+
+// dropHead forgets the oldest entry (assumes lock is held).
+func (d *Deduplicator) dropHead() {
+	entry := d.order[d.head]
+	d.order[d.head] = dedupEntry{}
+	d.head++
+
+	// Only delete if the map still points at this entry
+	if seen, ok := d.seen[entry.key]; ok && seen == entry.seen {
+		delete(d.seen, entry.key)
+	}
+}
+
+// compact reclaims the consumed prefix of the FIFO once it dominates the slice.
+func (d *Deduplicator) compact() {
+	if d.head > 0 && d.head >= len(d.order)/2 {
+		n := copy(d.order, d.order[d.head:])
+		d.order = d.order[:n]
+		d.head = 0
+	}
+}
+
+// Len returns the number of keys currently remembered.
+func (d *Deduplicator) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.seen)
+}
+
+// DuplicateCount returns the total number of duplicates detected.
+func (d *Deduplicator) DuplicateCount() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.duplicates
+}
+
+// Reset forgets all remembered and in-flight keys. A publish in flight
+// during Reset still remembers its key once delivered.
+func (d *Deduplicator) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.seen = make(map[string]clock.MonoTime)
+	d.pending = make(map[string]struct{})
+	d.order = nil
+	d.head = 0
+}
@@ -0,0 +1,165 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+)
+
+func TestDeduplicator_DetectsDuplicateID(t *testing.T) {
+	d := NewDeduplicator()
+
+	evt := &Event{ID: "evt-1", Type: "test.event"}
+
+	if d.IsDuplicate(evt) {
+		t.Error("First occurrence should not be a duplicate")
+	}
+	if !d.IsDuplicate(evt) {
+		t.Error("Second occurrence should be a duplicate")
+	}
+	if d.DuplicateCount() != 1 {
+		t.Errorf("Expected 1 duplicate, got %d", d.DuplicateCount())
+	}
+}
+
+func TestDeduplicator_WindowExpiry(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	clk.Load(0, []time.Duration{5 * time.Second, 6 * time.Second})
+
+	d := NewDeduplicator(WithDedupWindow(10*time.Second), WithDedupClock(clk))
+	evt := &Event{ID: "evt-1", Type: "test.event"}
+
+	d.IsDuplicate(evt)
+
+	clk.Advance() // t=5s, still inside window
+	if !d.IsDuplicate(evt) {
+		t.Error("Expected duplicate inside window")
+	}
+
+	clk.Advance() // t=11s, first sighting expired
+	if d.IsDuplicate(evt) {
+		t.Error("Expected key to be forgotten after window")
+	}
+}
+
+func TestDeduplicator_MaxSize(t *testing.T) {
+	d := NewDeduplicator(WithDedupMaxSize(2))
+
+	d.IsDuplicate(&Event{ID: "a"})
+	d.IsDuplicate(&Event{ID: "b"})
+	d.IsDuplicate(&Event{ID: "c"}) // Evicts "a"
+
+	if d.Len() != 2 {
+		t.Errorf("Expected 2 remembered keys, got %d", d.Len())
+	}
+	if d.IsDuplicate(&Event{ID: "a"}) {
+		t.Error("Evicted key should not be reported as duplicate")
+	}
+	if !d.IsDuplicate(&Event{ID: "c"}) {
+		t.Error("Recent key should still be reported as duplicate")
+	}
+}
+
+func TestDeduplicator_ResetForgetsInFlight(t *testing.T) {
+	d := NewDeduplicator()
+	evt := &Event{ID: "evt-1", Type: "test.event"}
+
+	key, _ := d.reserve(evt) // Publish still in flight
+	d.Reset()
+	if _, dup := d.reserve(evt); dup {
+		t.Error("Expected an in-flight key forgotten by Reset")
+	}
+
+	// The original publish still completes normally
+	d.commit(key, true)
+	if !d.IsDuplicate(evt) {
+		t.Error("Expected the delivered key remembered after Reset")
+	}
+}
+
+func TestDeduplicator_MetadataKey(t *testing.T) {
+	d := NewDeduplicator(WithDedupKey(DedupByMetadata("idempotency_key")))
+
+	first := (&Event{ID: "1"}).WithMetadata("idempotency_key", "order-42")
+	retry := (&Event{ID: "2"}).WithMetadata("idempotency_key", "order-42")
+	unkeyed := &Event{ID: "3"}
+
+	if d.IsDuplicate(first) {
+		t.Error("First occurrence should not be a duplicate")
+	}
+	if !d.IsDuplicate(retry) {
+		t.Error("Retry with same idempotency key should be a duplicate")
+	}
+	if d.IsDuplicate(unkeyed) || d.IsDuplicate(unkeyed) {
+		t.Error("Events without the key should never be duplicates")
+	}
+}
+
+func TestDeduplicator_ReportsToErrorBus(t *testing.T) {
+	errBus := NewErrorBus(8)
+	defer errBus.Close()
+
+	sub, _ := errBus.Subscribe(context.Background())
+
+	d := NewDeduplicator(WithDedupErrorBus(errBus))
+	evt := &Event{ID: "evt-1", Type: "test.event"}
+	d.IsDuplicate(evt)
+	d.IsDuplicate(evt)
+
+	select {
+	case errEvt := <-sub.Events():
+		if errEvt.Code != CodeDuplicateEvent {
+			t.Errorf("Expected code %s, got %s", CodeDuplicateEvent, errEvt.Code)
+		}
+		if errEvt.Severity != DebugSeverity {
+			t.Errorf("Expected DEBUG severity, got %s", errEvt.Severity)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Expected duplicate to be reported on error bus")
+	}
+}
+
+func TestBus_WithDeduplicator(t *testing.T) {
+	bus := NewInMemoryBus(WithDeduplicator(NewDeduplicator()))
+	defer bus.Close()
+
+	ctx := context.Background()
+	sub, err := bus.Subscribe(ctx, Filter{})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	evt := &Event{ID: "evt-1", Type: "test.event"}
+	bus.Publish(ctx, evt)
+	bus.Publish(ctx, evt)
+
+	if got := len(sub.Events()); got != 1 {
+		t.Errorf("Expected 1 delivered event, got %d", got)
+	}
+}
+
+func TestBus_DeduplicatorRemembersDeliveredOnly(t *testing.T) {
+	dedup := NewDeduplicator()
+	bus := NewInMemoryBus(WithDeduplicator(dedup))
+	defer bus.Close()
+
+	ctx := context.Background()
+	sub, err := bus.Subscribe(ctx, Filter{})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	// Expired on publish: not delivered, so a retry is not a duplicate
+	bus.Publish(ctx, (&Event{ID: "evt-1", Type: "test.event"}).WithDeadline(time.Now().Add(-time.Second)))
+	if dedup.Len() != 0 {
+		t.Fatalf("Expected undelivered key forgotten, remembering %d", dedup.Len())
+	}
+	bus.Publish(ctx, &Event{ID: "evt-1", Type: "test.event"})
+
+	if got := len(sub.Events()); got != 1 || dedup.DuplicateCount() != 0 {
+		t.Errorf("Expected the retry delivered, got %d events and %d duplicates", got, dedup.DuplicateCount())
+	}
+}
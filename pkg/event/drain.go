@@ -0,0 +1,96 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrBusDraining is returned by Publish once Drain has started.
+var ErrBusDraining = errors.New("bus is draining")
+
+// drainPollInterval is how often Drain checks subscription buffers.
+const drainPollInterval = time.Millisecond
+
+// DrainReport describes what a bus had left when Drain finished.
+type DrainReport struct {
+	Bus         string
+	Duration    time.Duration
+	Undelivered map[string]int // Events still buffered, keyed by subscription label
+}
+
+// Total returns the number of events left undelivered.
+func (r DrainReport) Total() int {
+	total := 0
+	for _, n := range r.Undelivered {
+		total += n
+	}
+	return total
+}
+
+// Labels returns the subscriptions with undelivered events, sorted.
+func (r DrainReport) Labels() []string {
+	labels := make([]string, 0, len(r.Undelivered))
+	for label := range r.Undelivered {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// Drainer is implemented by buses that support graceful draining.
+type Drainer interface {
+	// Drain stops accepting publishes, waits until subscribers have consumed
+	// their buffered events or ctx is done, then closes the bus.
+	Drain(ctx context.Context) (DrainReport, error)
+}
+
+// Drain stops accepting publishes (Publish returns ErrBusDraining), lets
+// subscribers consume what is already buffered until ctx is done, then closes
+// the bus. The report lists events still buffered at that point; the error is
+// ctx.Err() if the deadline was reached with events outstanding.
+func (b *InMemoryBus) Drain(ctx context.Context) (DrainReport, error) {
+	b.draining.Store(true)
+	report, err := waitDrained(ctx, b.Stats)
+	b.Close()
+	return report, err
+}
+
+// Drain stops accepting publishes, lets subscribers consume what is already
+// buffered until ctx is done, then closes the bus. See InMemoryBus.Drain.
+func (b *ShardedBus) Drain(ctx context.Context) (DrainReport, error) {
+	b.base.draining.Store(true)
+	report, err := waitDrained(ctx, b.Stats)
+	b.Close()
+	return report, err
+}
+
+// waitDrained polls subscription buffers until all are empty or ctx is done.
+func waitDrained(ctx context.Context, stats func() BusStats) (DrainReport, error) {
+	start := time.Now()
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		st := stats()
+		report := DrainReport{Bus: st.Name, Undelivered: make(map[string]int)}
+		for _, sub := range st.Subscriptions {
+			if sub.BufferLen > 0 {
+				report.Undelivered[sub.Label()] = sub.BufferLen
+			}
+		}
+
+		if len(report.Undelivered) == 0 {
+			report.Duration = time.Since(start)
+			return report, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			report.Duration = time.Since(start)
+			return report, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,6 +2,7 @@ package event
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -63,6 +64,25 @@ func (s ErrorSeverity) String() string {
 	}
 }
 
+// ParseErrorSeverity parses a severity name as returned by String
+// (case-insensitive, "WARN" accepted for WARNING).
+func ParseErrorSeverity(name string) (ErrorSeverity, error) {
+	switch strings.ToUpper(name) {
+	case "DEBUG":
+		return DebugSeverity, nil
+	case "INFO":
+		return InfoSeverity, nil
+	case "WARNING", "WARN":
+		return WarningSeverity, nil
+	case "ERROR":
+		return Error, nil
+	case "CRITICAL":
+		return CriticalSeverity, nil
+	default:
+		return DebugSeverity, fmt.Errorf("unknown severity %q", name)
+	}
+}
+
 // ControlSignal represents a control intent separate from severity.
 // Allows routing decisions without string-matching messages.
 type ControlSignal int
@@ -101,6 +121,16 @@ func (s ControlSignal) String() string {
 	}
 }
 
+// ParseControlSignal parses a signal name as returned by String (case-insensitive).
+func ParseControlSignal(name string) (ControlSignal, error) {
+	for s := SignalNone; s <= SignalRecovered; s++ {
+		if strings.EqualFold(s.String(), name) {
+			return s, nil
+		}
+	}
+	return SignalNone, fmt.Errorf("unknown control signal %q", name)
+}
+
 // Error Code Constants
 //
 // These are terse, refactor-stable codes for common error conditions.
@@ -110,6 +140,8 @@ const (
 	CodeMemPressure     = "MEM_PRESSURE"      // Memory usage approaching limit
 	CodeMemRelief       = "MEM_RELIEF"        // Memory pressure relieved
 	CodeMemCritical     = "MEM_CRITICAL"      // Critical memory pressure
+	CodeGCForced        = "GC_FORCED"         // Forced GC executed
+	CodeGCTuned         = "GC_TUNED"          // GC percent changed
 	CodePSIPreOOM       = "PSI_PRE_OOM"       // PSI pre-OOM warning
 	CodeOOMImminent     = "OOM_IMMINENT"      // OOM likely within seconds
 	CodeDegradedMode    = "DEGRADED_MODE"     // Entered degraded mode
@@ -124,6 +156,8 @@ const (
 	CodeDropSlow        = "DROP_SLOW"         // Event dropped (slow subscriber)
 	CodeDropRED         = "DROP_RED"          // Event dropped (RED algorithm)
 	CodeDropFull        = "DROP_FULL"         // Event dropped (queue full)
+	CodeDuplicateEvent  = "DUPLICATE_EVENT"   // Duplicate event suppressed
+	CodeEventExpired    = "EVENT_EXPIRED"     // Stale event skipped (TTL elapsed)
 
 	// Component Failures
 	CodeAdapterFail     = "ADAPTER_FAIL"      // Adapter encountered error
@@ -144,10 +178,17 @@ const (
 	CodeWorkerIdle      = "WORKER_IDLE"       // Worker pool idle
 	CodeWorkerSaturated = "WORKER_SATURATED"  // Worker pool saturated
 
+	// Scheduling
+	CodeScheduleFail    = "SCHEDULE_FAIL"     // Scheduled publish failed
+
 	// System Health
 	CodeHealthCheck     = "HEALTH_CHECK"      // Periodic health check
 	CodePanic           = "PANIC"             // Panic recovered
 	CodeShutdown        = "SHUTDOWN"          // Graceful shutdown initiated
+	CodeDrainIncomplete = "DRAIN_INCOMPLETE"  // Events left buffered at shutdown
+
+	// Alerting
+	CodeAlertFired      = "ALERT_FIRED"       // Alert rule condition met
 )
 
 // NewErrorEvent creates an error event with timestamp set to now.
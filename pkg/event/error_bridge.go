@@ -0,0 +1,321 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrorRecordSchema is the version of the ErrorRecord JSON schema. It is
+// bumped only for incompatible changes; fields may be added without a bump.
+const ErrorRecordSchema = 1
+
+// ErrorRecord is the stable JSON representation of an ErrorEvent, used by
+// JSONLSink and as the payload of bridged "system.error.<code>" events:
+//
+//	{"schema":1,"time":"2025-01-01T15:04:05Z","severity":"WARNING",
+//	 "code":"MEM_PRESSURE","component":"monitor:memory","message":"Memory usage high",
+//	 "signal":"THROTTLE","recoverable":true,"context":{"usage_pct":"72.0%"}}
+type ErrorRecord struct {
+	Schema      int            `json:"schema"`
+	Time        time.Time      `json:"time"`
+	Severity    string         `json:"severity"`
+	Code        string         `json:"code"`
+	Component   string         `json:"component"`
+	Message     string         `json:"message"`
+	Signal      string         `json:"signal,omitempty"`
+	Recoverable bool           `json:"recoverable"`
+	Context     map[string]any `json:"context,omitempty"`
+}
+
+// NewErrorRecord converts an ErrorEvent to its JSON form.
+func NewErrorRecord(evt ErrorEvent) ErrorRecord {
+	rec := ErrorRecord{
+		Schema:      ErrorRecordSchema,
+		Time:        evt.Timestamp,
+		Severity:    evt.Severity.String(),
+		Code:        evt.Code,
+		Component:   evt.Component,
+		Message:     evt.Message,
+		Recoverable: evt.Recoverable,
+		Context:     evt.Context,
+	}
+	if evt.Signal != SignalNone {
+		rec.Signal = evt.Signal.String()
+	}
+	return rec
+}
+
+// ErrorEvent converts the record back to an ErrorEvent.
+func (r ErrorRecord) ErrorEvent() (ErrorEvent, error) {
+	if r.Schema > ErrorRecordSchema {
+		return ErrorEvent{}, fmt.Errorf("error record: unsupported schema %d", r.Schema)
+	}
+	sev, err := ParseErrorSeverity(r.Severity)
+	if err != nil {
+		return ErrorEvent{}, fmt.Errorf("error record: %w", err)
+	}
+	signal := SignalNone
+	if r.Signal != "" {
+		if signal, err = ParseControlSignal(r.Signal); err != nil {
+			return ErrorEvent{}, fmt.Errorf("error record: %w", err)
+		}
+	}
+	return ErrorEvent{
+		Timestamp:   r.Time,
+		Severity:    sev,
+		Code:        r.Code,
+		Component:   r.Component,
+		Message:     r.Message,
+		Signal:      signal,
+		Recoverable: r.Recoverable,
+		Context:     r.Context,
+	}, nil
+}
+
+// Bridged error events.
+const (
+	// EventTypeErrorPrefix prefixes the type of bridged error events:
+	// "system.error.<code>" (e.g., "system.error.MEM_PRESSURE").
+	EventTypeErrorPrefix = "system.error."
+
+	// MetadataBridge marks events published by an ErrorBridge. The bridge
+	// never converts such events back into ErrorEvents.
+	MetadataBridge = "bridge"
+
+	// ContextBridgedEvent holds the ID of the event an ErrorEvent was
+	// converted from. The bridge never republishes such ErrorEvents.
+	ContextBridgedEvent = "bridged_event_id"
+)
+
+// ErrorEventType returns the bridged event type for an error code.
+func ErrorEventType(code string) string {
+	return EventTypeErrorPrefix + code
+}
+
+// NewErrorEventEnvelope wraps evt as a "system.error.<code>" event with an
+// ErrorRecord payload. Severity, code and component are copied to metadata
+// so bus filters can select on them.
+func NewErrorEventEnvelope(evt ErrorEvent, source string) (*Event, error) {
+	out, err := NewEvent(ErrorEventType(evt.Code), source, NewErrorRecord(evt), JSONCodec{})
+	if err != nil {
+		return nil, fmt.Errorf("bridge: encode %s: %w", evt.Code, err)
+	}
+	out.Metadata["severity"] = evt.Severity.String()
+	out.Metadata["code"] = evt.Code
+	out.Metadata["component"] = evt.Component
+	return out, nil
+}
+
+// DecodeErrorEvent converts a "system.error.<code>" event carrying an
+// ErrorRecord back to an ErrorEvent. ok is false for other event types.
+// It is the default reverse converter of an ErrorBridge.
+func DecodeErrorEvent(evt *Event) (ErrorEvent, bool) {
+	if !strings.HasPrefix(evt.Type, EventTypeErrorPrefix) {
+		return ErrorEvent{}, false
+	}
+	var rec ErrorRecord
+	if err := (JSONCodec{}).Unmarshal(evt.Data, &rec); err != nil {
+		return ErrorEvent{}, false
+	}
+	out, err := rec.ErrorEvent()
+	if err != nil {
+		return ErrorEvent{}, false
+	}
+	return out, true
+}
+
+// BridgeStats counts events moved by an ErrorBridge.
+type BridgeStats struct {
+	Forwarded  uint64 // ErrorEvents published to the bus
+	Reversed   uint64 // Bus events published to the ErrorBus
+	Suppressed uint64 // Events skipped because they came from a bridge (loop protection)
+	Failed     uint64 // ErrorEvents that failed to encode or publish
+}
+
+// BridgeOption configures an ErrorBridge.
+type BridgeOption func(*bridgeConfig)
+
+// bridgeConfig collects ErrorBridge options.
+type bridgeConfig struct {
+	source  string
+	filter  ErrorFilter
+	reverse *Filter
+	convert func(*Event) (ErrorEvent, bool)
+}
+
+// WithBridgeFilter selects which ErrorEvents are forwarded to the bus, with
+// the same semantics as ErrorBus.SubscribeFiltered. Default: all.
+func WithBridgeFilter(filter ErrorFilter) BridgeOption {
+	return func(c *bridgeConfig) {
+		c.filter = filter
+	}
+}
+
+// WithBridgeSource sets the Source of forwarded events (default "error-bus").
+func WithBridgeSource(source string) BridgeOption {
+	return func(c *bridgeConfig) {
+		c.source = source
+	}
+}
+
+// WithBridgeReverse enables the bus → ErrorBus direction for events matching
+// filter. convert turns a domain event into an ErrorEvent (ok=false skips
+// it); nil uses DecodeErrorEvent.
+//
+// Example:
+//
+//	event.WithBridgeReverse(event.Filter{Types: []string{"orders.failed"}},
+//	    func(evt *event.Event) (event.ErrorEvent, bool) {
+//	        return event.NewErrorEvent(event.Error, "ORDER_FAILED", evt.Source, "Order failed"), true
+//	    })
+func WithBridgeReverse(filter Filter, convert func(*Event) (ErrorEvent, bool)) BridgeOption {
+	return func(c *bridgeConfig) {
+		c.reverse = &filter
+		c.convert = convert
+	}
+}
+
+// ErrorBridge republishes ErrorEvents on an event.Bus as
+// "system.error.<code>" events (payload: ErrorRecord), so they can flow
+// through emitters, recorders and other bus tooling. Optionally it also
+// converts bus events into ErrorEvents.
+//
+// Loop protection: forwarded events carry Metadata[MetadataBridge] and are
+// never converted back; converted ErrorEvents carry
+// Context[ContextBridgedEvent] and are never forwarded again.
+type ErrorBridge struct {
+	errBus  *ErrorBus
+	bus     Bus
+	source  string
+	convert func(*Event) (ErrorEvent, bool)
+
+	errSub *ErrorSubscription
+	busSub Subscription
+	wg     sync.WaitGroup
+
+	forwarded  atomic.Uint64
+	reversed   atomic.Uint64
+	suppressed atomic.Uint64
+	failed     atomic.Uint64
+
+	closeOnce sync.Once
+}
+
+// NewErrorBridge connects errBus to bus and starts forwarding.
+//
+// Usage:
+//
+//	bridge, _ := event.NewErrorBridge(eng.ErrorBus(), eng.ExternalBus(),
+//	    event.WithBridgeFilter(event.ErrorFilter{MinSeverity: event.WarningSeverity}))
+//	defer bridge.Close()
+func NewErrorBridge(errBus *ErrorBus, bus Bus, opts ...BridgeOption) (*ErrorBridge, error) {
+	cfg := bridgeConfig{source: "error-bus"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.convert == nil {
+		cfg.convert = DecodeErrorEvent
+	}
+
+	b := &ErrorBridge{
+		errBus:  errBus,
+		bus:     bus,
+		source:  cfg.source,
+		convert: cfg.convert,
+	}
+
+	errSub, err := errBus.SubscribeFiltered(context.Background(), cfg.filter)
+	if err != nil {
+		return nil, fmt.Errorf("bridge: subscribe error bus: %w", err)
+	}
+	b.errSub = errSub
+
+	if cfg.reverse != nil {
+		busSub, err := bus.Subscribe(context.Background(), *cfg.reverse)
+		if err != nil {
+			errBus.Unsubscribe(errSub)
+			return nil, fmt.Errorf("bridge: subscribe bus: %w", err)
+		}
+		b.busSub = busSub
+		b.wg.Add(1)
+		go b.reverse()
+	}
+
+	b.wg.Add(1)
+	go b.forward()
+	return b, nil
+}
+
+// forward publishes ErrorEvents to the bus.
+func (b *ErrorBridge) forward() {
+	defer b.wg.Done()
+	for evt := range b.errSub.Events() {
+		if _, ok := evt.Context[ContextBridgedEvent]; ok {
+			b.suppressed.Add(1)
+			continue
+		}
+		out, err := NewErrorEventEnvelope(evt, b.source)
+		if err != nil {
+			b.failed.Add(1)
+			continue
+		}
+		out.Metadata[MetadataBridge] = "error-bus"
+		if err := b.bus.Publish(context.Background(), out); err != nil {
+			b.failed.Add(1)
+			continue
+		}
+		b.forwarded.Add(1)
+	}
+}
+
+// reverse publishes matching bus events to the ErrorBus.
+func (b *ErrorBridge) reverse() {
+	defer b.wg.Done()
+	for evt := range b.busSub.Events() {
+		if evt.Metadata[MetadataBridge] != "" {
+			b.suppressed.Add(1)
+			continue
+		}
+		errEvt, ok := b.convert(evt)
+		if !ok {
+			continue
+		}
+		// Copy context so the converter's map is not shared
+		ctx := make(map[string]any, len(errEvt.Context)+1)
+		for k, v := range errEvt.Context {
+			ctx[k] = v
+		}
+		ctx[ContextBridgedEvent] = evt.ID
+		errEvt.Context = ctx
+		b.errBus.Publish(errEvt)
+		b.reversed.Add(1)
+	}
+}
+
+// Stats returns bridge counters.
+func (b *ErrorBridge) Stats() BridgeStats {
+	return BridgeStats{
+		Forwarded:  b.forwarded.Load(),
+		Reversed:   b.reversed.Load(),
+		Suppressed: b.suppressed.Load(),
+		Failed:     b.failed.Load(),
+	}
+}
+
+// Close detaches the bridge from both buses and waits for in-flight events.
+// Safe to call multiple times and after either bus has closed.
+func (b *ErrorBridge) Close() error {
+	var err error
+	b.closeOnce.Do(func() {
+		b.errBus.Unsubscribe(b.errSub)
+		if b.busSub != nil {
+			err = b.busSub.Close()
+		}
+		b.wg.Wait()
+	})
+	return err
+}
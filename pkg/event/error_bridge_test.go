@@ -0,0 +1,136 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-json-experiment/json"
+)
+
+// recvEvent waits briefly for one event from sub.
+func recvEvent(t *testing.T, sub Subscription) *Event {
+	t.Helper()
+	select {
+	case evt := <-sub.Events():
+		return evt
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for event")
+		return nil
+	}
+}
+
+func TestErrorRecord_RoundTrip(t *testing.T) {
+	evt := NewErrorEvent(WarningSeverity, CodeMemPressure, "monitor:memory", "Memory usage high").
+		WithSignal(SignalThrottle).
+		WithContext("usage_pct", 72.5)
+
+	data, err := json.Marshal(NewErrorRecord(evt))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var rec ErrorRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if rec.Schema != ErrorRecordSchema {
+		t.Errorf("Expected schema %d, got %d", ErrorRecordSchema, rec.Schema)
+	}
+
+	back, err := rec.ErrorEvent()
+	if err != nil {
+		t.Fatalf("ErrorEvent failed: %v", err)
+	}
+	if back.Severity != WarningSeverity || back.Signal != SignalThrottle || back.Code != evt.Code ||
+		!back.Timestamp.Equal(evt.Timestamp) || back.Context["usage_pct"] != 72.5 {
+		t.Errorf("Round trip mismatch: %+v", back)
+	}
+
+	rec.Schema = ErrorRecordSchema + 1
+	if _, err := rec.ErrorEvent(); err == nil {
+		t.Error("Expected newer schema to be rejected")
+	}
+}
+
+func TestErrorBridge_Forward(t *testing.T) {
+	errBus := NewErrorBus(16)
+	defer errBus.Close()
+	bus := NewInMemoryBus()
+	defer bus.Close()
+
+	sub, _ := bus.Subscribe(t.Context(), Filter{Types: []string{EventTypeErrorPrefix + "*"}})
+	bridge, err := NewErrorBridge(errBus, bus, WithBridgeFilter(ErrorFilter{MinSeverity: WarningSeverity}))
+	if err != nil {
+		t.Fatalf("NewErrorBridge failed: %v", err)
+	}
+	defer bridge.Close()
+
+	errBus.Publish(NewErrorEvent(InfoSeverity, CodeHealthCheck, "engine", "filtered out"))
+	errBus.Publish(NewErrorEvent(Error, CodeEmitterFail, "emitter:http", "send failed"))
+
+	evt := recvEvent(t, sub)
+	if evt.Type != "system.error.EMITTER_FAIL" || evt.Source != "error-bus" {
+		t.Errorf("Unexpected event: %s from %s", evt.Type, evt.Source)
+	}
+	if evt.Metadata["severity"] != "ERROR" || evt.Metadata["component"] != "emitter:http" || evt.Metadata[MetadataBridge] == "" {
+		t.Errorf("Unexpected metadata: %v", evt.Metadata)
+	}
+	decoded, ok := DecodeErrorEvent(evt)
+	if !ok || decoded.Message != "send failed" {
+		t.Errorf("Expected decodable payload, got %+v", decoded)
+	}
+
+	bridge.Close()
+	if stats := bridge.Stats(); stats.Forwarded != 1 {
+		t.Errorf("Expected 1 forwarded, got %+v", stats)
+	}
+}
+
+func TestErrorBridge_ReverseWithLoopProtection(t *testing.T) {
+	errBus := NewErrorBus(16)
+	defer errBus.Close()
+	bus := NewInMemoryBus()
+	defer bus.Close()
+
+	errSub, _ := errBus.Subscribe(t.Context())
+	bridge, err := NewErrorBridge(errBus, bus, WithBridgeReverse(
+		Filter{Types: []string{"orders.failed", EventTypeErrorPrefix + "*"}},
+		func(evt *Event) (ErrorEvent, bool) {
+			if d, ok := DecodeErrorEvent(evt); ok {
+				return d, true
+			}
+			return NewErrorEvent(Error, "ORDER_FAILED", evt.Source, "Order failed"), true
+		},
+	))
+	if err != nil {
+		t.Fatalf("NewErrorBridge failed: %v", err)
+	}
+
+	domain, _ := NewEvent("orders.failed", "orders", map[string]string{"id": "42"}, JSONCodec{})
+	bus.Publish(t.Context(), domain)
+
+	select {
+	case got := <-errSub.Events():
+		if got.Code != "ORDER_FAILED" || got.Context[ContextBridgedEvent] != domain.ID {
+			t.Errorf("Unexpected converted error: %+v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected converted ErrorEvent")
+	}
+
+	// A native error goes out once and its bridged copy is not converted back
+	errBus.Publish(NewErrorEvent(WarningSeverity, CodeBufSat, "bus:external", "saturated"))
+	<-errSub.Events()
+
+	time.Sleep(50 * time.Millisecond)
+	bridge.Close()
+
+	stats := bridge.Stats()
+	if stats.Reversed != 1 || stats.Forwarded != 1 || stats.Suppressed != 2 {
+		t.Errorf("Expected 1 reversed, 1 forwarded, 2 suppressed, got %+v", stats)
+	}
+	select {
+	case extra := <-errSub.Events():
+		t.Errorf("Unexpected looped error: %+v", extra)
+	default:
+	}
+}
@@ -3,6 +3,7 @@ package event
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"sync/atomic"
 )
@@ -21,14 +22,65 @@ type ErrorBus struct {
 	droppedCounter atomic.Uint64 // Total events dropped across all subs
 	mu             sync.Mutex    // Protects subscription modifications only
 	closed         bool
-	bufferSize     int // Buffer size per subscription
+	bufferSize     int                          // Buffer size per subscription
+	sampler        atomic.Pointer[ErrorSampler] // Optional: collapse repetitive errors
+	history        atomic.Pointer[ErrorHistory] // Optional: recent error timeline
 }
 
 // ErrorSubscription represents a subscription to the error bus.
 type ErrorSubscription struct {
-	id     string
-	ch     chan ErrorEvent
-	closed atomic.Bool
+	id      string
+	ch      chan ErrorEvent
+	mu      sync.RWMutex // Held for reading during sends, for writing by Close
+	closed  atomic.Bool
+	filter  ErrorFilter
+	dropped atomic.Uint64 // Matching events dropped on a full buffer
+}
+
+// ErrorFilter selects the error events a subscription receives.
+// Empty fields match everything; all set fields must match.
+//
+// Example (critical memory alerts only):
+//
+//	event.ErrorFilter{
+//	    MinSeverity: event.CriticalSeverity,
+//	    Codes:       []string{"MEM_*", "OOM_*"},
+//	}
+type ErrorFilter struct {
+	// MinSeverity drops events below this severity (zero value = DEBUG = all)
+	MinSeverity ErrorSeverity
+
+	// Codes matches event codes (supports wildcards like "MEM_*")
+	Codes []string
+
+	// Components matches components (supports wildcards like "monitor:*")
+	Components []string
+
+	// Signals matches any of the given control signals
+	Signals []ControlSignal
+
+	// Recoverable, if set, matches only events with this recoverable flag
+	Recoverable *bool
+}
+
+// Matches reports whether evt passes the filter.
+func (f ErrorFilter) Matches(evt ErrorEvent) bool {
+	if evt.Severity < f.MinSeverity {
+		return false
+	}
+	if len(f.Codes) > 0 && !matchesAny(evt.Code, f.Codes) {
+		return false
+	}
+	if len(f.Components) > 0 && !matchesAny(evt.Component, f.Components) {
+		return false
+	}
+	if len(f.Signals) > 0 && !slices.Contains(f.Signals, evt.Signal) {
+		return false
+	}
+	if f.Recoverable != nil && evt.Recoverable != *f.Recoverable {
+		return false
+	}
+	return true
 }
 
 // NewErrorBus creates a new error bus with the given buffer size per subscription.
@@ -49,41 +101,104 @@ func NewErrorBus(bufferSize int) *ErrorBus {
 	return bus
 }
 
+// SetSampler installs (or, with nil, removes) a sampler that collapses
+// repetitive errors before they reach subscribers.
+func (b *ErrorBus) SetSampler(sampler *ErrorSampler) {
+	b.sampler.Store(sampler)
+}
+
+// Sampler returns the installed sampler, or nil.
+func (b *ErrorBus) Sampler() *ErrorSampler {
+	return b.sampler.Load()
+}
+
+// FlushSampler publishes summaries for expired sampling windows.
+// Returns the number of successful deliveries.
+func (b *ErrorBus) FlushSampler() int {
+	sampler := b.sampler.Load()
+	if sampler == nil {
+		return 0
+	}
+
+	delivered := 0
+	for _, evt := range sampler.Flush() {
+		delivered += b.deliver(evt)
+	}
+	return delivered
+}
+
+// SetHistory records every published event (after sampling) in history,
+// including events no subscriber received.
+// Pass nil to stop recording.
+func (b *ErrorBus) SetHistory(history *ErrorHistory) {
+	b.history.Store(history)
+}
+
+// History returns the installed history, or nil.
+func (b *ErrorBus) History() *ErrorHistory {
+	return b.history.Load()
+}
+
 // Publish sends an error event to all subscribers.
 // This method NEVER blocks - it drops events if subscriber buffers are full.
+// With a sampler installed, repeats may be collapsed into a later summary.
 // Returns the number of successful deliveries.
 func (b *ErrorBus) Publish(evt ErrorEvent) int {
-	subs := b.subs.Load()
-	if subs == nil || len(*subs) == 0 {
-		return 0
+	sampler := b.sampler.Load()
+	if sampler == nil {
+		return b.deliver(evt)
 	}
 
 	delivered := 0
+	for _, out := range sampler.Sample(evt) {
+		delivered += b.deliver(out)
+	}
+	return delivered
+}
 
-	for i := range *subs {
-		sub := (*subs)[i]
+// deliver sends an event to every matching subscription without blocking.
+func (b *ErrorBus) deliver(evt ErrorEvent) int {
+	delivered, dropped := 0, 0
 
-		// Skip closed subscriptions
-		if sub.closed.Load() {
-			continue
-		}
+	subs := b.subs.Load()
+	if subs != nil {
+		for i := range *subs {
+			sub := (*subs)[i]
+
+			// Skip events the subscriber did not ask for, so they never take up
+			// buffer space
+			if !sub.filter.Matches(evt) {
+				continue
+			}
 
-		// Non-blocking send - drop on full buffer
-		select {
-		case sub.ch <- evt:
-			delivered++
-		default:
-			// Buffer full - drop event to protect critical path
-			b.droppedCounter.Add(1)
+			switch sub.trySend(evt) {
+			case sendDelivered:
+				delivered++
+			case sendDropped:
+				// Buffer full - drop event to protect critical path
+				b.droppedCounter.Add(1)
+				dropped++
+			}
 		}
 	}
 
+	// Recorded even without subscribers, so the timeline survives drops
+	if history := b.history.Load(); history != nil {
+		history.record(evt, delivered, dropped)
+	}
+
 	return delivered
 }
 
 // Subscribe creates a new subscription to error events.
 // The returned subscription will receive all error events published after subscription.
 func (b *ErrorBus) Subscribe(ctx context.Context) (*ErrorSubscription, error) {
+	return b.SubscribeFiltered(ctx, ErrorFilter{})
+}
+
+// SubscribeFiltered creates a subscription that only receives error events
+// matching filter. Filtering happens in Publish, before the channel send.
+func (b *ErrorBus) SubscribeFiltered(ctx context.Context, filter ErrorFilter) (*ErrorSubscription, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -92,8 +207,9 @@ func (b *ErrorBus) Subscribe(ctx context.Context) (*ErrorSubscription, error) {
 	}
 
 	sub := &ErrorSubscription{
-		id: generateSubscriptionID(),
-		ch: make(chan ErrorEvent, b.bufferSize),
+		id:     generateSubscriptionID(),
+		ch:     make(chan ErrorEvent, b.bufferSize),
+		filter: filter,
 	}
 
 	// Copy-on-write: create new subscription list
@@ -117,13 +233,11 @@ func (b *ErrorBus) Unsubscribe(sub *ErrorSubscription) {
 		return
 	}
 
-	// Mark as closed
-	sub.closed.Store(true)
-	close(sub.ch)
+	sub.Close()
 
 	// Remove from subscription list
 	oldSubs := b.subs.Load()
-	newSubs := make([]*ErrorSubscription, 0, len(*oldSubs)-1)
+	newSubs := make([]*ErrorSubscription, 0, len(*oldSubs))
 
 	for _, s := range *oldSubs {
 		if s != sub {
@@ -135,7 +249,14 @@ func (b *ErrorBus) Unsubscribe(sub *ErrorSubscription) {
 }
 
 // Close shuts down the error bus and all subscriptions.
+// Pending sampler summaries are delivered first.
 func (b *ErrorBus) Close() error {
+	if sampler := b.sampler.Load(); sampler != nil {
+		for _, evt := range sampler.FlushAll() {
+			b.deliver(evt)
+		}
+	}
+
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -148,10 +269,7 @@ func (b *ErrorBus) Close() error {
 	// Close all subscriptions
 	subs := b.subs.Load()
 	for _, sub := range *subs {
-		if !sub.closed.Load() {
-			sub.closed.Store(true)
-			close(sub.ch)
-		}
+		sub.Close()
 	}
 
 	// Clear subscription list
@@ -182,16 +300,56 @@ func (s *ErrorSubscription) Events() <-chan ErrorEvent {
 
 // Close closes the subscription and stops receiving events.
 func (s *ErrorSubscription) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.closed.CompareAndSwap(false, true) {
 		close(s.ch)
 	}
 }
 
+// sendResult is the outcome of trySend.
+type sendResult int
+
+const (
+	sendClosed sendResult = iota
+	sendDelivered
+	sendDropped
+)
+
+// trySend delivers evt without blocking. The read lock keeps Close from
+// closing the channel mid-send.
+func (s *ErrorSubscription) trySend(evt ErrorEvent) sendResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed.Load() {
+		return sendClosed
+	}
+	select {
+	case s.ch <- evt:
+		return sendDelivered
+	default:
+		s.dropped.Add(1)
+		return sendDropped
+	}
+}
+
 // ID returns the subscription identifier.
 func (s *ErrorSubscription) ID() string {
 	return s.id
 }
 
+// Filter returns the subscription filter.
+func (s *ErrorSubscription) Filter() ErrorFilter {
+	return s.filter
+}
+
+// Dropped returns the number of matching events this subscription missed
+// because its buffer was full.
+func (s *ErrorSubscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
 // generateSubscriptionID generates a unique subscription ID.
 var subIDCounter atomic.Uint64
 
@@ -206,7 +364,12 @@ type ErrorHandler func(ErrorEvent)
 // Subscribe with a handler that processes events in a background goroutine.
 // The goroutine is automatically stopped when the context is cancelled.
 func (b *ErrorBus) SubscribeWithHandler(ctx context.Context, handler ErrorHandler) (*ErrorSubscription, error) {
-	sub, err := b.Subscribe(ctx)
+	return b.SubscribeFilteredWithHandler(ctx, ErrorFilter{}, handler)
+}
+
+// SubscribeFilteredWithHandler is SubscribeWithHandler for a filtered subscription.
+func (b *ErrorBus) SubscribeFilteredWithHandler(ctx context.Context, filter ErrorFilter, handler ErrorHandler) (*ErrorSubscription, error) {
+	sub, err := b.SubscribeFiltered(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,78 @@
+package event
+
+import (
+	"context"
+	"testing"
+)
+
+func TestErrorFilter_Matches(t *testing.T) {
+	unrecoverable := false
+	evt := NewErrorEvent(WarningSeverity, CodeMemPressure, "monitor:memory", "high").
+		WithSignal(SignalThrottle).
+		WithRecoverable(false)
+
+	tests := []struct {
+		name   string
+		filter ErrorFilter
+		want   bool
+	}{
+		{"empty", ErrorFilter{}, true},
+		{"severity below", ErrorFilter{MinSeverity: CriticalSeverity}, false},
+		{"severity at", ErrorFilter{MinSeverity: WarningSeverity}, true},
+		{"code glob", ErrorFilter{Codes: []string{"MEM_*"}}, true},
+		{"code miss", ErrorFilter{Codes: []string{"PSI_*", "OOM_*"}}, false},
+		{"component glob", ErrorFilter{Components: []string{"monitor:*"}}, true},
+		{"component miss", ErrorFilter{Components: []string{"bus:*"}}, false},
+		{"signal", ErrorFilter{Signals: []ControlSignal{SignalShed, SignalThrottle}}, true},
+		{"signal miss", ErrorFilter{Signals: []ControlSignal{SignalRecovered}}, false},
+		{"recoverable", ErrorFilter{Recoverable: &unrecoverable}, true},
+		{"all fields", ErrorFilter{
+			MinSeverity: InfoSeverity,
+			Codes:       []string{"MEM_*"},
+			Components:  []string{"monitor:*"},
+			Signals:     []ControlSignal{SignalThrottle},
+			Recoverable: &unrecoverable,
+		}, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.Matches(evt); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestErrorBus_SubscribeFiltered(t *testing.T) {
+	bus := NewErrorBus(2)
+	defer bus.Close()
+
+	ctx := context.Background()
+	critical, _ := bus.SubscribeFiltered(ctx, ErrorFilter{MinSeverity: CriticalSeverity})
+	all, _ := bus.Subscribe(ctx)
+
+	// A storm of warnings must not crowd out the critical event
+	for i := 0; i < 10; i++ {
+		bus.Publish(NewErrorEvent(WarningSeverity, CodeEmitterFail, "emitter:x", "fail"))
+	}
+	if n := bus.Publish(NewErrorEvent(CriticalSeverity, CodeOOMImminent, "monitor:psi", "oom")); n != 1 {
+		t.Errorf("Expected 1 delivery of the critical event, got %d", n)
+	}
+
+	evt := <-critical.Events()
+	if evt.Code != CodeOOMImminent {
+		t.Errorf("Expected OOM_IMMINENT, got %s", evt.Code)
+	}
+	if len(critical.Events()) != 0 {
+		t.Error("Filtered subscription should not receive warnings")
+	}
+
+	if critical.Dropped() != 0 {
+		t.Errorf("Filtered subscription should not count drops, got %d", critical.Dropped())
+	}
+	if all.Dropped() != 9 {
+		t.Errorf("Expected 9 drops on the unfiltered subscription, got %d", all.Dropped())
+	}
+	if bus.DroppedCount() != 9 {
+		t.Errorf("Expected 9 total drops, got %d", bus.DroppedCount())
+	}
+}
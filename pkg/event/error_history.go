@@ -0,0 +1,160 @@
+package event
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrorHistoryEntry is one error event recorded by an ErrorHistory.
+type ErrorHistoryEntry struct {
+	Seq       uint64 // Monotonic sequence number (1 = first event recorded)
+	Event     ErrorEvent
+	Delivered int // Subscribers that received the event
+	Dropped   int // Subscribers whose buffer was full
+}
+
+// ErrorQuery selects entries from an ErrorHistory. Zero fields match
+// everything.
+type ErrorQuery struct {
+	Since  time.Time   // Events at or after this time
+	Until  time.Time   // Events before this time
+	Filter ErrorFilter // Severity, code, component, signal and recoverable filters
+	Limit  int         // Return at most the newest Limit matches (0 = all)
+}
+
+// ErrorHistory is a bounded ring of recent error events, recorded by an
+// ErrorBus whether or not any subscriber received them. It keeps the error
+// timeline available for crash reports and admin tools.
+//
+// Usage:
+//
+//	history := event.NewErrorHistory(256)
+//	errorBus.SetHistory(history)
+//	recent := history.Query(event.ErrorQuery{
+//	    Since:  time.Now().Add(-time.Minute),
+//	    Filter: event.ErrorFilter{MinSeverity: event.WarningSeverity},
+//	})
+type ErrorHistory struct {
+	mu      sync.Mutex
+	entries []ErrorHistoryEntry
+	next    int // Slot for the next entry
+	count   int // Valid entries (≤ capacity)
+	seq     uint64
+}
+
+// NewErrorHistory creates a history holding the last capacity events
+// (minimum 1).
+func NewErrorHistory(capacity int) *ErrorHistory {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &ErrorHistory{entries: make([]ErrorHistoryEntry, capacity)}
+}
+
+// record appends evt, overwriting the oldest entry when full.
+func (h *ErrorHistory) record(evt ErrorEvent, delivered, dropped int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.seq++
+	h.entries[h.next] = ErrorHistoryEntry{
+		Seq:       h.seq,
+		Event:     evt,
+		Delivered: delivered,
+		Dropped:   dropped,
+	}
+	h.next = (h.next + 1) % len(h.entries)
+	if h.count < len(h.entries) {
+		h.count++
+	}
+}
+
+// Query returns matching entries, oldest first.
+func (h *ErrorHistory) Query(q ErrorQuery) []ErrorHistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var out []ErrorHistoryEntry
+	start := (h.next - h.count + len(h.entries)) % len(h.entries)
+	for i := 0; i < h.count; i++ {
+		entry := h.entries[(start+i)%len(h.entries)]
+		ts := entry.Event.Timestamp
+		if !q.Since.IsZero() && ts.Before(q.Since) {
+			continue
+		}
+		if !q.Until.IsZero() && !ts.Before(q.Until) {
+			continue
+		}
+		if !q.Filter.Matches(entry.Event) {
+			continue
+		}
+		out = append(out, entry)
+	}
+
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[len(out)-q.Limit:]
+	}
+	return out
+}
+
+// All returns every recorded entry, oldest first.
+func (h *ErrorHistory) All() []ErrorHistoryEntry {
+	return h.Query(ErrorQuery{})
+}
+
+// Len returns how many entries are held.
+func (h *ErrorHistory) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// Cap returns the maximum number of entries held.
+func (h *ErrorHistory) Cap() int {
+	return len(h.entries)
+}
+
+// Total returns how many events have been recorded, including overwritten ones.
+func (h *ErrorHistory) Total() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.seq
+}
+
+// Dump writes the history as a human-readable timeline, oldest first
+// (used in crash reports).
+func (h *ErrorHistory) Dump(w io.Writer) error {
+	entries := h.All()
+	total := h.Total()
+
+	fmt.Fprintf(w, "=== Error History ===\n")
+	fmt.Fprintf(w, "Last %d of %d error events:\n\n", len(entries), total)
+
+	for _, entry := range entries {
+		evt := entry.Event
+		fmt.Fprintf(w, "[%d] %s %-8s %s %s: %s",
+			entry.Seq,
+			evt.Timestamp.Format("15:04:05.000"),
+			evt.Severity,
+			evt.Code,
+			evt.Component,
+			evt.Message)
+		if evt.Signal != SignalNone {
+			fmt.Fprintf(w, " [%s]", evt.Signal)
+		}
+		for _, k := range slices.Sorted(maps.Keys(evt.Context)) {
+			fmt.Fprintf(w, " %s=%v", k, evt.Context[k])
+		}
+		if entry.Dropped > 0 {
+			fmt.Fprintf(w, " (dropped by %d subscribers)", entry.Dropped)
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package event
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestErrorHistory_RingAndQuery(t *testing.T) {
+	bus := NewErrorBus(4)
+	defer bus.Close()
+	history := NewErrorHistory(3)
+	bus.SetHistory(history)
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	codes := []string{CodeHealthCheck, CodeMemPressure, CodeEmitterFail, CodeBufSat}
+	for i, code := range codes {
+		evt := NewErrorEvent(WarningSeverity, code, "c", "m")
+		evt.Timestamp = base.Add(time.Duration(i) * time.Second)
+		bus.Publish(evt) // Recorded without subscribers
+	}
+
+	if history.Len() != 3 || history.Total() != 4 {
+		t.Fatalf("Expected 3 of 4 entries held, got %d of %d", history.Len(), history.Total())
+	}
+	all := history.All()
+	if all[0].Event.Code != CodeMemPressure || all[2].Seq != 4 {
+		t.Errorf("Expected oldest overwritten and chronological order, got %+v", all)
+	}
+
+	got := history.Query(ErrorQuery{Since: base.Add(2 * time.Second), Until: base.Add(3 * time.Second)})
+	if len(got) != 1 || got[0].Event.Code != CodeEmitterFail {
+		t.Errorf("Time range query returned %+v", got)
+	}
+	got = history.Query(ErrorQuery{Filter: ErrorFilter{Codes: []string{"MEM_*", "BUF_*"}}, Limit: 1})
+	if len(got) != 1 || got[0].Event.Code != CodeBufSat {
+		t.Errorf("Code query with limit returned %+v", got)
+	}
+	if got := history.Query(ErrorQuery{Filter: ErrorFilter{MinSeverity: Error}}); len(got) != 0 {
+		t.Errorf("Severity query returned %+v", got)
+	}
+}
+
+func TestErrorHistory_RecordsDrops(t *testing.T) {
+	bus := NewErrorBus(1)
+	defer bus.Close()
+	history := NewErrorHistory(8)
+	bus.SetHistory(history)
+	bus.Subscribe(t.Context())
+
+	bus.Publish(NewErrorEvent(WarningSeverity, CodeDropSlow, "bus:x", "first"))
+	bus.Publish(NewErrorEvent(WarningSeverity, CodeDropSlow, "bus:x", "second"))
+
+	all := history.All()
+	if all[0].Delivered != 1 || all[1].Dropped != 1 {
+		t.Errorf("Expected delivery then drop, got %+v", all)
+	}
+
+	var buf bytes.Buffer
+	history.Dump(&buf)
+	out := buf.String()
+	if !strings.Contains(out, "=== Error History ===") || !strings.Contains(out, "second (dropped by 1 subscribers)") {
+		t.Errorf("Unexpected dump:\n%s", out)
+	}
+}
@@ -0,0 +1,242 @@
+package event
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+)
+
+// Context keys set on aggregated error events.
+const (
+	ContextRepeatCount = "repeat_count" // Occurrences collapsed into this event
+	ContextFirstSeen   = "first_seen"   // Timestamp of the first collapsed occurrence
+	ContextLastSeen    = "last_seen"    // Timestamp of the last collapsed occurrence
+)
+
+// ErrorSampler keeps repetitive errors from flooding the ErrorBus.
+//
+// Identical events (same code and component) are collapsed per window: the
+// first occurrence passes through, later ones are counted, and when the
+// window closes a single summary event is emitted carrying repeat_count,
+// first_seen and last_seen in its Context. On top of that, each code draws
+// from a token bucket; an event arriving with the bucket empty is folded into
+// the window summary instead of passing through.
+//
+// CriticalSeverity events are never sampled or delayed.
+//
+// Usage:
+//
+//	sampler := event.NewErrorSampler(event.WithSampleWindow(time.Second))
+//	errorBus.SetSampler(sampler)
+//	// Periodically: errorBus.FlushSampler()
+type ErrorSampler struct {
+	window time.Duration
+	rate   float64 // Tokens per second per code
+	burst  float64 // Bucket capacity per code
+	clock  clock.Clock
+
+	mu         sync.Mutex
+	windows    map[sampleKey]*sampleWindow
+	buckets    map[string]*sampleBucket
+	suppressed uint64
+}
+
+// sampleKey identifies a stream of identical errors.
+type sampleKey struct {
+	code      string
+	component string
+}
+
+// sampleWindow tracks occurrences of one key within the current window.
+type sampleWindow struct {
+	start      clock.MonoTime
+	last       ErrorEvent // Most recent suppressed occurrence
+	firstSeen  time.Time
+	lastSeen   time.Time
+	suppressed int
+}
+
+// sampleBucket is a per-code token bucket.
+type sampleBucket struct {
+	tokens float64
+	last   clock.MonoTime
+}
+
+// ErrorSamplerOption configures an ErrorSampler.
+type ErrorSamplerOption func(*ErrorSampler)
+
+// WithSampleWindow sets the aggregation window.
+func WithSampleWindow(window time.Duration) ErrorSamplerOption {
+	return func(s *ErrorSampler) {
+		s.window = window
+	}
+}
+
+// WithSampleRate sets the per-code token bucket (events/sec and burst).
+func WithSampleRate(rate float64, burst int) ErrorSamplerOption {
+	return func(s *ErrorSampler) {
+		s.rate = rate
+		s.burst = float64(burst)
+	}
+}
+
+// WithSampleClock sets the clock used for windows and token refill.
+func WithSampleClock(clk clock.Clock) ErrorSamplerOption {
+	return func(s *ErrorSampler) {
+		s.clock = clk
+	}
+}
+
+// NewErrorSampler creates an error sampler with the given options.
+//
+// Defaults:
+//   - window: 1 second
+//   - rate: 10 events/sec per code, burst 20
+//   - clock: SystemClock
+func NewErrorSampler(opts ...ErrorSamplerOption) *ErrorSampler {
+	s := &ErrorSampler{
+		window:  time.Second,
+		rate:    10,
+		burst:   20,
+		windows: make(map[sampleKey]*sampleWindow),
+		buckets: make(map[string]*sampleBucket),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.clock == nil {
+		s.clock = clock.NewSystemClock()
+	}
+	if s.burst < 1 {
+		s.burst = 1
+	}
+	return s
+}
+
+// Sample decides what to publish for evt. It returns evt itself if it passes,
+// preceded by the summary of an expired window for the same key, or nothing
+// if evt was collapsed into the current window.
+func (s *ErrorSampler) Sample(evt ErrorEvent) []ErrorEvent {
+	if evt.Severity >= CriticalSeverity {
+		return []ErrorEvent{evt}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	key := sampleKey{code: evt.Code, component: evt.Component}
+
+	var out []ErrorEvent
+	if w, ok := s.windows[key]; ok {
+		if s.clock.Since(w.start) < s.window {
+			w.add(evt)
+			s.suppressed++
+			return nil
+		}
+		if w.suppressed > 0 {
+			out = append(out, w.summary())
+		}
+		delete(s.windows, key)
+	}
+
+	w := &sampleWindow{start: now}
+	s.windows[key] = w
+	if s.take(evt.Code, now) {
+		out = append(out, evt)
+	} else {
+		w.add(evt)
+		s.suppressed++
+	}
+	return out
+}
+
+// take consumes a token from the code's bucket. Caller must hold s.mu.
+func (s *ErrorSampler) take(code string, now clock.MonoTime) bool {
+	b, ok := s.buckets[code]
+	if !ok {
+		b = &sampleBucket{tokens: s.burst, last: now}
+		s.buckets[code] = b
+	}
+
+	b.tokens += clock.ToDuration(now-b.last).Seconds() * s.rate
+	if b.tokens > s.burst {
+		b.tokens = s.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Flush closes every expired window and returns summaries for those that
+// collapsed at least one event. Call it periodically (about once per window)
+// so a burst that stops abruptly is still reported.
+func (s *ErrorSampler) Flush() []ErrorEvent {
+	return s.flush(false)
+}
+
+// FlushAll closes every window, expired or not (used at shutdown).
+func (s *ErrorSampler) FlushAll() []ErrorEvent {
+	return s.flush(true)
+}
+
+// flush closes expired (or all) windows and returns their summaries.
+func (s *ErrorSampler) flush(all bool) []ErrorEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []ErrorEvent
+	for key, w := range s.windows {
+		if !all && s.clock.Since(w.start) < s.window {
+			continue
+		}
+		if w.suppressed > 0 {
+			out = append(out, w.summary())
+		}
+		delete(s.windows, key)
+	}
+	return out
+}
+
+// Suppressed returns the number of events collapsed into summaries.
+func (s *ErrorSampler) Suppressed() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.suppressed
+}
+
+// Window returns the aggregation window.
+func (s *ErrorSampler) Window() time.Duration {
+	return s.window
+}
+
+// add records a suppressed occurrence.
+func (w *sampleWindow) add(evt ErrorEvent) {
+	if w.suppressed == 0 {
+		w.firstSeen = evt.Timestamp
+	}
+	w.suppressed++
+	w.last = evt
+	w.lastSeen = evt.Timestamp
+}
+
+// summary builds the aggregated event for a window.
+func (w *sampleWindow) summary() ErrorEvent {
+	evt := w.last
+	evt.Context = maps.Clone(evt.Context)
+	if evt.Context == nil {
+		evt.Context = make(map[string]any)
+	}
+	evt.Context[ContextRepeatCount] = w.suppressed
+	evt.Context[ContextFirstSeen] = w.firstSeen
+	evt.Context[ContextLastSeen] = w.lastSeen
+	return evt
+}
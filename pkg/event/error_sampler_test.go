@@ -0,0 +1,112 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+)
+
+func TestErrorSampler_CollapsesWithinWindow(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	clk.Load(0, []time.Duration{500 * time.Millisecond, 600 * time.Millisecond})
+
+	s := NewErrorSampler(WithSampleWindow(time.Second), WithSampleClock(clk))
+	fail := NewErrorEvent(Error, CodeEmitterFail, "emitter:http", "send failed")
+
+	if out := s.Sample(fail); len(out) != 1 {
+		t.Fatalf("First occurrence should pass, got %d events", len(out))
+	}
+	for i := 0; i < 4; i++ {
+		if out := s.Sample(fail); len(out) != 0 {
+			t.Fatalf("Repeat %d should be collapsed", i)
+		}
+	}
+
+	// A different component is a different stream
+	if out := s.Sample(NewErrorEvent(Error, CodeEmitterFail, "emitter:file", "x")); len(out) != 1 {
+		t.Error("Different component should pass")
+	}
+
+	clk.Advance() // t=500ms, window still open
+	if out := s.Flush(); len(out) != 0 {
+		t.Errorf("Open window should not flush, got %d events", len(out))
+	}
+
+	clk.Advance() // t=1.1s, window closed
+	out := s.Flush()
+	if len(out) != 1 {
+		t.Fatalf("Expected 1 summary, got %d", len(out))
+	}
+	if out[0].Context[ContextRepeatCount] != 4 {
+		t.Errorf("Expected repeat_count=4, got %v", out[0].Context[ContextRepeatCount])
+	}
+	if _, ok := out[0].Context[ContextFirstSeen].(time.Time); !ok {
+		t.Error("Summary should carry first_seen")
+	}
+	if s.Suppressed() != 4 {
+		t.Errorf("Expected 4 suppressed, got %d", s.Suppressed())
+	}
+	if fail.Context[ContextRepeatCount] != nil {
+		t.Error("Summary must not modify the original event context")
+	}
+}
+
+func TestErrorSampler_TokenBucketPerCode(t *testing.T) {
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+
+	s := NewErrorSampler(WithSampleWindow(time.Nanosecond), WithSampleRate(1, 2), WithSampleClock(clk))
+	passed := 0
+	for i := 0; i < 5; i++ {
+		// Distinct components so aggregation does not apply, only the code bucket
+		evt := NewErrorEvent(WarningSeverity, CodeDropSlow, string(rune('a'+i)), "drop")
+		for _, out := range s.Sample(evt) {
+			if out.Context[ContextRepeatCount] == nil {
+				passed++
+			}
+		}
+	}
+	if passed != 2 {
+		t.Errorf("Expected burst of 2 to pass, got %d", passed)
+	}
+
+	// Rate-limited events surface in summaries
+	if n := len(s.FlushAll()); n != 3 {
+		t.Errorf("Expected 3 summaries for rate-limited events, got %d", n)
+	}
+}
+
+func TestErrorSampler_CriticalPassesThrough(t *testing.T) {
+	s := NewErrorSampler(WithSampleRate(1, 1))
+	oom := NewErrorEvent(CriticalSeverity, CodeOOMImminent, "monitor:psi", "oom")
+	for i := 0; i < 10; i++ {
+		if out := s.Sample(oom); len(out) != 1 {
+			t.Fatalf("Critical event %d should pass unconditionally", i)
+		}
+	}
+}
+
+func TestErrorBus_Sampling(t *testing.T) {
+	bus := NewErrorBus(64)
+	bus.SetSampler(NewErrorSampler(WithSampleWindow(time.Hour)))
+	sub, _ := bus.Subscribe(t.Context())
+
+	for i := 0; i < 10; i++ {
+		bus.Publish(NewErrorEvent(WarningSeverity, CodeEmitterFail, "emitter:x", "fail"))
+	}
+	if n := len(sub.Events()); n != 1 {
+		t.Errorf("Expected 1 event before close, got %d", n)
+	}
+
+	// Close delivers pending summaries before closing subscriptions
+	bus.Close()
+	var events []ErrorEvent
+	for evt := range sub.Events() {
+		events = append(events, evt)
+	}
+	if len(events) != 2 || events[1].Context[ContextRepeatCount] != 9 {
+		t.Errorf("Expected first event plus a summary of 9, got %v", events)
+	}
+}
@@ -0,0 +1,361 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-json-experiment/json"
+)
+
+// ErrorSink consumes error events from an ErrorBus (see AttachSink).
+// Write is called from a single goroutine per attachment.
+type ErrorSink interface {
+	// Write outputs one error event
+	Write(evt ErrorEvent) error
+
+	// Close flushes and releases the sink
+	Close() error
+}
+
+// SinkStats counts what happened to events routed to a sink.
+type SinkStats struct {
+	Written uint64 // Events written successfully
+	Failed  uint64 // Events the sink returned an error for
+	Dropped uint64 // Events lost to full buffers (subscription or sink queue)
+}
+
+// SinkOption configures an attached sink.
+type SinkOption func(*sinkConfig)
+
+// sinkConfig collects AttachSink options.
+type sinkConfig struct {
+	buffer int
+	filter ErrorFilter
+}
+
+// WithSinkBuffer sets how many events may queue for a slow sink before
+// newer events are dropped.
+func WithSinkBuffer(n int) SinkOption {
+	return func(c *sinkConfig) {
+		c.buffer = n
+	}
+}
+
+// WithSinkFilter routes only matching events to the sink.
+func WithSinkFilter(filter ErrorFilter) SinkOption {
+	return func(c *sinkConfig) {
+		c.filter = filter
+	}
+}
+
+// AttachedSink is a sink receiving events from an ErrorBus.
+type AttachedSink struct {
+	sink  ErrorSink
+	bus   *ErrorBus
+	sub   *ErrorSubscription
+	queue chan ErrorEvent
+	done  chan struct{}
+
+	written atomic.Uint64
+	failed  atomic.Uint64
+	dropped atomic.Uint64 // Sink queue overflows (subscription drops are counted by sub)
+
+	mu      sync.Mutex
+	lastErr error
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// AttachSink subscribes sink to bus. Events are queued (default 256) and
+// written from a background goroutine, so a slow sink never blocks
+// publishers; overflow is dropped and counted. The sink is closed when the
+// attachment is closed or the bus shuts down and the queue has been written.
+//
+// Usage:
+//
+//	attached, _ := event.AttachSink(eng.ErrorBus(), event.NewStderrSink(),
+//	    event.WithSinkFilter(event.ErrorFilter{MinSeverity: event.WarningSeverity}))
+//	defer attached.Close()
+func AttachSink(bus *ErrorBus, sink ErrorSink, opts ...SinkOption) (*AttachedSink, error) {
+	cfg := sinkConfig{buffer: 256}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.buffer < 1 {
+		cfg.buffer = 1
+	}
+
+	sub, err := bus.SubscribeFiltered(context.Background(), cfg.filter)
+	if err != nil {
+		return nil, fmt.Errorf("sink: subscribe: %w", err)
+	}
+
+	a := &AttachedSink{
+		sink:  sink,
+		bus:   bus,
+		sub:   sub,
+		queue: make(chan ErrorEvent, cfg.buffer),
+		done:  make(chan struct{}),
+	}
+	go a.forward()
+	go a.write()
+	return a, nil
+}
+
+// forward moves events from the subscription into the sink queue without
+// blocking, so the subscription buffer stays free.
+func (a *AttachedSink) forward() {
+	defer close(a.queue)
+	for evt := range a.sub.Events() {
+		select {
+		case a.queue <- evt:
+		default:
+			a.dropped.Add(1)
+		}
+	}
+}
+
+// write drains the sink queue into the sink.
+func (a *AttachedSink) write() {
+	defer close(a.done)
+	for evt := range a.queue {
+		if err := a.sink.Write(evt); err != nil {
+			a.failed.Add(1)
+			a.mu.Lock()
+			a.lastErr = err
+			a.mu.Unlock()
+			continue
+		}
+		a.written.Add(1)
+	}
+}
+
+// Stats returns delivery counters for the sink.
+func (a *AttachedSink) Stats() SinkStats {
+	return SinkStats{
+		Written: a.written.Load(),
+		Failed:  a.failed.Load(),
+		Dropped: a.dropped.Load() + a.sub.Dropped(),
+	}
+}
+
+// LastError returns the most recent sink write error, if any.
+func (a *AttachedSink) LastError() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lastErr
+}
+
+// Close detaches the sink, waits for queued events to be written and closes
+// the sink. Safe to call multiple times and after the bus has closed.
+func (a *AttachedSink) Close() error {
+	a.closeOnce.Do(func() {
+		a.bus.Unsubscribe(a.sub)
+		<-a.done
+		a.closeErr = a.sink.Close()
+	})
+	return a.closeErr
+}
+
+// LevelCritical is the slog level used for CriticalSeverity events.
+const LevelCritical = slog.LevelError + 4
+
+// SeverityLevel maps an error severity to an slog level.
+func SeverityLevel(sev ErrorSeverity) slog.Level {
+	switch sev {
+	case DebugSeverity:
+		return slog.LevelDebug
+	case InfoSeverity:
+		return slog.LevelInfo
+	case WarningSeverity:
+		return slog.LevelWarn
+	case Error:
+		return slog.LevelError
+	default:
+		return LevelCritical
+	}
+}
+
+// SlogSink writes error events to an slog.Handler. Severity maps to the
+// record level, code/component/signal/recoverable become attributes and
+// Context is added as a "context" group.
+type SlogSink struct {
+	handler slog.Handler
+}
+
+// NewSlogSink creates a sink that forwards to handler.
+func NewSlogSink(handler slog.Handler) *SlogSink {
+	return &SlogSink{handler: handler}
+}
+
+// Write converts evt to an slog.Record and hands it to the handler.
+func (s *SlogSink) Write(evt ErrorEvent) error {
+	ctx := context.Background()
+	level := SeverityLevel(evt.Severity)
+	if !s.handler.Enabled(ctx, level) {
+		return nil
+	}
+
+	rec := slog.NewRecord(evt.Timestamp, level, evt.Message, 0)
+	rec.AddAttrs(
+		slog.String("code", evt.Code),
+		slog.String("component", evt.Component),
+		slog.Bool("recoverable", evt.Recoverable),
+	)
+	if evt.Signal != SignalNone {
+		rec.AddAttrs(slog.String("signal", evt.Signal.String()))
+	}
+	if len(evt.Context) > 0 {
+		attrs := make([]any, 0, len(evt.Context))
+		for _, k := range slices.Sorted(maps.Keys(evt.Context)) {
+			attrs = append(attrs, slog.Any(k, evt.Context[k]))
+		}
+		rec.AddAttrs(slog.Group("context", attrs...))
+	}
+	return s.handler.Handle(ctx, rec)
+}
+
+// Close is a no-op; the handler's output is owned by the caller.
+func (s *SlogSink) Close() error {
+	return nil
+}
+
+// JSONLSink appends error events to a size-rotated JSON Lines file, one
+// ErrorRecord per line.
+type JSONLSink struct {
+	mu  sync.Mutex
+	out *rotatingFile
+}
+
+// JSONLOption configures a JSONLSink.
+type JSONLOption func(*jsonlConfig)
+
+// jsonlConfig collects JSONLSink options.
+type jsonlConfig struct {
+	maxBytes int64
+	maxFiles int
+}
+
+// WithJSONLRotation sets the rotation size and how many old segments to keep.
+func WithJSONLRotation(maxBytes int64, maxFiles int) JSONLOption {
+	return func(c *jsonlConfig) {
+		c.maxBytes = maxBytes
+		c.maxFiles = maxFiles
+	}
+}
+
+// NewJSONLSink creates a sink writing to path.
+// Defaults: rotate at 64 MiB, keep 5 old segments.
+func NewJSONLSink(path string, opts ...JSONLOption) (*JSONLSink, error) {
+	cfg := jsonlConfig{maxBytes: 64 << 20, maxFiles: 5}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	out, err := openRotatingFile("jsonl sink", path, cfg.maxBytes, cfg.maxFiles)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLSink{out: out}, nil
+}
+
+// Write appends evt as one JSON line and flushes it, so the file is complete
+// up to the last event even if the process dies.
+func (s *JSONLSink) Write(evt ErrorEvent) error {
+	line, err := json.Marshal(NewErrorRecord(evt))
+	if err != nil {
+		return fmt.Errorf("jsonl sink: marshal %s: %w", evt.Code, err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.out.Write(line); err != nil {
+		return err
+	}
+	return s.out.Flush()
+}
+
+// Close flushes and closes the file.
+func (s *JSONLSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.out.Close()
+}
+
+// ANSI colors by severity for ConsoleSink.
+var severityColors = map[ErrorSeverity]string{
+	DebugSeverity:    "\x1b[90m",   // Gray
+	InfoSeverity:     "\x1b[36m",   // Cyan
+	WarningSeverity:  "\x1b[33m",   // Yellow
+	Error:            "\x1b[31m",   // Red
+	CriticalSeverity: "\x1b[1;31m", // Bold red
+}
+
+const colorReset = "\x1b[0m"
+
+// ConsoleSink writes one human-readable line per error event:
+//
+//	15:04:05.000 WARNING  MEM_PRESSURE monitor:memory: Memory usage high [THROTTLE] heap_alloc=512MB usage_pct=72.0%
+type ConsoleSink struct {
+	mu    sync.Mutex
+	w     io.Writer
+	color bool
+}
+
+// NewConsoleSink creates a sink writing to w, with ANSI colors if color is set.
+func NewConsoleSink(w io.Writer, color bool) *ConsoleSink {
+	return &ConsoleSink{w: w, color: color}
+}
+
+// NewStderrSink creates a console sink on stderr, colored when stderr is a
+// terminal and NO_COLOR is not set.
+func NewStderrSink() *ConsoleSink {
+	color := false
+	if info, err := os.Stderr.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		color = os.Getenv("NO_COLOR") == ""
+	}
+	return NewConsoleSink(os.Stderr, color)
+}
+
+// Write formats evt as a single line.
+func (s *ConsoleSink) Write(evt ErrorEvent) error {
+	var b strings.Builder
+	b.WriteString(evt.Timestamp.Format("15:04:05.000"))
+	b.WriteByte(' ')
+
+	severity := fmt.Sprintf("%-8s", evt.Severity)
+	if s.color {
+		severity = severityColors[evt.Severity] + severity + colorReset
+	}
+	b.WriteString(severity)
+
+	fmt.Fprintf(&b, " %s %s: %s", evt.Code, evt.Component, evt.Message)
+	if evt.Signal != SignalNone {
+		fmt.Fprintf(&b, " [%s]", evt.Signal)
+	}
+	for _, k := range slices.Sorted(maps.Keys(evt.Context)) {
+		fmt.Fprintf(&b, " %s=%v", k, evt.Context[k])
+	}
+	b.WriteByte('\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := io.WriteString(s.w, b.String())
+	return err
+}
+
+// Close is a no-op; the writer is owned by the caller.
+func (s *ConsoleSink) Close() error {
+	return nil
+}
@@ -0,0 +1,180 @@
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-json-experiment/json"
+)
+
+// memorySink records written events; it fails events with code "FAIL".
+type memorySink struct {
+	mu     sync.Mutex
+	events []ErrorEvent
+	closed bool
+	block  chan struct{} // If set, Write waits on it
+}
+
+func (s *memorySink) Write(evt ErrorEvent) error {
+	if s.block != nil {
+		<-s.block
+	}
+	if evt.Code == "FAIL" {
+		return errors.New("sink failure")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, evt)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func TestAttachSink_WritesAndCloses(t *testing.T) {
+	bus := NewErrorBus(32)
+	sink := &memorySink{}
+	attached, err := AttachSink(bus, sink, WithSinkFilter(ErrorFilter{MinSeverity: WarningSeverity}))
+	if err != nil {
+		t.Fatalf("AttachSink failed: %v", err)
+	}
+
+	bus.Publish(NewErrorEvent(InfoSeverity, CodeHealthCheck, "engine", "filtered out"))
+	bus.Publish(NewErrorEvent(WarningSeverity, CodeMemPressure, "monitor:memory", "high"))
+	bus.Publish(NewErrorEvent(Error, "FAIL", "test", "sink rejects this"))
+
+	// Close waits until queued events are written
+	if err := attached.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	attached.Close() // Idempotent
+
+	if len(sink.events) != 1 || sink.events[0].Code != CodeMemPressure {
+		t.Errorf("Expected only MEM_PRESSURE written, got %v", sink.events)
+	}
+	if !sink.closed {
+		t.Error("Sink should be closed")
+	}
+	stats := attached.Stats()
+	if stats.Written != 1 || stats.Failed != 1 || attached.LastError() == nil {
+		t.Errorf("Expected 1 written and 1 failed, got %+v (last error %v)", stats, attached.LastError())
+	}
+}
+
+func TestAttachSink_DropsWhenSinkIsSlow(t *testing.T) {
+	bus := NewErrorBus(1)
+	sink := &memorySink{block: make(chan struct{})}
+	attached, _ := AttachSink(bus, sink, WithSinkBuffer(2))
+
+	for i := 0; i < 20; i++ {
+		bus.Publish(NewErrorEvent(WarningSeverity, CodeDropSlow, "bus:test", "drop"))
+		time.Sleep(time.Millisecond) // Let the forwarder move events to the sink queue
+	}
+	close(sink.block)
+	bus.Close()
+	attached.Close()
+
+	stats := attached.Stats()
+	if stats.Dropped == 0 {
+		t.Error("Expected drops with a blocked sink")
+	}
+	if stats.Written+stats.Dropped != 20 {
+		t.Errorf("Expected every event written or dropped, got %+v", stats)
+	}
+}
+
+func TestSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewSlogSink(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	sink.Write(NewErrorEvent(DebugSeverity, CodeHealthCheck, "engine", "below level"))
+	sink.Write(NewErrorEvent(CriticalSeverity, CodeOOMImminent, "monitor:psi", "oom").
+		WithSignal(SignalShed).
+		WithContext("avg10", 42.5))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 log line, got %d: %q", len(lines), buf.String())
+	}
+
+	var rec map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if rec["level"] != "ERROR+4" || rec["code"] != CodeOOMImminent || rec["signal"] != "SHED" {
+		t.Errorf("Unexpected record: %v", rec)
+	}
+	if ctx, _ := rec["context"].(map[string]any); ctx["avg10"] != 42.5 {
+		t.Errorf("Expected context group with avg10, got %v", rec["context"])
+	}
+}
+
+func TestJSONLSink_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+	sink, err := NewJSONLSink(path, WithJSONLRotation(300, 1))
+	if err != nil {
+		t.Fatalf("NewJSONLSink failed: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := sink.Write(NewErrorEvent(WarningSeverity, CodeBufSat, "bus:external", "saturated").WithContext("n", i)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	sink.Close()
+
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Errorf("Expected rotated segment: %v", err)
+	}
+	if _, err := os.Stat(path + ".2"); err == nil {
+		t.Error("Expected at most 1 rotated segment")
+	}
+
+	f, _ := os.Open(path)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	var last ErrorRecord
+	for scanner.Scan() {
+		if err := json.Unmarshal(scanner.Bytes(), &last); err != nil {
+			t.Fatalf("Invalid JSON line: %v", err)
+		}
+	}
+	if last.Severity != "WARNING" || last.Code != CodeBufSat || last.Context["n"] != 4.0 {
+		t.Errorf("Unexpected last record: %+v", last)
+	}
+}
+
+func TestConsoleSink_Format(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewConsoleSink(&buf, false)
+
+	evt := NewErrorEvent(WarningSeverity, CodeMemPressure, "monitor:memory", "Memory usage high").
+		WithSignal(SignalThrottle).
+		WithContext("usage_pct", "72.0%").
+		WithContext("heap_alloc", "512MB")
+	evt.Timestamp = time.Date(2025, 1, 1, 15, 4, 5, 0, time.UTC)
+	sink.Write(evt)
+
+	want := "15:04:05.000 WARNING  MEM_PRESSURE monitor:memory: Memory usage high [THROTTLE] heap_alloc=512MB usage_pct=72.0%\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	NewConsoleSink(&buf, true).Write(evt)
+	if !strings.Contains(buf.String(), "\x1b[33m") {
+		t.Error("Expected ANSI color for warnings")
+	}
+}
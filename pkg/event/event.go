@@ -33,6 +33,12 @@ type Event struct {
 
 	// CausationID identifies the event that directly caused this event
 	CausationID string `json:"causation_id,omitempty"`
+
+	// ExpiresAt is an absolute deadline after which the event is stale (zero = never)
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+
+	// TTL is a lifetime relative to Timestamp (zero = never)
+	TTL time.Duration `json:"ttl,omitzero,format:nano"`
 }
 
 // EventCodec defines how to serialize and deserialize event payloads.
@@ -95,6 +101,40 @@ func (e *Event) WithCausationID(id string) *Event {
 	return e
 }
 
+// WithTTL sets a lifetime relative to the event Timestamp.
+func (e *Event) WithTTL(ttl time.Duration) *Event {
+	e.TTL = ttl
+	return e
+}
+
+// WithDeadline sets an absolute expiry deadline.
+func (e *Event) WithDeadline(deadline time.Time) *Event {
+	e.ExpiresAt = deadline
+	return e
+}
+
+// Deadline returns the effective expiry time: the earlier of ExpiresAt and
+// Timestamp+TTL. Returns false if the event never expires.
+func (e *Event) Deadline() (time.Time, bool) {
+	var deadline time.Time
+	if !e.ExpiresAt.IsZero() {
+		deadline = e.ExpiresAt
+	}
+	if e.TTL > 0 && !e.Timestamp.IsZero() {
+		ttlDeadline := e.Timestamp.Add(e.TTL)
+		if deadline.IsZero() || ttlDeadline.Before(deadline) {
+			deadline = ttlDeadline
+		}
+	}
+	return deadline, !deadline.IsZero()
+}
+
+// Expired reports whether the event is stale at the given time.
+func (e *Event) Expired(now time.Time) bool {
+	deadline, ok := e.Deadline()
+	return ok && !now.Before(deadline)
+}
+
 // DecodePayload deserializes the event data into the provided struct.
 func (e *Event) DecodePayload(v any, codec EventCodec) error {
 	if len(e.Data) == 0 {
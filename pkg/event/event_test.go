@@ -302,3 +302,51 @@ func TestNewEvent_MarshalError(t *testing.T) {
 		t.Error("Expected error when marshaling invalid payload")
 	}
 }
+
+func TestEvent_Expiry(t *testing.T) {
+	now := time.Now()
+
+	never := &Event{Timestamp: now}
+	if never.Expired(now.Add(time.Hour)) {
+		t.Error("Event without TTL or deadline should never expire")
+	}
+
+	ttl := (&Event{Timestamp: now}).WithTTL(time.Second)
+	if ttl.Expired(now.Add(500 * time.Millisecond)) {
+		t.Error("Event should not be expired before TTL elapses")
+	}
+	if !ttl.Expired(now.Add(time.Second)) {
+		t.Error("Event should be expired once TTL elapses")
+	}
+
+	// Earlier of deadline and TTL wins
+	both := (&Event{Timestamp: now}).WithTTL(time.Minute).WithDeadline(now.Add(time.Second))
+	deadline, ok := both.Deadline()
+	if !ok || !deadline.Equal(now.Add(time.Second)) {
+		t.Errorf("Expected deadline at +1s, got %v (ok=%v)", deadline, ok)
+	}
+}
+
+func TestTTLPolicy_Apply(t *testing.T) {
+	policy := NewTTLPolicy().
+		Set("input.*", time.Second).
+		Set("input.key", 2*time.Second)
+
+	wildcard := &Event{Type: "input.mouse"}
+	policy.Apply(wildcard)
+	if wildcard.TTL != time.Second {
+		t.Errorf("Expected wildcard TTL 1s, got %s", wildcard.TTL)
+	}
+
+	exact := &Event{Type: "input.key"}
+	policy.Apply(exact)
+	if exact.TTL != 2*time.Second {
+		t.Errorf("Expected exact-match TTL 2s, got %s", exact.TTL)
+	}
+
+	explicit := (&Event{Type: "input.key"}).WithTTL(time.Millisecond)
+	policy.Apply(explicit)
+	if explicit.TTL != time.Millisecond {
+		t.Error("Policy should not override an explicit TTL")
+	}
+}
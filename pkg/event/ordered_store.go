@@ -215,3 +215,95 @@ func (s *OrderedEventStore) GetIntervals() []time.Duration {
 	}
 	return intervals
 }
+
+// CausationNode is one event in a causation tree along with the events it caused.
+type CausationNode struct {
+	Event    Event
+	Children []*CausationNode
+}
+
+// Size returns the number of events in the subtree rooted at n.
+func (n *CausationNode) Size() int {
+	count := 1
+	for _, child := range n.Children {
+		count += child.Size()
+	}
+	return count
+}
+
+// Walk visits every node depth-first with its depth (root = 0).
+func (n *CausationNode) Walk(fn func(node *CausationNode, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *CausationNode) walk(fn func(node *CausationNode, depth int), depth int) {
+	fn(n, depth)
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// CausationTree reconstructs the full workflow tree containing the given event.
+// It follows CausationID links up to the earliest ancestor present in the
+// store, then collects every descendant in chronological order.
+// Returns false if the event is not in the store.
+func (s *OrderedEventStore) CausationTree(eventID string) (*CausationNode, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byID := make(map[string]int, len(s.events))
+	children := make(map[string][]int)
+	for i, evt := range s.events {
+		byID[evt.ID] = i
+		if evt.CausationID != "" {
+			children[evt.CausationID] = append(children[evt.CausationID], i)
+		}
+	}
+
+	idx, ok := byID[eventID]
+	if !ok {
+		return nil, false
+	}
+
+	// Climb to the root, guarding against causation cycles
+	visited := map[string]bool{eventID: true}
+	for {
+		parent, ok := byID[s.events[idx].CausationID]
+		if !ok || visited[s.events[parent].ID] {
+			break
+		}
+		visited[s.events[parent].ID] = true
+		idx = parent
+	}
+
+	built := make(map[string]bool)
+	var build func(i int) *CausationNode
+	build = func(i int) *CausationNode {
+		node := &CausationNode{Event: s.events[i]}
+		built[s.events[i].ID] = true
+		for _, c := range children[s.events[i].ID] {
+			if !built[s.events[c].ID] {
+				node.Children = append(node.Children, build(c))
+			}
+		}
+		return node
+	}
+
+	return build(idx), true
+}
+
+// GetByCorrelation returns all events sharing a correlation ID, in
+// chronological order. The workflow's root event (whose ID is the
+// correlation ID) is included.
+func (s *OrderedEventStore) GetByCorrelation(correlationID string) []Event {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []Event
+	for _, evt := range s.events {
+		if evt.CorrelationID == correlationID || evt.ID == correlationID {
+			result = append(result, evt)
+		}
+	}
+	return result
+}
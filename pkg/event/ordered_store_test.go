@@ -637,3 +637,49 @@ func TestOrderedEventStore_GetIntervals_EdgeCases(t *testing.T) {
 		t.Errorf("Expected 1 interval from two events, got %d", len(intervals))
 	}
 }
+
+func TestOrderedEventStore_CausationTree(t *testing.T) {
+	store := NewOrderedEventStore()
+	base := time.Now()
+
+	// root → a → a1
+	//      → b
+	store.Append(Event{ID: "root", Timestamp: base})
+	store.Append(Event{ID: "a", CausationID: "root", CorrelationID: "root", Timestamp: base.Add(1 * time.Millisecond)})
+	store.Append(Event{ID: "b", CausationID: "root", CorrelationID: "root", Timestamp: base.Add(2 * time.Millisecond)})
+	store.Append(Event{ID: "a1", CausationID: "a", CorrelationID: "root", Timestamp: base.Add(3 * time.Millisecond)})
+	store.Append(Event{ID: "unrelated", Timestamp: base.Add(4 * time.Millisecond)})
+
+	// Any member of the workflow yields the whole tree
+	tree, ok := store.CausationTree("a1")
+	if !ok {
+		t.Fatal("Expected tree for a1")
+	}
+	if tree.Event.ID != "root" {
+		t.Errorf("Expected root 'root', got %s", tree.Event.ID)
+	}
+	if tree.Size() != 4 {
+		t.Errorf("Expected 4 events in tree, got %d", tree.Size())
+	}
+	if len(tree.Children) != 2 || tree.Children[0].Event.ID != "a" || tree.Children[1].Event.ID != "b" {
+		t.Errorf("Unexpected children order")
+	}
+
+	var depthOfA1 int
+	tree.Walk(func(n *CausationNode, depth int) {
+		if n.Event.ID == "a1" {
+			depthOfA1 = depth
+		}
+	})
+	if depthOfA1 != 2 {
+		t.Errorf("Expected a1 at depth 2, got %d", depthOfA1)
+	}
+
+	if _, ok := store.CausationTree("missing"); ok {
+		t.Error("Expected no tree for unknown ID")
+	}
+
+	if got := len(store.GetByCorrelation("root")); got != 4 {
+		t.Errorf("Expected 4 events in workflow, got %d", got)
+	}
+}
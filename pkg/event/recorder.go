@@ -0,0 +1,185 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/go-json-experiment/json"
+)
+
+// Record is one captured event with the engine time at which it was seen.
+// Recordings are stored as JSON lines, one Record per line.
+type Record struct {
+	// Mono is the recorder clock reading when the event was received
+	Mono clock.MonoTime `json:"mono"`
+
+	// Event is the captured event
+	Event *Event `json:"event"`
+}
+
+// Recorder subscribes to a bus and writes every matching event, stamped with
+// its MonoTime, to a rotating JSONL file. A recording can be fed back into a
+// fresh engine with Replayer to reproduce a captured session.
+//
+// Rotation keeps at most maxFiles segments:
+//
+//	traffic.jsonl     (current)
+//	traffic.jsonl.1   (previous)
+//	traffic.jsonl.2   (older) ...
+//
+// Usage:
+//
+//	rec, err := event.NewRecorder("traffic.jsonl", event.WithRecorderClock(eng.Clock()))
+//	rec.Start(ctx, eng.ExternalBus(), event.Filter{})
+//	defer rec.Close()
+type Recorder struct {
+	path     string
+	maxBytes int64 // Rotate when the current file reaches this size (0 = never)
+	maxFiles int   // Rotated segments to keep, excluding the current file
+	clock    clock.Clock
+
+	mu       sync.Mutex
+	out      *rotatingFile
+	recorded uint64
+	lastErr  error
+
+	sub  Subscription
+	done chan struct{}
+}
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithRecorderClock sets the clock used to stamp records (use the engine clock).
+func WithRecorderClock(clk clock.Clock) RecorderOption {
+	return func(r *Recorder) {
+		r.clock = clk
+	}
+}
+
+// WithRecorderRotation sets the rotation size and how many old segments to keep.
+func WithRecorderRotation(maxBytes int64, maxFiles int) RecorderOption {
+	return func(r *Recorder) {
+		r.maxBytes = maxBytes
+		r.maxFiles = maxFiles
+	}
+}
+
+// NewRecorder creates a recorder writing to path.
+// Defaults: system clock, rotate at 64 MiB, keep 5 old segments.
+func NewRecorder(path string, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:     path,
+		maxBytes: 64 << 20,
+		maxFiles: 5,
+		clock:    clock.NewSystemClock(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	out, err := openRotatingFile("recorder", r.path, r.maxBytes, r.maxFiles)
+	if err != nil {
+		return nil, err
+	}
+	r.out = out
+	return r, nil
+}
+
+// Start subscribes to bus and records matching events until ctx is cancelled
+// or Close is called.
+func (r *Recorder) Start(ctx context.Context, bus Bus, filter Filter) error {
+	sub, err := SubscribeNamed(ctx, bus, "recorder", filter)
+	if err != nil {
+		return fmt.Errorf("recorder: subscribe: %w", err)
+	}
+
+	r.mu.Lock()
+	r.sub = sub
+	r.done = make(chan struct{})
+	r.mu.Unlock()
+
+	go r.run(ctx, sub, r.done)
+	return nil
+}
+
+// run drains the subscription into the file.
+func (r *Recorder) run(ctx context.Context, sub Subscription, done chan struct{}) {
+	defer close(done)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case evt, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if err := r.Record(evt); err != nil {
+				r.mu.Lock()
+				r.lastErr = err
+				r.mu.Unlock()
+			}
+		}
+	}
+}
+
+// Record writes a single event stamped with the current clock reading.
+// It can be called directly when the recorder is not attached to a bus.
+func (r *Recorder) Record(evt *Event) error {
+	line, err := json.Marshal(Record{Mono: r.clock.Now(), Event: evt})
+	if err != nil {
+		return fmt.Errorf("recorder: marshal event %s: %w", evt.ID, err)
+	}
+	line = append(line, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.out.Write(line); err != nil {
+		return err
+	}
+	r.recorded++
+	return nil
+}
+
+// Flush writes buffered records to disk.
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.out.Flush()
+}
+
+// Recorded returns the number of events written.
+func (r *Recorder) Recorded() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.recorded
+}
+
+// LastError returns the most recent background write error, if any.
+func (r *Recorder) LastError() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastErr
+}
+
+// Close stops recording, waits for queued events to be written and closes
+// the file. Safe to call multiple times.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	sub, done := r.sub, r.done
+	r.sub = nil
+	r.mu.Unlock()
+
+	if sub != nil {
+		sub.Close()
+		<-done
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.out.Close()
+}
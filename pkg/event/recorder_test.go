@@ -0,0 +1,180 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+)
+
+func TestRecorder_RecordsBusTraffic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traffic.jsonl")
+	clk := clock.NewDeltaClock()
+	clk.SetNoSleep(true)
+	clk.Load(clock.FromDuration(time.Second), []time.Duration{10 * time.Millisecond})
+
+	rec, err := NewRecorder(path, WithRecorderClock(clk))
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+
+	bus := NewInMemoryBus()
+	defer bus.Close()
+	ctx := context.Background()
+	if err := rec.Start(ctx, bus, Filter{Types: []string{"input.key"}}); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	bus.Publish(ctx, &Event{ID: "a", Type: "input.key", Metadata: map[string]string{"key": "x"}})
+	bus.Publish(ctx, &Event{ID: "skip", Type: "app.other"})
+	waitForRecorded(t, rec, 1)
+
+	clk.Advance()
+	bus.Publish(ctx, &Event{ID: "b", Type: "input.key"})
+	waitForRecorded(t, rec, 2)
+
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	records, err := ReadRecording(path)
+	if err != nil {
+		t.Fatalf("ReadRecording failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].Event.ID != "a" || records[0].Event.Metadata["key"] != "x" {
+		t.Errorf("Unexpected first record: %+v", records[0].Event)
+	}
+	if gap := clock.ToDuration(records[1].Mono - records[0].Mono); gap != 10*time.Millisecond {
+		t.Errorf("Expected 10ms between records, got %v", gap)
+	}
+}
+
+func TestRecorder_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traffic.jsonl")
+	rec, err := NewRecorder(path, WithRecorderRotation(200, 2))
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := rec.Record(&Event{ID: fmt.Sprintf("evt-%d", i), Type: "test.event"}); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+	rec.Close()
+
+	if _, err := os.Stat(path + ".2"); err != nil {
+		t.Fatalf("Expected second rotated segment: %v", err)
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("Expected at most 2 rotated segments")
+	}
+
+	records, err := ReadRecording(path)
+	if err != nil {
+		t.Fatalf("ReadRecording failed: %v", err)
+	}
+	if len(records) == 0 || len(records) >= 10 {
+		t.Fatalf("Expected oldest records to be rotated out, got %d", len(records))
+	}
+	// Surviving records are the newest, in order
+	for i, r := range records {
+		want := fmt.Sprintf("evt-%d", 10-len(records)+i)
+		if r.Event.ID != want {
+			t.Errorf("Record %d: expected %s, got %s", i, want, r.Event.ID)
+		}
+	}
+}
+
+func TestReplayer_PreservesTiming(t *testing.T) {
+	ms := func(n int) clock.MonoTime { return clock.FromDuration(time.Duration(n) * time.Millisecond) }
+	records := []Record{
+		{Mono: ms(100), Event: &Event{ID: "a", Type: "input.key"}},
+		{Mono: ms(150), Event: &Event{ID: "b", Type: "input.key"}},
+		{Mono: ms(400), Event: &Event{ID: "c", Type: "input.key"}},
+	}
+
+	replayer := NewReplayer(records, WithReplayNoSleep())
+	bus := NewInMemoryBus()
+	defer bus.Close()
+
+	ctx := context.Background()
+	sub, _ := bus.Subscribe(ctx, Filter{})
+
+	n, err := replayer.Replay(ctx, bus)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("Expected 3 published, got %d", n)
+	}
+
+	for i, want := range []string{"a", "b", "c"} {
+		evt := <-sub.Events()
+		if evt.ID != want {
+			t.Errorf("Event %d: expected %s, got %s", i, want, evt.ID)
+		}
+	}
+	if replayer.Clock().Now() != ms(400) {
+		t.Errorf("Expected replay clock at 400ms, got %v", clock.ToDuration(replayer.Clock().Now()))
+	}
+
+	// Replay is repeatable
+	replayer.Reset()
+	if replayer.Clock().Now() != ms(100) {
+		t.Errorf("Expected clock reset to 100ms, got %v", clock.ToDuration(replayer.Clock().Now()))
+	}
+	if n, _ := replayer.Replay(ctx, bus); n != 3 {
+		t.Errorf("Expected second replay to publish 3, got %d", n)
+	}
+}
+
+func TestReplayer_RebaseTimestamps(t *testing.T) {
+	captured := time.Now().Add(-time.Hour)
+	records := []Record{
+		{Mono: 0, Event: &Event{ID: "a", Type: "input.key", Timestamp: captured, TTL: time.Minute}},
+	}
+
+	bus := NewInMemoryBus()
+	defer bus.Close()
+	ctx := context.Background()
+	sub, _ := bus.Subscribe(ctx, Filter{})
+
+	// Without rebasing the captured event is long expired
+	NewReplayer(records, WithReplayNoSleep()).Replay(ctx, bus)
+	if len(sub.Events()) != 0 {
+		t.Fatal("Expected stale event to be dropped")
+	}
+
+	NewReplayer(records, WithReplayNoSleep(), WithRebaseTimestamps()).Replay(ctx, bus)
+	select {
+	case evt := <-sub.Events():
+		if time.Since(evt.Timestamp) > time.Minute {
+			t.Errorf("Expected rebased timestamp, got %v", evt.Timestamp)
+		}
+	default:
+		t.Fatal("Expected rebased event to be delivered")
+	}
+	if !records[0].Event.Timestamp.Equal(captured) {
+		t.Error("Replay must not modify the recorded event")
+	}
+}
+
+// waitForRecorded polls until the recorder has written n events.
+func waitForRecorded(t *testing.T, rec *Recorder, n uint64) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for rec.Recorded() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %d recorded events (got %d)", n, rec.Recorded())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
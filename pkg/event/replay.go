@@ -0,0 +1,202 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/clock"
+	"github.com/go-json-experiment/json"
+)
+
+// ReadRecording loads a recording written by Recorder, including its rotated
+// segments (path.N ... path.1, then path), in capture order.
+func ReadRecording(path string) ([]Record, error) {
+	// Find the oldest rotated segment
+	var segments []string
+	for n := 1; ; n++ {
+		p := segmentPath(path, n)
+		if _, err := os.Stat(p); err != nil {
+			break
+		}
+		segments = append([]string{p}, segments...)
+	}
+	segments = append(segments, path)
+
+	var records []Record
+	for _, p := range segments {
+		seg, err := readSegment(p)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, seg...)
+	}
+	return records, nil
+}
+
+// readSegment parses one JSONL file.
+func readSegment(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("replay: open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16<<20) // Allow large payloads
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("replay: %s line %d: %w", path, line, err)
+		}
+		if rec.Event == nil {
+			return nil, fmt.Errorf("replay: %s line %d: missing event", path, line)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("replay: read %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// Replayer republishes recorded events with their original inter-arrival
+// timing. Timing is driven by a clock.DeltaClock loaded with the gaps between
+// records, so replay can run in real time, at a speed multiple, or with no
+// sleeping at all for deterministic tests.
+//
+// Pass Clock() to the fresh engine (engine.WithClock) so components that read
+// engine time observe the recorded timeline:
+//
+//	records, _ := event.ReadRecording("traffic.jsonl")
+//	replayer := event.NewReplayer(records, event.WithReplayNoSleep())
+//	eng := engine.New(engine.WithClock(replayer.Clock()))
+//	replayer.Replay(ctx, eng.ExternalBus())
+type Replayer struct {
+	records         []Record
+	clock           *clock.DeltaClock
+	rebaseTimestamp bool
+}
+
+// ReplayOption configures a Replayer.
+type ReplayOption func(*Replayer)
+
+// WithReplaySpeed sets the playback speed multiplier (1.0 = real time, 2.0 = 2x).
+func WithReplaySpeed(mult float64) ReplayOption {
+	return func(r *Replayer) {
+		r.clock.SetSpeed(mult)
+	}
+}
+
+// WithReplayNoSleep replays as fast as possible while still advancing the
+// clock through the recorded gaps.
+func WithReplayNoSleep() ReplayOption {
+	return func(r *Replayer) {
+		r.clock.SetNoSleep(true)
+	}
+}
+
+// WithRebaseTimestamps shifts event timestamps and deadlines so the first
+// event appears to have been created at replay start. Without it, events with
+// a TTL captured long ago are dropped as expired.
+func WithRebaseTimestamps() ReplayOption {
+	return func(r *Replayer) {
+		r.rebaseTimestamp = true
+	}
+}
+
+// NewReplayer creates a replayer for records (in capture order).
+func NewReplayer(records []Record, opts ...ReplayOption) *Replayer {
+	r := &Replayer{
+		records: records,
+		clock:   clock.NewDeltaClock(),
+	}
+
+	var start clock.MonoTime
+	var deltas []time.Duration
+	if len(records) > 0 {
+		start = records[0].Mono
+		deltas = make([]time.Duration, 0, len(records)-1)
+		for i := 1; i < len(records); i++ {
+			gap := records[i].Mono - records[i-1].Mono
+			if gap < 0 {
+				gap = 0
+			}
+			deltas = append(deltas, clock.ToDuration(gap))
+		}
+	}
+	r.clock.Load(start, deltas)
+
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Clock returns the replay clock. Its Now() reports the MonoTime of the most
+// recently replayed record.
+func (r *Replayer) Clock() *clock.DeltaClock {
+	return r.clock
+}
+
+// Len returns the number of records.
+func (r *Replayer) Len() int {
+	return len(r.records)
+}
+
+// Replay publishes every record to bus, advancing the clock between records.
+// Returns the number of events published. Each event is copied, so the
+// records can be replayed again after Reset.
+func (r *Replayer) Replay(ctx context.Context, bus Bus) (int, error) {
+	var shift time.Duration
+	if r.rebaseTimestamp && len(r.records) > 0 {
+		shift = time.Since(r.records[0].Event.Timestamp)
+	}
+
+	for i, rec := range r.records {
+		if i > 0 {
+			r.clock.Advance()
+		}
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+
+		evt := copyEvent(rec.Event)
+		if shift != 0 {
+			evt.Timestamp = evt.Timestamp.Add(shift)
+			if !evt.ExpiresAt.IsZero() {
+				evt.ExpiresAt = evt.ExpiresAt.Add(shift)
+			}
+		}
+
+		if err := bus.Publish(ctx, evt); err != nil {
+			return i, fmt.Errorf("replay: publish record %d (%s): %w", i, evt.ID, err)
+		}
+	}
+	return len(r.records), nil
+}
+
+// Reset rewinds the clock so the recording can be replayed again.
+func (r *Replayer) Reset() {
+	r.clock.Reset()
+}
+
+// copyEvent returns a copy with its own metadata map.
+func copyEvent(evt *Event) *Event {
+	cp := *evt
+	if evt.Metadata != nil {
+		cp.Metadata = make(map[string]string, len(evt.Metadata))
+		for k, v := range evt.Metadata {
+			cp.Metadata[k] = v
+		}
+	}
+	return &cp
+}
@@ -0,0 +1,402 @@
+package event
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Retention keeps a bounded history of published events so that late
+// subscribers can catch up (see WithRetainedLatest, WithRetainedLast and
+// WithRetainedSince).
+//
+// Two layouts are supported:
+//   - Per type: one ring of up to N events for every event type, so a chatty
+//     type cannot push out the last value of a quiet one (MQTT-style retained)
+//   - Global: one ring of up to N events across all types
+//
+// Both are additionally bounded by an approximate byte budget; once exceeded,
+// the oldest events are evicted regardless of type. SetScale shrinks both
+// limits at runtime (the engine does this while the governor is degraded).
+//
+// Usage:
+//
+//	retention := event.NewRetention(event.WithRetentionPerType(16))
+//	bus := event.NewInMemoryBus(event.WithRetention(retention))
+//	sub, _ := bus.SubscribeWithOptions(ctx, filter, event.WithRetainedLatest())
+type Retention struct {
+	perType  bool
+	capacity int   // Events per ring
+	maxBytes int64 // 0 = unbounded
+
+	mu      sync.Mutex
+	now     func() time.Time // Expiry clock of the owning bus (nil = time.Now)
+	scale   float64          // Fraction of capacity and maxBytes in effect (0-1]
+	seq     uint64
+	rings   map[string][]retainedEvent // Keyed by type, or "" in global mode
+	bytes   int64
+	count   int
+	evicted uint64
+}
+
+// retainedEvent is one entry in a retention ring.
+type retainedEvent struct {
+	seq  uint64 // Publish order across all rings
+	evt  *Event
+	size int64
+}
+
+// RetentionOption configures a Retention store.
+type RetentionOption func(*Retention)
+
+// WithRetentionPerType keeps the last n events of every event type.
+func WithRetentionPerType(n int) RetentionOption {
+	return func(r *Retention) {
+		r.perType = true
+		r.capacity = n
+	}
+}
+
+// WithRetentionGlobal keeps the last n events across all types.
+func WithRetentionGlobal(n int) RetentionOption {
+	return func(r *Retention) {
+		r.perType = false
+		r.capacity = n
+	}
+}
+
+// WithRetentionMaxBytes caps the approximate memory held by retained events.
+// Zero means no byte limit.
+func WithRetentionMaxBytes(n int64) RetentionOption {
+	return func(r *Retention) {
+		r.maxBytes = n
+	}
+}
+
+// NewRetention creates a retention store.
+//
+// Defaults:
+//   - layout: per type, 1 event (last value only)
+//   - maxBytes: unbounded
+func NewRetention(opts ...RetentionOption) *Retention {
+	r := &Retention{
+		perType:  true,
+		capacity: 1,
+		scale:    1.0,
+		rings:    make(map[string][]retainedEvent),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.capacity < 1 {
+		r.capacity = 1
+	}
+	return r
+}
+
+// setClock makes the store expire events on the owning bus's clock.
+func (r *Retention) setClock(now func() time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.now = now
+}
+
+// Add retains an event, evicting older events as needed.
+func (r *Retention) Add(evt *Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := r.key(evt)
+	r.seq++
+	entry := retainedEvent{seq: r.seq, evt: evt, size: retainedSize(evt)}
+	r.rings[key] = append(r.rings[key], entry)
+	r.bytes += entry.size
+	r.count++
+
+	r.enforce()
+}
+
+// key returns the ring an event belongs to.
+func (r *Retention) key(evt *Event) string {
+	if r.perType {
+		return evt.Type
+	}
+	return ""
+}
+
+// enforce evicts events until the scaled count and byte limits hold.
+// Caller must hold r.mu.
+func (r *Retention) enforce() {
+	capacity := r.scaledCapacity()
+	for key, ring := range r.rings {
+		for len(ring) > capacity {
+			ring = r.evictHead(key, ring)
+		}
+	}
+
+	maxBytes := r.scaledMaxBytes()
+	for maxBytes > 0 && r.bytes > maxBytes && r.count > 0 {
+		// Evict the oldest event across all rings
+		oldest := ""
+		var oldestSeq uint64
+		for key, ring := range r.rings {
+			if oldestSeq == 0 || ring[0].seq < oldestSeq {
+				oldest, oldestSeq = key, ring[0].seq
+			}
+		}
+		r.evictHead(oldest, r.rings[oldest])
+	}
+}
+
+// evictHead drops the oldest event of a ring and returns the remainder.
+// Caller must hold r.mu.
+func (r *Retention) evictHead(key string, ring []retainedEvent) []retainedEvent {
+	r.bytes -= ring[0].size
+	r.count--
+	r.evicted++
+	ring[0] = retainedEvent{}
+	ring = ring[1:]
+	if len(ring) == 0 {
+		delete(r.rings, key)
+	} else {
+		r.rings[key] = ring
+	}
+	return ring
+}
+
+// scaledCapacity returns the per-ring capacity after scaling (at least 1).
+func (r *Retention) scaledCapacity() int {
+	n := int(float64(r.capacity) * r.scale)
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// scaledMaxBytes returns the byte limit after scaling (0 = unbounded).
+func (r *Retention) scaledMaxBytes() int64 {
+	if r.maxBytes <= 0 {
+		return 0
+	}
+	n := int64(float64(r.maxBytes) * r.scale)
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// SetScale limits retention to a fraction (0-1] of its configured capacity and
+// byte budget, evicting immediately if the store is over the new limit.
+// Each ring keeps at least its latest event unless the byte budget forbids it.
+func (r *Retention) SetScale(scale float64) {
+	if scale <= 0 || scale > 1 {
+		scale = 1.0
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.scale = scale
+	r.enforce()
+}
+
+// Scale returns the fraction of capacity currently in effect.
+func (r *Retention) Scale() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.scale
+}
+
+// SetMaxBytes replaces the byte budget (0 = unbounded).
+func (r *Retention) SetMaxBytes(n int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.maxBytes = n
+	r.enforce()
+}
+
+// MaxBytes returns the configured (unscaled) byte budget.
+func (r *Retention) MaxBytes() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.maxBytes
+}
+
+// Len returns the number of retained events.
+func (r *Retention) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
+
+// Bytes returns the approximate memory held by retained events.
+func (r *Retention) Bytes() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.bytes
+}
+
+// Evicted returns the number of events evicted since creation.
+func (r *Retention) Evicted() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.evicted
+}
+
+// Latest returns the newest retained event of every type accepted by match,
+// oldest first.
+func (r *Retention) Latest(match func(*Event) bool) []*Event {
+	entries := r.snapshot(match)
+	seen := make(map[string]bool)
+	var latest []retainedEvent
+	for i := len(entries) - 1; i >= 0; i-- {
+		if t := entries[i].evt.Type; !seen[t] {
+			seen[t] = true
+			latest = append(latest, entries[i])
+		}
+	}
+	return reverseEvents(latest)
+}
+
+// Last returns up to n of the newest retained events accepted by match,
+// oldest first. Returns nil if n is not positive.
+func (r *Retention) Last(n int, match func(*Event) bool) []*Event {
+	if n <= 0 {
+		return nil
+	}
+	entries := r.snapshot(match)
+	if n < len(entries) {
+		entries = entries[len(entries)-n:]
+	}
+	return events(entries)
+}
+
+// Since returns retained events accepted by match whose Timestamp is at or
+// after t, oldest first.
+func (r *Retention) Since(t time.Time, match func(*Event) bool) []*Event {
+	// Timestamps are set by publishers and need not follow publish order,
+	// so every entry is checked
+	var out []*Event
+	for _, e := range r.snapshot(match) {
+		if !e.evt.Timestamp.Before(t) {
+			out = append(out, e.evt)
+		}
+	}
+	return out
+}
+
+// snapshot returns all retained events accepted by match (nil = all), in
+// publish order. Expired events are skipped.
+func (r *Retention) snapshot(match func(*Event) bool) []retainedEvent {
+	r.mu.Lock()
+	entries := make([]retainedEvent, 0, r.count)
+	for _, ring := range r.rings {
+		entries = append(entries, ring...)
+	}
+	clock := r.now
+	r.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].seq < entries[j].seq })
+
+	now := time.Now()
+	if clock != nil {
+		now = clock()
+	}
+	out := entries[:0]
+	for _, e := range entries {
+		if e.evt.Expired(now) {
+			continue
+		}
+		if match == nil || match(e.evt) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// events extracts the events from retention entries.
+func events(entries []retainedEvent) []*Event {
+	out := make([]*Event, len(entries))
+	for i, e := range entries {
+		out[i] = e.evt
+	}
+	return out
+}
+
+// reverseEvents extracts the events from entries in reverse order.
+func reverseEvents(entries []retainedEvent) []*Event {
+	out := make([]*Event, len(entries))
+	for i, e := range entries {
+		out[len(entries)-1-i] = e.evt
+	}
+	return out
+}
+
+// retainedEventOverhead approximates the fixed cost of an Event and its
+// retention entry (struct, headers and timestamps).
+const retainedEventOverhead = 256
+
+// retainedSize approximates the memory held by a retained event.
+func retainedSize(evt *Event) int64 {
+	size := retainedEventOverhead + len(evt.ID) + len(evt.Type) + len(evt.Source) +
+		len(evt.Data) + len(evt.CorrelationID) + len(evt.CausationID)
+	for k, v := range evt.Metadata {
+		size += len(k) + len(v) + 32 // Map entry overhead
+	}
+	return int64(size)
+}
+
+// retainedSelector picks the retained events replayed to a new subscription.
+type retainedSelector func(r *Retention, match func(*Event) bool) []*Event
+
+// WithRetainedLatest replays the newest retained event of every matching type
+// before live events (last-value semantics). Ignored on buses without retention.
+func WithRetainedLatest() SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.retained = func(r *Retention, match func(*Event) bool) []*Event {
+			return r.Latest(match)
+		}
+	}
+}
+
+// WithRetainedLast replays up to n of the newest matching retained events
+// before live events. Ignored on buses without retention or if n <= 0.
+func WithRetainedLast(n int) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.retained = func(r *Retention, match func(*Event) bool) []*Event {
+			return r.Last(n, match)
+		}
+	}
+}
+
+// WithRetainedSince replays matching retained events with a Timestamp at or
+// after t before live events. Ignored on buses without retention.
+func WithRetainedSince(t time.Time) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.retained = func(r *Retention, match func(*Event) bool) []*Event {
+			return r.Since(t, match)
+		}
+	}
+}
+
+// replayRetained queues the retained events selected by cfg on a new
+// subscription that publishers cannot send to yet and returns them. At most
+// one buffer's worth is replayed (the newest), so this never blocks.
+func (s *inMemorySubscription) replayRetained(r *Retention, cfg subscribeConfig) []*Event {
+	if r == nil || cfg.retained == nil {
+		return nil
+	}
+
+	evts := cfg.retained(r, s.matches)
+	if len(evts) > s.bufferSize {
+		evts = evts[len(evts)-s.bufferSize:]
+	}
+	for _, evt := range evts {
+		s.ch <- evt
+		s.recordDelivery()
+	}
+	return evts
+}
@@ -0,0 +1,246 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// eventIDs collects event IDs in order.
+func eventIDs(evts []*Event) []string {
+	ids := make([]string, len(evts))
+	for i, evt := range evts {
+		ids[i] = evt.ID
+	}
+	return ids
+}
+
+// drainIDs reads everything currently buffered on a subscription.
+func drainIDs(sub Subscription) []string {
+	var ids []string
+	for {
+		select {
+		case evt := <-sub.Events():
+			ids = append(ids, evt.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestRetention_PerTypeAndGlobal(t *testing.T) {
+	perType := NewRetention(WithRetentionPerType(2))
+	global := NewRetention(WithRetentionGlobal(2))
+	for i := 0; i < 5; i++ {
+		evt := &Event{ID: fmt.Sprintf("a-%d", i), Type: "app.a"}
+		perType.Add(evt)
+		global.Add(evt)
+	}
+	quiet := &Event{ID: "b-0", Type: "app.b"}
+	perType.Add(quiet)
+	global.Add(quiet)
+
+	// A chatty type cannot push out the last value of a quiet one
+	if got := fmt.Sprint(eventIDs(perType.Last(10, nil))); got != "[a-3 a-4 b-0]" {
+		t.Errorf("Per-type retention: got %s", got)
+	}
+	if got := fmt.Sprint(eventIDs(global.Last(10, nil))); got != "[a-4 b-0]" {
+		t.Errorf("Global retention: got %s", got)
+	}
+	if got := fmt.Sprint(eventIDs(perType.Latest(nil))); got != "[a-4 b-0]" {
+		t.Errorf("Latest: got %s", got)
+	}
+	if perType.Evicted() != 3 || perType.Len() != 3 {
+		t.Errorf("Expected 3 retained and 3 evicted, got %d and %d", perType.Len(), perType.Evicted())
+	}
+	if got := perType.Last(-1, nil); got != nil {
+		t.Errorf("Expected nothing for a negative count, got %s", eventIDs(got))
+	}
+}
+
+func TestRetention_Since(t *testing.T) {
+	r := NewRetention(WithRetentionGlobal(10))
+	base := time.Now()
+	for i := 0; i < 4; i++ {
+		r.Add(&Event{ID: fmt.Sprintf("evt-%d", i), Type: "test", Timestamp: base.Add(time.Duration(i) * time.Second)})
+	}
+	r.Add(&Event{ID: "stale", Type: "test", Timestamp: base, ExpiresAt: base.Add(-time.Second)})
+
+	if got := fmt.Sprint(eventIDs(r.Since(base.Add(2*time.Second), nil))); got != "[evt-2 evt-3]" {
+		t.Errorf("Since: got %s", got)
+	}
+	if n := len(r.Last(10, nil)); n != 4 {
+		t.Errorf("Expired events should not be replayed, got %d events", n)
+	}
+}
+
+func TestRetention_ByteBudgetAndScale(t *testing.T) {
+	size := retainedSize(&Event{ID: "evt-0", Type: "test"})
+	r := NewRetention(WithRetentionGlobal(100), WithRetentionMaxBytes(10*size))
+	for i := 0; i < 20; i++ {
+		r.Add(&Event{ID: fmt.Sprintf("evt-%d", i%10), Type: "test"})
+	}
+	if r.Len() != 10 || r.Bytes() > 10*size {
+		t.Errorf("Expected byte budget to hold 10 events, got %d (%d bytes)", r.Len(), r.Bytes())
+	}
+
+	r.SetScale(0.5)
+	if r.Len() != 5 {
+		t.Errorf("Expected 5 events at scale 0.5, got %d", r.Len())
+	}
+	if got := fmt.Sprint(eventIDs(r.Last(1, nil))); got != "[evt-9]" {
+		t.Errorf("Shrinking should keep the newest events, got %s", got)
+	}
+
+	r.SetScale(1.0)
+	if r.Scale() != 1.0 || r.Len() != 5 {
+		t.Errorf("Restoring scale should not resurrect evicted events, got %d", r.Len())
+	}
+}
+
+func TestBus_SubscribeRetained(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		bus  interface {
+			Bus
+			OptionSubscriber
+		}
+	}{
+		{"in-memory", NewInMemoryBus(WithMetrics(nil), WithRetention(NewRetention(WithRetentionPerType(3))))},
+		{"sharded", NewShardedBus(2, WithMetrics(nil), WithRetention(NewRetention(WithRetentionPerType(3))))},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.bus.Close()
+			ctx := context.Background()
+			start := time.Now()
+			for i := 0; i < 4; i++ {
+				tc.bus.Publish(ctx, &Event{ID: fmt.Sprintf("temp-%d", i), Type: "sensor.temp", Timestamp: start.Add(time.Duration(i) * time.Second)})
+			}
+			tc.bus.Publish(ctx, &Event{ID: "door-0", Type: "sensor.door", Timestamp: start.Add(5 * time.Second)})
+			tc.bus.Publish(ctx, &Event{ID: "log-0", Type: "log.line", Timestamp: start.Add(6 * time.Second)})
+
+			filter := Filter{Types: []string{"sensor.*"}}
+			latest, _ := tc.bus.SubscribeWithOptions(ctx, filter, WithRetainedLatest())
+			last, _ := tc.bus.SubscribeWithOptions(ctx, filter, WithRetainedLast(2))
+			since, _ := tc.bus.SubscribeWithOptions(ctx, filter, WithRetainedSince(start.Add(2*time.Second)))
+			negative, _ := tc.bus.SubscribeWithOptions(ctx, filter, WithRetainedLast(-1))
+			plain, _ := tc.bus.Subscribe(ctx, filter)
+
+			tc.bus.Publish(ctx, &Event{ID: "temp-live", Type: "sensor.temp"})
+
+			for _, c := range []struct {
+				name string
+				sub  Subscription
+				want string
+			}{
+				{"latest", latest, "[temp-3 door-0 temp-live]"},
+				{"last", last, "[temp-3 door-0 temp-live]"},
+				{"since", since, "[temp-2 temp-3 door-0 temp-live]"},
+				{"negative", negative, "[temp-live]"},
+				{"plain", plain, "[temp-live]"},
+			} {
+				if got := fmt.Sprint(drainIDs(c.sub)); got != c.want {
+					t.Errorf("%s: expected %s, got %s", c.name, c.want, got)
+				}
+			}
+
+			if stats := tc.bus.(interface{ Stats() BusStats }).Stats(); stats.Retained != 5 || stats.RetainedBytes == 0 {
+				t.Errorf("Expected 5 retained events in stats, got %d (%d bytes)", stats.Retained, stats.RetainedBytes)
+			}
+		})
+	}
+}
+
+func TestBus_SubscribeRetainedCappedToBuffer(t *testing.T) {
+	bus := NewInMemoryBus(WithMetrics(nil), WithBufferSize(4), WithRetention(NewRetention(WithRetentionGlobal(100))))
+	defer bus.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		bus.Publish(ctx, &Event{ID: fmt.Sprintf("evt-%d", i), Type: "test"})
+	}
+
+	sub, err := bus.SubscribeWithOptions(ctx, Filter{}, WithRetainedLast(10))
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	if got := fmt.Sprint(drainIDs(sub)); got != "[evt-6 evt-7 evt-8 evt-9]" {
+		t.Errorf("Expected the newest buffer's worth of events, got %s", got)
+	}
+}
+
+func TestBus_RetainedExpiryClock(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bus := NewInMemoryBus(
+		WithRetention(NewRetention(WithRetentionPerType(4))),
+		WithExpiryClock(func() time.Time { return now }),
+	)
+	defer bus.Close()
+	ctx := context.Background()
+
+	// Stale by wall time, fresh on the bus clock: replayed like live delivery
+	bus.Publish(ctx, &Event{ID: "evt-1", Type: "sensor.temp", TTL: time.Second})
+	fresh, _ := bus.SubscribeWithOptions(ctx, Filter{}, WithRetainedLatest())
+	if got := fmt.Sprint(drainIDs(fresh)); got != "[evt-1]" {
+		t.Errorf("Expected the event replayed on the bus clock, got %s", got)
+	}
+
+	now = now.Add(2 * time.Second)
+	stale, _ := bus.SubscribeWithOptions(ctx, Filter{}, WithRetainedLatest())
+	if got := drainIDs(stale); len(got) != 0 {
+		t.Errorf("Expected the expired event skipped on the bus clock, got %v", got)
+	}
+}
+
+func TestShardedBus_SubscribeRetainedWhilePublishing(t *testing.T) {
+	const total, subscribers = 500, 50
+	bus := NewShardedBus(4, WithMetrics(nil), WithBufferSize(total), WithRetention(NewRetention(WithRetentionGlobal(total))))
+	defer bus.Close()
+	ctx := context.Background()
+
+	// Subscribers join at staggered points while events are being published
+	var published atomic.Int64
+	subs := make([]Subscription, subscribers)
+	var wg sync.WaitGroup
+	for n := range subs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for published.Load() < int64(n*total/subscribers) {
+				runtime.Gosched()
+			}
+			sub, err := bus.SubscribeWithOptions(ctx, Filter{}, WithRetainedLast(total))
+			if err != nil {
+				t.Errorf("Subscribe failed: %v", err)
+				return
+			}
+			subs[n] = sub
+		}()
+	}
+	for i := 0; i < total; i++ {
+		bus.Publish(ctx, &Event{ID: fmt.Sprintf("evt-%d", i), Type: "test"})
+		published.Add(1)
+	}
+	wg.Wait()
+
+	// Each replays what was retained when it joined and gets the rest live:
+	// every event exactly once, in publish order
+	for n, sub := range subs {
+		if sub == nil {
+			continue
+		}
+		ids := drainIDs(sub)
+		if len(ids) != total {
+			t.Fatalf("subscriber %d: expected %d events, got %d", n, total, len(ids))
+		}
+		for i, id := range ids {
+			if want := fmt.Sprintf("evt-%d", i); id != want {
+				t.Fatalf("subscriber %d: expected %s at %d, got %s", n, want, i, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package event
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// rotatingFile is a buffered append-only file that rotates by size, keeping
+// at most maxFiles old segments:
+//
+//	out.jsonl     (current)
+//	out.jsonl.1   (previous)
+//	out.jsonl.2   (older) ...
+//
+// It is not safe for concurrent use; owners serialize access.
+type rotatingFile struct {
+	prefix   string // Error message prefix (e.g., "recorder")
+	path     string
+	maxBytes int64 // Rotate when the current file reaches this size (0 = never)
+	maxFiles int   // Rotated segments to keep, excluding the current file
+
+	file *os.File
+	w    *bufio.Writer
+	size int64
+}
+
+// openRotatingFile opens (or creates) path for appending.
+func openRotatingFile(prefix, path string, maxBytes int64, maxFiles int) (*rotatingFile, error) {
+	f := &rotatingFile{prefix: prefix, path: path, maxBytes: maxBytes, maxFiles: maxFiles}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// open opens (or creates) the current segment for appending.
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("%s: open %s: %w", f.prefix, f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("%s: stat %s: %w", f.prefix, f.path, err)
+	}
+
+	f.file = file
+	f.w = bufio.NewWriter(file)
+	f.size = info.Size()
+	return nil
+}
+
+// Write appends line, rotating first if it would exceed maxBytes.
+func (f *rotatingFile) Write(line []byte) error {
+	if f.file == nil {
+		return fmt.Errorf("%s: closed", f.prefix)
+	}
+
+	if f.maxBytes > 0 && f.size > 0 && f.size+int64(len(line)) > f.maxBytes {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := f.w.Write(line); err != nil {
+		return fmt.Errorf("%s: write: %w", f.prefix, err)
+	}
+	f.size += int64(len(line))
+	return nil
+}
+
+// rotate shifts path.N-1 → path.N, ..., path → path.1 and opens a fresh file.
+func (f *rotatingFile) rotate() error {
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if f.maxFiles <= 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("%s: rotate: %w", f.prefix, err)
+		}
+		return f.open()
+	}
+
+	os.Remove(segmentPath(f.path, f.maxFiles))
+	for i := f.maxFiles - 1; i >= 1; i-- {
+		os.Rename(segmentPath(f.path, i), segmentPath(f.path, i+1))
+	}
+	if err := os.Rename(f.path, segmentPath(f.path, 1)); err != nil {
+		return fmt.Errorf("%s: rotate: %w", f.prefix, err)
+	}
+	return f.open()
+}
+
+// Flush writes buffered lines to disk.
+func (f *rotatingFile) Flush() error {
+	if f.w == nil {
+		return nil
+	}
+	return f.w.Flush()
+}
+
+// Close flushes and closes the current segment. Safe to call multiple times.
+func (f *rotatingFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	flushErr := f.w.Flush()
+	closeErr := f.file.Close()
+	f.file = nil
+	f.w = nil
+	if flushErr != nil {
+		return fmt.Errorf("%s: flush: %w", f.prefix, flushErr)
+	}
+	return closeErr
+}
+
+// segmentPath returns the path of the n-th rotated segment.
+func segmentPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
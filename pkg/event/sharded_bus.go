@@ -0,0 +1,270 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/BYTE-6D65/pipeline/pkg/trace"
+)
+
+// ShardedBus is a Bus for high publish concurrency.
+//
+// InMemoryBus guards its subscription map with a single RWMutex, so every
+// Subscribe or Close takes the write lock and stalls all publishers. ShardedBus
+// spreads subscriptions across N shards, each holding a copy-on-write slice
+// (the same scheme ErrorBus uses):
+//   - Publish is lock-free: it loads each shard's snapshot atomically
+//   - Subscribe/Close lock only one shard and swap in a new slice
+//   - Publishers never wait on subscription churn
+//
+// Delivery semantics (buffering, drop-slow, dedup, TTL, tracing, metrics) are
+// identical to InMemoryBus and are configured with the same BusOptions.
+type ShardedBus struct {
+	base   *InMemoryBus // Supplies configuration and the delivery pipeline
+	shards []busShard
+	closed atomic.Bool
+	count  atomic.Int64 // Active subscriptions across all shards
+}
+
+// busShard holds one copy-on-write subscription list.
+type busShard struct {
+	mu   sync.Mutex // Serializes updates to subs
+	subs atomic.Pointer[[]*inMemorySubscription]
+}
+
+// NewShardedBus creates a sharded bus. shards <= 0 uses GOMAXPROCS.
+func NewShardedBus(shards int, opts ...BusOption) *ShardedBus {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+
+	b := &ShardedBus{
+		base:   NewInMemoryBus(opts...),
+		shards: make([]busShard, shards),
+	}
+	for i := range b.shards {
+		empty := []*inMemorySubscription{}
+		b.shards[i].subs.Store(&empty)
+	}
+	return b
+}
+
+// Publish sends an event to all matching subscribers without taking any lock.
+// Like InMemoryBus.Publish, it delivers an enriched copy and leaves evt as is.
+func (b *ShardedBus) Publish(ctx context.Context, evt *Event) error {
+	if b.closed.Load() {
+		return fmt.Errorf("bus is closed")
+	}
+	return b.base.publish(ctx, evt, b.snapshot())
+}
+
+// snapshot iterates the current subscription list of every shard.
+// A subscription closed mid-publish is skipped by send.
+func (b *ShardedBus) snapshot() iter.Seq[*inMemorySubscription] {
+	return func(yield func(*inMemorySubscription) bool) {
+		for i := range b.shards {
+			for _, sub := range *b.shards[i].subs.Load() {
+				if !yield(sub) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Subscribe creates a new subscription with the given filter.
+func (b *ShardedBus) Subscribe(ctx context.Context, filter Filter) (Subscription, error) {
+	return b.SubscribeWithOptions(ctx, filter)
+}
+
+// SubscribeWithOptions creates a new subscription with the given filter and options.
+// Subscriptions are assigned to shards round-robin.
+func (b *ShardedBus) SubscribeWithOptions(ctx context.Context, filter Filter, opts ...SubscribeOption) (Subscription, error) {
+	cfg := subscribeConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	seq := b.base.nextSubID.Add(1) - 1
+	idx := int(seq % uint64(len(b.shards)))
+	size := b.base.BufferSize()
+	sub := &inMemorySubscription{
+		id:         fmt.Sprintf("sub-%d", seq),
+		name:       cfg.name,
+		bus:        b.base,
+		sharded:    b,
+		shard:      idx,
+		filter:     filter,
+		ch:         make(chan *Event, size),
+		bufferSize: size,
+	}
+
+	// Publish does not lock, so the subscription is inserted before replay
+	// and holds its own lock until replay is done: live sends queue behind
+	// the replayed events. Publish retains before fan-out, so every event is
+	// either replayed or delivered live; one that is both is delivered once.
+	sub.mu.Lock()
+	shard := &b.shards[idx]
+	shard.mu.Lock()
+	// Checked under the shard lock so Close cannot miss this subscription
+	if b.closed.Load() {
+		shard.mu.Unlock()
+		sub.mu.Unlock()
+		return nil, fmt.Errorf("bus is closed")
+	}
+	old := *shard.subs.Load()
+	next := make([]*inMemorySubscription, len(old), len(old)+1)
+	copy(next, old)
+	next = append(next, sub)
+	shard.subs.Store(&next)
+	shard.mu.Unlock()
+
+	if replayed := sub.replayRetained(b.base.retention, cfg); len(replayed) > 0 {
+		sub.replayed = make(map[*Event]struct{}, len(replayed))
+		for _, evt := range replayed {
+			sub.replayed[evt] = struct{}{}
+		}
+	}
+	sub.mu.Unlock()
+
+	total := b.count.Add(1)
+	if m := b.base.metrics; m != nil {
+		m.SubscribersTotal.WithLabelValues(b.base.name).Set(float64(total))
+		m.BufferSize.WithLabelValues(b.base.name, sub.name).Set(float64(size))
+		m.BufferUsage.WithLabelValues(b.base.name, sub.name).Set(0)
+	}
+
+	return sub, nil
+}
+
+// unsubscribe removes a subscription from its shard and closes its channel.
+func (b *ShardedBus) unsubscribe(sub *inMemorySubscription) {
+	shard := &b.shards[sub.shard]
+	shard.mu.Lock()
+	old := *shard.subs.Load()
+	removed := false
+	next := make([]*inMemorySubscription, 0, len(old))
+	for _, s := range old {
+		if s == sub {
+			removed = true
+			continue
+		}
+		next = append(next, s)
+	}
+	if removed {
+		shard.subs.Store(&next)
+	}
+	shard.mu.Unlock()
+
+	sub.closeChannel()
+
+	if removed {
+		total := b.count.Add(-1)
+		if m := b.base.metrics; m != nil {
+			m.SubscribersTotal.WithLabelValues(b.base.name).Set(float64(total))
+			if !nameInUse(b.snapshot(), sub.name) {
+				m.DeleteSubscription(b.base.name, sub.name)
+			}
+		}
+	}
+}
+
+// Close shuts down the bus and all subscriptions.
+func (b *ShardedBus) Close() error {
+	if !b.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	empty := []*inMemorySubscription{}
+	for i := range b.shards {
+		shard := &b.shards[i]
+		shard.mu.Lock()
+		subs := *shard.subs.Load()
+		shard.subs.Store(&empty)
+		shard.mu.Unlock()
+
+		for _, sub := range subs {
+			sub.closeChannel()
+			if m := b.base.metrics; m != nil {
+				m.DeleteSubscription(b.base.name, sub.name)
+			}
+		}
+	}
+	b.count.Store(0)
+	return nil
+}
+
+// Shards returns the number of shards.
+func (b *ShardedBus) Shards() int {
+	return len(b.shards)
+}
+
+// Name returns the bus name used in metrics labels.
+func (b *ShardedBus) Name() string {
+	return b.base.name
+}
+
+// Retention returns the bus history store, or nil if retention is disabled.
+func (b *ShardedBus) Retention() *Retention {
+	return b.base.retention
+}
+
+// SetTracer replaces the tracer on a running bus (nil disables tracing).
+func (b *ShardedBus) SetTracer(tracer *trace.Tracer) {
+	b.base.SetTracer(tracer)
+}
+
+// SetTTLPolicy replaces the TTL policy on a running bus.
+func (b *ShardedBus) SetTTLPolicy(policy *TTLPolicy) {
+	b.base.SetTTLPolicy(policy)
+}
+
+// SetErrorBus replaces the error bus on a running bus.
+func (b *ShardedBus) SetErrorBus(errorBus *ErrorBus) {
+	b.base.SetErrorBus(errorBus)
+}
+
+// SetExpiryClock replaces the expiry time source on a running bus.
+func (b *ShardedBus) SetExpiryClock(now func() time.Time) {
+	b.base.SetExpiryClock(now)
+}
+
+// SetBufferSize changes the channel buffer size of subscriptions created
+// from now on. Existing subscriptions keep their buffers.
+func (b *ShardedBus) SetBufferSize(size int) {
+	b.base.SetBufferSize(size)
+}
+
+// BufferSize returns the buffer size given to new subscriptions.
+func (b *ShardedBus) BufferSize() int {
+	return b.base.BufferSize()
+}
+
+// Stats returns a snapshot of the bus and all active subscriptions.
+func (b *ShardedBus) Stats() BusStats {
+	stats := BusStats{
+		Name:      b.base.name,
+		Closed:    b.closed.Load(),
+		Published: b.base.published.Load(),
+	}
+	if r := b.base.retention; r != nil {
+		stats.Retained = r.Len()
+		stats.RetainedBytes = r.Bytes()
+	}
+	stats.Subscriptions = make([]SubscriptionStats, 0, b.count.Load())
+	for sub := range b.snapshot() {
+		stats.Subscriptions = append(stats.Subscriptions, sub.stats())
+	}
+	sortSubscriptionStats(stats.Subscriptions)
+	return stats
+}
+
+// Subscriptions returns a snapshot of all active subscriptions, sorted by ID.
+func (b *ShardedBus) Subscriptions() []SubscriptionStats {
+	return b.Stats().Subscriptions
+}
@@ -0,0 +1,197 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShardedBus_PublishSubscribe(t *testing.T) {
+	bus := NewShardedBus(4, WithMetrics(nil))
+	defer bus.Close()
+
+	ctx := context.Background()
+	var subs []Subscription
+	for i := 0; i < 8; i++ {
+		sub, err := bus.Subscribe(ctx, Filter{Types: []string{"app.*"}})
+		if err != nil {
+			t.Fatalf("Subscribe failed: %v", err)
+		}
+		subs = append(subs, sub)
+	}
+	other, _ := bus.Subscribe(ctx, Filter{Types: []string{"input.*"}})
+
+	if err := bus.Publish(ctx, &Event{ID: "evt-1", Type: "app.created"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	for i, sub := range subs {
+		select {
+		case evt := <-sub.Events():
+			if evt.ID != "evt-1" {
+				t.Errorf("Subscriber %d: expected evt-1, got %s", i, evt.ID)
+			}
+		default:
+			t.Errorf("Subscriber %d did not receive the event", i)
+		}
+	}
+	if len(other.Events()) != 0 {
+		t.Error("Non-matching subscriber should not receive the event")
+	}
+
+	stats := bus.Stats()
+	if len(stats.Subscriptions) != 9 {
+		t.Errorf("Expected 9 subscriptions, got %d", len(stats.Subscriptions))
+	}
+	if stats.Published != 1 {
+		t.Errorf("Expected 1 published, got %d", stats.Published)
+	}
+}
+
+func TestShardedBus_Unsubscribe(t *testing.T) {
+	bus := NewShardedBus(2, WithMetrics(nil))
+	defer bus.Close()
+
+	ctx := context.Background()
+	sub, _ := bus.Subscribe(ctx, Filter{})
+	keep, _ := bus.Subscribe(ctx, Filter{})
+
+	sub.Close()
+	sub.Close() // Idempotent
+
+	if _, ok := <-sub.Events(); ok {
+		t.Error("Closed subscription channel should be closed")
+	}
+
+	bus.Publish(ctx, &Event{ID: "evt-1", Type: "test"})
+	if len(keep.Events()) != 1 {
+		t.Error("Remaining subscriber should receive the event")
+	}
+	if n := len(bus.Subscriptions()); n != 1 {
+		t.Errorf("Expected 1 subscription, got %d", n)
+	}
+}
+
+func TestShardedBus_Close(t *testing.T) {
+	bus := NewShardedBus(2, WithMetrics(nil))
+	ctx := context.Background()
+	sub, _ := bus.Subscribe(ctx, Filter{})
+
+	bus.Close()
+	bus.Close() // Idempotent
+
+	if _, ok := <-sub.Events(); ok {
+		t.Error("Subscription channel should be closed")
+	}
+	if err := bus.Publish(ctx, &Event{ID: "evt-1"}); err == nil {
+		t.Error("Publish on closed bus should fail")
+	}
+	if _, err := bus.Subscribe(ctx, Filter{}); err == nil {
+		t.Error("Subscribe on closed bus should fail")
+	}
+	if !bus.Stats().Closed {
+		t.Error("Stats should report closed")
+	}
+}
+
+// Publishers must not be stalled or crash while subscriptions churn.
+func TestShardedBus_ConcurrentChurn(t *testing.T) {
+	bus := NewShardedBus(4, WithMetrics(nil), WithDropSlow(true))
+	defer bus.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var published atomic.Int64
+	for p := 0; p < 4; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; ctx.Err() == nil; i++ {
+				if bus.Publish(context.Background(), &Event{ID: fmt.Sprintf("%d-%d", p, i), Type: "test"}) == nil {
+					published.Add(1)
+				}
+			}
+		}(p)
+	}
+	for c := 0; c < 4; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				sub, err := bus.Subscribe(context.Background(), Filter{})
+				if err != nil {
+					return
+				}
+				sub.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if published.Load() == 0 {
+		t.Error("Expected publishes to make progress during churn")
+	}
+	if n := len(bus.Subscriptions()); n != 0 {
+		t.Errorf("Expected all churned subscriptions removed, got %d", n)
+	}
+}
+
+// benchmarkBusChurn publishes from RunParallel goroutines while one goroutine
+// repeatedly subscribes and unsubscribes. Run with -cpu=1,2,4,8 to compare
+// scaling across GOMAXPROCS.
+func benchmarkBusChurn(b *testing.B, bus Bus) {
+	ctx := context.Background()
+	for i := 0; i < 16; i++ {
+		sub, _ := bus.Subscribe(ctx, Filter{Types: []string{"bench.*"}})
+		go func() {
+			for range sub.Events() {
+			}
+		}()
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			sub, err := bus.Subscribe(ctx, Filter{Types: []string{"other.*"}})
+			if err != nil {
+				return
+			}
+			sub.Close()
+			runtime.Gosched() // Let publishers run at GOMAXPROCS=1
+		}
+	}()
+
+	evt := &Event{ID: "bench", Type: "bench.event"}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			bus.Publish(ctx, evt)
+		}
+	})
+	b.StopTimer()
+
+	close(stop)
+	<-done
+	bus.Close()
+}
+
+func BenchmarkInMemoryBus_PublishWithChurn(b *testing.B) {
+	benchmarkBusChurn(b, NewInMemoryBus(WithMetrics(nil), WithDropSlow(true)))
+}
+
+func BenchmarkShardedBus_PublishWithChurn(b *testing.B) {
+	benchmarkBusChurn(b, NewShardedBus(0, WithMetrics(nil), WithDropSlow(true)))
+}
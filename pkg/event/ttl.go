@@ -0,0 +1,110 @@
+package event
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// TTLPolicy assigns default lifetimes to events by type.
+//
+// Patterns use the same wildcard syntax as Filter.Types ("input.*").
+// An exact type match wins over a wildcard; otherwise the first matching
+// pattern in registration order applies. Events that already carry a TTL or
+// deadline are left untouched.
+//
+// Example:
+//
+//	policy := event.NewTTLPolicy().
+//	    Set("input.*", 500*time.Millisecond).
+//	    Set("metrics.sample", 5*time.Second)
+type TTLPolicy struct {
+	mu    sync.RWMutex
+	rules []ttlRule
+}
+
+// ttlRule maps a type pattern to a default TTL.
+type ttlRule struct {
+	pattern string
+	ttl     time.Duration
+}
+
+// NewTTLPolicy creates an empty TTL policy.
+func NewTTLPolicy() *TTLPolicy {
+	return &TTLPolicy{}
+}
+
+// Set registers (or replaces) the default TTL for a type pattern.
+// A non-positive ttl removes the pattern.
+func (p *TTLPolicy) Set(pattern string, ttl time.Duration) *TTLPolicy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, r := range p.rules {
+		if r.pattern == pattern {
+			if ttl <= 0 {
+				p.rules = append(p.rules[:i], p.rules[i+1:]...)
+			} else {
+				p.rules[i].ttl = ttl
+			}
+			return p
+		}
+	}
+
+	if ttl > 0 {
+		p.rules = append(p.rules, ttlRule{pattern: pattern, ttl: ttl})
+	}
+	return p
+}
+
+// TTLFor returns the default TTL for an event type.
+func (p *TTLPolicy) TTLFor(eventType string) (time.Duration, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, r := range p.rules {
+		if r.pattern == eventType {
+			return r.ttl, true
+		}
+	}
+	for _, r := range p.rules {
+		if matched, err := filepath.Match(r.pattern, eventType); err == nil && matched {
+			return r.ttl, true
+		}
+	}
+	return 0, false
+}
+
+// Apply sets the type default TTL on evt if it has no TTL or deadline.
+func (p *TTLPolicy) Apply(evt *Event) {
+	if evt.TTL > 0 || !evt.ExpiresAt.IsZero() {
+		return
+	}
+	if ttl, ok := p.TTLFor(evt.Type); ok {
+		evt.TTL = ttl
+	}
+}
+
+// Len returns the number of registered patterns.
+func (p *TTLPolicy) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.rules)
+}
+
+// NewExpiredEvent builds the error event reported when a stale event is skipped.
+// stage identifies where it was caught ("publish", "deliver", "emit").
+func NewExpiredEvent(component, stage string, evt *Event, now time.Time) ErrorEvent {
+	deadline, _ := evt.Deadline()
+	return NewErrorEvent(
+		InfoSeverity,
+		CodeEventExpired,
+		component,
+		fmt.Sprintf("Expired event skipped at %s (type=%s)", stage, evt.Type),
+	).WithSignal(SignalShed).
+		WithContext("event_id", evt.ID).
+		WithContext("event_type", evt.Type).
+		WithContext("stage", stage).
+		WithContext("stale_for", now.Sub(deadline).String())
+}
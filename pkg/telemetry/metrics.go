@@ -12,6 +12,8 @@ type Metrics struct {
 	// Event Bus Metrics
 	EventsPublished    *prometheus.CounterVec
 	EventsDropped      *prometheus.CounterVec
+	EventsDeduplicated *prometheus.CounterVec
+	EventsExpired      *prometheus.CounterVec
 	PublishDuration    *prometheus.HistogramVec
 	FilterDuration     *prometheus.HistogramVec
 	SendDuration       *prometheus.HistogramVec
@@ -23,6 +25,12 @@ type Metrics struct {
 	// Engine Metrics
 	EngineOperations *prometheus.CounterVec
 	EngineDuration   *prometheus.HistogramVec
+	ScheduledPending *prometheus.GaugeVec
+
+	// Rate Limiting Metrics
+	PublishThrottled *prometheus.CounterVec
+	ThrottleWait     *prometheus.HistogramVec
+	ThrottleRate     *prometheus.GaugeVec
 }
 
 var (
@@ -72,7 +80,23 @@ func InitMetrics(registry prometheus.Registerer) *Metrics {
 				Name: "pipeline_events_dropped_total",
 				Help: "Total number of events dropped due to slow subscribers",
 			},
-			[]string{"bus", "event_type", "subscription_id"},
+			[]string{"bus", "event_type", "subscription"},
+		),
+
+		EventsDeduplicated: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "pipeline_events_deduplicated_total",
+				Help: "Total number of duplicate events suppressed by deduplication",
+			},
+			[]string{"bus", "event_type"},
+		),
+
+		EventsExpired: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "pipeline_events_expired_total",
+				Help: "Total number of events skipped because their TTL or deadline elapsed",
+			},
+			[]string{"bus", "event_type", "stage"},
 		),
 
 		PublishDuration: promauto.With(registry).NewHistogramVec(
@@ -90,7 +114,7 @@ func InitMetrics(registry prometheus.Registerer) *Metrics {
 				Help:    "Time taken to filter events against subscription criteria",
 				Buckets: latencyBuckets,
 			},
-			[]string{"bus", "subscription_id"},
+			[]string{"bus", "subscription"},
 		),
 
 		SendDuration: promauto.With(registry).NewHistogramVec(
@@ -99,7 +123,7 @@ func InitMetrics(registry prometheus.Registerer) *Metrics {
 				Help:    "Time taken to send event to subscription channel (includes blocking time)",
 				Buckets: latencyBuckets,
 			},
-			[]string{"bus", "subscription_id", "result"},
+			[]string{"bus", "subscription", "result"},
 		),
 
 		SendBlocked: promauto.With(registry).NewCounterVec(
@@ -107,7 +131,7 @@ func InitMetrics(registry prometheus.Registerer) *Metrics {
 				Name: "pipeline_event_send_blocked_total",
 				Help: "Number of times event send blocked waiting for channel space",
 			},
-			[]string{"bus", "subscription_id"},
+			[]string{"bus", "subscription"},
 		),
 
 		SubscribersTotal: promauto.With(registry).NewGaugeVec(
@@ -123,7 +147,7 @@ func InitMetrics(registry prometheus.Registerer) *Metrics {
 				Name: "pipeline_subscription_buffer_usage",
 				Help: "Current number of events in subscription buffer",
 			},
-			[]string{"bus", "subscription_id"},
+			[]string{"bus", "subscription"},
 		),
 
 		BufferSize: promauto.With(registry).NewGaugeVec(
@@ -131,7 +155,7 @@ func InitMetrics(registry prometheus.Registerer) *Metrics {
 				Name: "pipeline_subscription_buffer_size",
 				Help: "Maximum capacity of subscription buffer",
 			},
-			[]string{"bus", "subscription_id"},
+			[]string{"bus", "subscription"},
 		),
 
 		// Engine Metrics
@@ -151,6 +175,40 @@ func InitMetrics(registry prometheus.Registerer) *Metrics {
 			},
 			[]string{"operation"},
 		),
+
+		ScheduledPending: promauto.With(registry).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "pipeline_scheduled_events_pending",
+				Help: "Number of events waiting in the scheduler",
+			},
+			[]string{"scheduler"},
+		),
+
+		// Rate Limiting Metrics
+		PublishThrottled: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "pipeline_publish_throttled_total",
+				Help: "Publishes delayed or rejected by the per-source rate limiter",
+			},
+			[]string{"source", "result"},
+		),
+
+		ThrottleWait: promauto.With(registry).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "pipeline_publish_throttle_wait_seconds",
+				Help:    "Time publishers spent waiting for a rate limiter token",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"source"},
+		),
+
+		ThrottleRate: promauto.With(registry).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "pipeline_publish_rate_limit",
+				Help: "Current effective rate limit (events/sec) after governor scaling",
+			},
+			[]string{"source"},
+		),
 	}
 
 	defaultMetrics = m
@@ -166,6 +224,18 @@ func Default() *Metrics {
 	return defaultMetrics
 }
 
+// DeleteSubscription removes the per-subscription series for a subscription
+// name on a bus, so closed subscriptions stop being exported.
+func (m *Metrics) DeleteSubscription(bus, subscription string) {
+	labels := prometheus.Labels{"bus": bus, "subscription": subscription}
+	m.EventsDropped.DeletePartialMatch(labels)
+	m.FilterDuration.DeletePartialMatch(labels)
+	m.SendDuration.DeletePartialMatch(labels)
+	m.SendBlocked.DeletePartialMatch(labels)
+	m.BufferUsage.DeletePartialMatch(labels)
+	m.BufferSize.DeletePartialMatch(labels)
+}
+
 // Timer is a helper for timing operations.
 type Timer struct {
 	start time.Time
@@ -0,0 +1,144 @@
+package trace
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// W3C Trace Context (https://www.w3.org/TR/trace-context/) field names.
+// These are also the event Metadata keys used to carry the context.
+const (
+	TraceparentKey = "traceparent"
+	TracestateKey  = "tracestate"
+
+	// EnqueuedAtKey carries the Unix-nano time an event was handed to
+	// subscriber queues, so consumers can record the queue wait stage.
+	EnqueuedAtKey = "trace.enqueued_at"
+)
+
+// ErrInvalidTraceparent is returned when a traceparent header cannot be parsed.
+var ErrInvalidTraceparent = errors.New("trace: invalid traceparent")
+
+// TraceID is a 16-byte W3C trace identifier.
+type TraceID [16]byte
+
+// SpanID is an 8-byte W3C span identifier.
+type SpanID [8]byte
+
+// IsValid reports whether the ID is non-zero.
+func (t TraceID) IsValid() bool { return t != TraceID{} }
+
+// String returns the lowercase hex encoding.
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+
+// IsValid reports whether the ID is non-zero.
+func (s SpanID) IsValid() bool { return s != SpanID{} }
+
+// String returns the lowercase hex encoding.
+func (s SpanID) String() string { return hex.EncodeToString(s[:]) }
+
+// NewTraceID returns a random trace ID.
+func NewTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+// NewSpanID returns a random span ID.
+func NewSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+// FlagSampled is the W3C "sampled" trace flag.
+const FlagSampled byte = 0x01
+
+// SpanContext identifies a span within a trace.
+type SpanContext struct {
+	TraceID    TraceID
+	SpanID     SpanID
+	Flags      byte
+	TraceState string // Opaque vendor state, propagated unchanged
+}
+
+// IsValid reports whether both IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Sampled reports whether the sampled flag is set.
+func (sc SpanContext) Sampled() bool {
+	return sc.Flags&FlagSampled != 0
+}
+
+// Traceparent formats the context as a version-00 traceparent header.
+func (sc SpanContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", sc.TraceID, sc.SpanID, sc.Flags)
+}
+
+// ParseTraceparent parses a traceparent header ("00-<trace>-<span>-<flags>").
+// Future versions are accepted as long as the first four fields are valid.
+func ParseTraceparent(header string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+
+	version, traceHex, spanHex, flagsHex := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || version == "ff" || (version == "00" && len(parts) != 4) {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	if len(traceHex) != 32 || len(spanHex) != 16 || len(flagsHex) != 2 {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+
+	var sc SpanContext
+	if _, err := hex.Decode(sc.TraceID[:], []byte(traceHex)); err != nil {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(spanHex)); err != nil {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	var flags [1]byte
+	if _, err := hex.Decode(flags[:], []byte(flagsHex)); err != nil {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	sc.Flags = flags[0]
+
+	if !sc.IsValid() {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	return sc, nil
+}
+
+// Extract reads the span context from a metadata map (e.g., Event.Metadata).
+func Extract(metadata map[string]string) (SpanContext, bool) {
+	header, ok := metadata[TraceparentKey]
+	if !ok {
+		return SpanContext{}, false
+	}
+	sc, err := ParseTraceparent(header)
+	if err != nil {
+		return SpanContext{}, false
+	}
+	sc.TraceState = metadata[TracestateKey]
+	return sc, true
+}
+
+// Inject writes the span context into a metadata map.
+func Inject(metadata map[string]string, sc SpanContext) {
+	metadata[TraceparentKey] = sc.Traceparent()
+	if sc.TraceState != "" {
+		metadata[TracestateKey] = sc.TraceState
+	} else {
+		delete(metadata, TracestateKey)
+	}
+}
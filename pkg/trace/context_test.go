@@ -0,0 +1,162 @@
+package trace
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-json-experiment/json"
+)
+
+func TestParseTraceparent_RoundTrip(t *testing.T) {
+	header := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	sc, err := ParseTraceparent(header)
+	if err != nil {
+		t.Fatalf("ParseTraceparent failed: %v", err)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Unexpected trace ID %s", sc.TraceID)
+	}
+	if sc.SpanID.String() != "00f067aa0ba902b7" {
+		t.Errorf("Unexpected span ID %s", sc.SpanID)
+	}
+	if !sc.Sampled() {
+		t.Error("Expected sampled flag")
+	}
+	if got := sc.Traceparent(); got != header {
+		t.Errorf("Expected %s, got %s", header, got)
+	}
+}
+
+func TestParseTraceparent_Invalid(t *testing.T) {
+	cases := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",          // Missing flags
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",       // Forbidden version
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",       // Zero trace ID
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",       // Zero span ID
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",        // Short trace ID
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", // Extra field on v00
+		"00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",       // Not hex
+	}
+	for _, header := range cases {
+		if _, err := ParseTraceparent(header); err == nil {
+			t.Errorf("Expected error for %q", header)
+		}
+	}
+
+	// Future versions may append fields
+	if _, err := ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"); err != nil {
+		t.Errorf("Future version should parse: %v", err)
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	sc := SpanContext{TraceID: NewTraceID(), SpanID: NewSpanID(), Flags: FlagSampled, TraceState: "vendor=a"}
+	md := make(map[string]string)
+	Inject(md, sc)
+
+	got, ok := Extract(md)
+	if !ok {
+		t.Fatal("Extract failed")
+	}
+	if got != sc {
+		t.Errorf("Expected %+v, got %+v", sc, got)
+	}
+
+	if _, ok := Extract(map[string]string{TraceparentKey: "garbage"}); ok {
+		t.Error("Extract should reject an invalid traceparent")
+	}
+}
+
+func TestTracer_ParentingAndFlush(t *testing.T) {
+	exporter := NewInMemoryExporter()
+	tracer := NewTracer(exporter, WithBatchSize(10))
+
+	root := tracer.Start(SpanContext{}, "root", SpanKindProducer)
+	child := tracer.Start(root.Context(), "child", SpanKindInternal)
+	child.SetError(errTest).End()
+	root.End()
+	root.End() // No effect
+
+	if len(exporter.Spans()) != 0 {
+		t.Fatal("Spans should be buffered until the batch fills or Flush")
+	}
+	if err := tracer.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	spans := exporter.ByTrace(root.Context().TraceID)
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	for _, s := range spans {
+		if s.Name == "child" {
+			if s.Parent != root.Context().SpanID {
+				t.Error("Child should reference root as parent")
+			}
+			if s.Err != errTest.Error() {
+				t.Errorf("Expected error %q, got %q", errTest, s.Err)
+			}
+		}
+	}
+}
+
+func TestTracer_UnsampledParentIsNotRecorded(t *testing.T) {
+	exporter := NewInMemoryExporter()
+	tracer := NewTracer(exporter, WithBatchSize(1))
+
+	parent := SpanContext{TraceID: NewTraceID(), SpanID: NewSpanID()}
+	tracer.Start(parent, "skipped", SpanKindInternal).End()
+
+	if n := len(exporter.Spans()); n != 0 {
+		t.Errorf("Expected no spans for unsampled trace, got %d", n)
+	}
+}
+
+func TestMarshalOTLP(t *testing.T) {
+	start := time.Unix(0, 1000)
+	span := Span{
+		Name:       "publish test",
+		Kind:       SpanKindProducer,
+		Context:    SpanContext{TraceID: NewTraceID(), SpanID: NewSpanID(), Flags: FlagSampled},
+		Start:      start,
+		End:        start.Add(time.Microsecond),
+		Attributes: map[string]string{"bus": "external"},
+		Err:        "boom",
+	}
+
+	data, err := MarshalOTLP([]Span{span}, "svc")
+	if err != nil {
+		t.Fatalf("MarshalOTLP failed: %v", err)
+	}
+
+	var req otlpRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	got := spans[0]
+	if got.TraceID != span.Context.TraceID.String() || got.StartTimeUnixNano != "1000" || got.EndTimeUnixNano != "2000" {
+		t.Errorf("Unexpected span encoding: %+v", got)
+	}
+	if got.ParentSpanID != "" {
+		t.Error("Root span should omit parentSpanId")
+	}
+	if got.Status.Code != 2 || got.Status.Message != "boom" {
+		t.Errorf("Expected error status, got %+v", got.Status)
+	}
+	if !strings.Contains(string(data), `"service.name"`) {
+		t.Error("Expected service.name resource attribute")
+	}
+}
+
+type testError string
+
+func (e testError) Error() string { return string(e) }
+
+const errTest = testError("emit failed")
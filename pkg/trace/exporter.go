@@ -0,0 +1,230 @@
+package trace
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/go-json-experiment/json"
+)
+
+// InMemoryExporter keeps exported spans in memory for tests and offline inspection.
+type InMemoryExporter struct {
+	mu    sync.Mutex
+	spans []Span
+}
+
+// NewInMemoryExporter creates an empty in-memory exporter.
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{}
+}
+
+// Export appends spans.
+func (e *InMemoryExporter) Export(spans []Span) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+// Shutdown is a no-op.
+func (e *InMemoryExporter) Shutdown() error {
+	return nil
+}
+
+// Spans returns a copy of all exported spans.
+func (e *InMemoryExporter) Spans() []Span {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	result := make([]Span, len(e.spans))
+	copy(result, e.spans)
+	return result
+}
+
+// ByTrace returns exported spans belonging to one trace, ordered by start time.
+func (e *InMemoryExporter) ByTrace(traceID TraceID) []Span {
+	var result []Span
+	for _, s := range e.Spans() {
+		if s.Context.TraceID == traceID {
+			result = append(result, s)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Start.Before(result[j].Start)
+	})
+	return result
+}
+
+// Reset discards all exported spans.
+func (e *InMemoryExporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = nil
+}
+
+// FileExporter writes spans as OTLP-JSON, one ExportTraceServiceRequest per
+// line. The output can be loaded by OTLP file receivers (e.g., the
+// OpenTelemetry Collector "otlpjsonfile" receiver) or inspected with jq.
+type FileExporter struct {
+	mu          sync.Mutex
+	file        *os.File
+	w           *bufio.Writer
+	serviceName string
+}
+
+// NewFileExporter creates (or appends to) an OTLP-JSON span file.
+func NewFileExporter(path, serviceName string) (*FileExporter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("trace: open span file: %w", err)
+	}
+	return &FileExporter{
+		file:        f,
+		w:           bufio.NewWriter(f),
+		serviceName: serviceName,
+	}, nil
+}
+
+// Export writes one OTLP-JSON line for the batch.
+func (e *FileExporter) Export(spans []Span) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	data, err := MarshalOTLP(spans, e.serviceName)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, err := e.w.Write(data); err != nil {
+		return err
+	}
+	if err := e.w.WriteByte('\n'); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// Shutdown flushes and closes the file.
+func (e *FileExporter) Shutdown() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.w.Flush(); err != nil {
+		e.file.Close()
+		return err
+	}
+	return e.file.Close()
+}
+
+// OTLP-JSON wire structures (subset of opentelemetry-proto trace/v1).
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	TraceState        string         `json:"traceState,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"` // 0=unset, 2=error
+	Message string `json:"message,omitempty"`
+}
+
+// MarshalOTLP encodes spans as a single OTLP-JSON ExportTraceServiceRequest.
+func MarshalOTLP(spans []Span, serviceName string) ([]byte, error) {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		o := otlpSpan{
+			TraceID:           s.Context.TraceID.String(),
+			SpanID:            s.Context.SpanID.String(),
+			TraceState:        s.Context.TraceState,
+			Name:              s.Name,
+			Kind:              int(s.Kind),
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+			Attributes:        sortedAttributes(s.Attributes),
+		}
+		if s.Parent.IsValid() {
+			o.ParentSpanID = s.Parent.String()
+		}
+		if s.Err != "" {
+			o.Status = otlpStatus{Code: 2, Message: s.Err}
+		}
+		out = append(out, o)
+	}
+
+	req := otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{Attributes: []otlpKeyValue{
+				{Key: "service.name", Value: otlpValue{StringValue: serviceName}},
+			}},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/BYTE-6D65/pipeline"},
+				Spans: out,
+			}},
+		}},
+	}
+
+	return json.Marshal(req)
+}
+
+// sortedAttributes converts a map to OTLP attributes in key order.
+func sortedAttributes(attrs map[string]string) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]otlpKeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, otlpKeyValue{Key: k, Value: otlpValue{StringValue: attrs[k]}})
+	}
+	return kvs
+}
@@ -0,0 +1,234 @@
+package trace
+
+import (
+	"sync"
+	"time"
+)
+
+// SpanKind mirrors the OTLP span kinds used by the pipeline.
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1 // Work inside a component (filter, queue wait)
+	SpanKindProducer SpanKind = 4 // Publishing an event
+	SpanKindConsumer SpanKind = 5 // Receiving/handling an event
+)
+
+// Span stages recorded by the pipeline.
+const (
+	StagePublish   = "publish"
+	StageFilter    = "filter"
+	StageQueueWait = "queue_wait"
+	StageDeliver   = "deliver"
+	StageEmit      = "emit"
+)
+
+// Span is a finished unit of work.
+type Span struct {
+	Name       string
+	Kind       SpanKind
+	Context    SpanContext
+	Parent     SpanID // Zero for root spans
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]string
+	Err        string // Non-empty marks the span as failed
+}
+
+// Duration returns End - Start.
+func (s Span) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Exporter receives finished spans in batches.
+type Exporter interface {
+	// Export writes a batch of spans. It must not retain the slice.
+	Export(spans []Span) error
+
+	// Shutdown flushes and releases resources.
+	Shutdown() error
+}
+
+// Tracer creates spans and hands them to an exporter in batches.
+//
+// It is deliberately minimal: no sampling policy beyond the W3C sampled flag
+// on new root traces, no propagation formats beyond traceparent/tracestate,
+// and no dependency on an external tracing SDK.
+type Tracer struct {
+	exporter    Exporter
+	serviceName string
+	batchSize   int
+
+	mu      sync.Mutex
+	pending []Span
+	lastErr error
+}
+
+// TracerOption configures a Tracer.
+type TracerOption func(*Tracer)
+
+// WithServiceName sets the service.name resource attribute.
+func WithServiceName(name string) TracerOption {
+	return func(t *Tracer) {
+		t.serviceName = name
+	}
+}
+
+// WithBatchSize sets how many spans are buffered before export.
+func WithBatchSize(size int) TracerOption {
+	return func(t *Tracer) {
+		t.batchSize = size
+	}
+}
+
+// NewTracer creates a tracer exporting to exporter.
+// Defaults: service name "pipeline", batch size 64.
+func NewTracer(exporter Exporter, opts ...TracerOption) *Tracer {
+	t := &Tracer{
+		exporter:    exporter,
+		serviceName: "pipeline",
+		batchSize:   64,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.batchSize <= 0 {
+		t.batchSize = 1
+	}
+	return t
+}
+
+// ServiceName returns the configured service name.
+func (t *Tracer) ServiceName() string {
+	return t.serviceName
+}
+
+// ActiveSpan is a span that has started but not yet ended.
+type ActiveSpan struct {
+	tracer *Tracer
+	span   Span
+	once   sync.Once
+}
+
+// Start begins a span. If parent is valid the span joins its trace, otherwise
+// a new sampled trace is started.
+func (t *Tracer) Start(parent SpanContext, name string, kind SpanKind) *ActiveSpan {
+	return t.StartAt(parent, name, kind, time.Now())
+}
+
+// StartAt begins a span with an explicit start time (e.g., for queue waits
+// measured after the fact).
+func (t *Tracer) StartAt(parent SpanContext, name string, kind SpanKind, start time.Time) *ActiveSpan {
+	sc := SpanContext{SpanID: NewSpanID(), Flags: FlagSampled}
+	var parentID SpanID
+
+	if parent.IsValid() {
+		sc.TraceID = parent.TraceID
+		sc.Flags = parent.Flags
+		sc.TraceState = parent.TraceState
+		parentID = parent.SpanID
+	} else {
+		sc.TraceID = NewTraceID()
+	}
+
+	return &ActiveSpan{
+		tracer: t,
+		span: Span{
+			Name:       name,
+			Kind:       kind,
+			Context:    sc,
+			Parent:     parentID,
+			Start:      start,
+			Attributes: make(map[string]string),
+		},
+	}
+}
+
+// Context returns the span's context, for propagation to children.
+func (s *ActiveSpan) Context() SpanContext {
+	return s.span.Context
+}
+
+// SetAttribute records a key-value attribute.
+func (s *ActiveSpan) SetAttribute(key, value string) *ActiveSpan {
+	s.span.Attributes[key] = value
+	return s
+}
+
+// SetError marks the span as failed.
+func (s *ActiveSpan) SetError(err error) *ActiveSpan {
+	if err != nil {
+		s.span.Err = err.Error()
+	}
+	return s
+}
+
+// End finishes the span now. Calling End more than once has no effect.
+func (s *ActiveSpan) End() {
+	s.EndAt(time.Now())
+}
+
+// EndAt finishes the span at the given time.
+func (s *ActiveSpan) EndAt(end time.Time) {
+	s.once.Do(func() {
+		s.span.End = end
+		if s.span.Context.Sampled() {
+			s.tracer.record(s.span)
+		}
+	})
+}
+
+// record buffers a finished span and exports when the batch is full.
+func (t *Tracer) record(span Span) {
+	t.mu.Lock()
+	t.pending = append(t.pending, span)
+	var batch []Span
+	if len(t.pending) >= t.batchSize {
+		batch = t.pending
+		t.pending = nil
+	}
+	t.mu.Unlock()
+
+	if batch != nil {
+		t.export(batch)
+	}
+}
+
+// export sends a batch and remembers the last error.
+func (t *Tracer) export(batch []Span) {
+	if err := t.exporter.Export(batch); err != nil {
+		t.mu.Lock()
+		t.lastErr = err
+		t.mu.Unlock()
+	}
+}
+
+// Flush exports all buffered spans.
+func (t *Tracer) Flush() error {
+	t.mu.Lock()
+	batch := t.pending
+	t.pending = nil
+	t.mu.Unlock()
+
+	if len(batch) > 0 {
+		if err := t.exporter.Export(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown flushes buffered spans and shuts down the exporter.
+func (t *Tracer) Shutdown() error {
+	if err := t.Flush(); err != nil {
+		return err
+	}
+	return t.exporter.Shutdown()
+}
+
+// LastError returns the most recent background export error, if any.
+func (t *Tracer) LastError() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.lastErr
+}